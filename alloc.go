@@ -0,0 +1,165 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// Alignment is the byte alignment of the first element of a slice returned
+// by the Make functions. It must be a power of two.
+type Alignment int
+
+const (
+	Align16   Alignment = 16
+	Align32   Alignment = 32
+	Align64   Alignment = 64
+	AlignPage Alignment = 4096
+)
+
+// MakeFloat32s returns a slice of n float32 values with its first element
+// aligned to align bytes. The slice is held in the Go heap and needs no
+// freeing.
+func MakeFloat32s(n int, align Alignment) []float32 {
+	return unsafe.Slice((*float32)(alloc(n, 4, align)), n)
+}
+
+// MakeFloat64s returns a slice of n float64 values with its first element
+// aligned to align bytes. The slice is held in the Go heap and needs no
+// freeing.
+func MakeFloat64s(n int, align Alignment) []float64 {
+	return unsafe.Slice((*float64)(alloc(n, 8, align)), n)
+}
+
+// MakeComplex64s returns a slice of n complex64 values with its first
+// element aligned to align bytes. The slice is held in the Go heap and
+// needs no freeing.
+func MakeComplex64s(n int, align Alignment) []complex64 {
+	return unsafe.Slice((*complex64)(alloc(n, 8, align)), n)
+}
+
+// MakeComplex128s returns a slice of n complex128 values with its first
+// element aligned to align bytes. The slice is held in the Go heap and
+// needs no freeing.
+func MakeComplex128s(n int, align Alignment) []complex128 {
+	return unsafe.Slice((*complex128)(alloc(n, 16, align)), n)
+}
+
+// alloc returns a pointer to zeroed Go memory for n elements of size bytes
+// aligned to align bytes. The Go heap does not move objects, so the
+// alignment holds for the life of the memory.
+func alloc(n, size int, align Alignment) unsafe.Pointer {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	if align <= 0 || align&(align-1) != 0 {
+		panic("cblas: illegal alignment")
+	}
+	if n == 0 {
+		return nil
+	}
+	a := int(align)
+	if a < 8 {
+		a = 8
+	}
+	// Allocating words rather than bytes gives the natural alignment of
+	// the element types without the need to pad for it.
+	words := make([]uint64, (n*size+a-1)/8+1)
+	p := uintptr(unsafe.Pointer(&words[0]))
+	off := (uintptr(a) - p%uintptr(a)) % uintptr(a)
+	return unsafe.Pointer(&words[off/8])
+}
+
+var (
+	// ErrNoHugePages is returned by the Huge functions when memory
+	// mapping is not supported on the platform.
+	ErrNoHugePages = errors.New("cblas: huge page allocation not supported")
+
+	// ErrNotMapped is returned by Free for a slice that was not returned
+	// by a Huge function or that has already been freed.
+	ErrNotMapped = errors.New("cblas: slice not allocated by a Huge function")
+)
+
+// HugeFloat32s returns a slice of n float32 values in zeroed memory mapped
+// outside the Go heap and advised to be backed by transparent huge pages.
+// The slice is aligned to the huge page size and must be released with
+// Free.
+func HugeFloat32s(n int) ([]float32, error) {
+	p, err := mapHuge(n, 4)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*float32)(p), n), nil
+}
+
+// HugeFloat64s returns a slice of n float64 values in zeroed memory mapped
+// outside the Go heap and advised to be backed by transparent huge pages.
+// The slice is aligned to the huge page size and must be released with
+// Free.
+func HugeFloat64s(n int) ([]float64, error) {
+	p, err := mapHuge(n, 8)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*float64)(p), n), nil
+}
+
+// HugeComplex64s returns a slice of n complex64 values in zeroed memory
+// mapped outside the Go heap and advised to be backed by transparent huge
+// pages. The slice is aligned to the huge page size and must be released
+// with Free.
+func HugeComplex64s(n int) ([]complex64, error) {
+	p, err := mapHuge(n, 8)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*complex64)(p), n), nil
+}
+
+// HugeComplex128s returns a slice of n complex128 values in zeroed memory
+// mapped outside the Go heap and advised to be backed by transparent huge
+// pages. The slice is aligned to the huge page size and must be released
+// with Free.
+func HugeComplex128s(n int) ([]complex128, error) {
+	p, err := mapHuge(n, 16)
+	if err != nil {
+		return nil, err
+	}
+	return unsafe.Slice((*complex128)(p), n), nil
+}
+
+// Free releases the memory of a []float32, []float64, []complex64 or
+// []complex128 returned by a Huge function. The slice and any slices
+// sharing its memory must not be used after Free returns. Freeing an
+// empty slice is a no-op.
+func Free(s interface{}) error {
+	var p unsafe.Pointer
+	switch s := s.(type) {
+	case []float32:
+		if cap(s) == 0 {
+			return nil
+		}
+		p = unsafe.Pointer(&s[:1][0])
+	case []float64:
+		if cap(s) == 0 {
+			return nil
+		}
+		p = unsafe.Pointer(&s[:1][0])
+	case []complex64:
+		if cap(s) == 0 {
+			return nil
+		}
+		p = unsafe.Pointer(&s[:1][0])
+	case []complex128:
+		if cap(s) == 0 {
+			return nil
+		}
+		p = unsafe.Pointer(&s[:1][0])
+	default:
+		panic("cblas: unsupported type")
+	}
+	return unmap(p)
+}
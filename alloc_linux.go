@@ -0,0 +1,67 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"math"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// hugePage is the size of a transparent huge page.
+const hugePage = 2 << 20
+
+// mappings holds the mappings made by mapHuge keyed by the address of
+// the first element handed out.
+var mappings struct {
+	sync.Mutex
+	m map[unsafe.Pointer][]byte
+}
+
+// mapHuge maps memory for n elements of size bytes aligned to a huge page
+// and advises the kernel to back it with huge pages.
+func mapHuge(n, size int) (unsafe.Pointer, error) {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	if n == 0 {
+		return nil, nil
+	}
+	if n > (math.MaxInt-2*hugePage)/size {
+		return nil, syscall.ENOMEM
+	}
+	length := (n*size + hugePage - 1) &^ (hugePage - 1)
+	// Over-map by a huge page so the used region can be aligned to
+	// one; mmap only guarantees page alignment.
+	b, err := syscall.Mmap(-1, 0, length+hugePage, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_PRIVATE|syscall.MAP_ANON)
+	if err != nil {
+		return nil, err
+	}
+	off := (hugePage - int(uintptr(unsafe.Pointer(&b[0]))%hugePage)) % hugePage
+	// The advice fails on kernels without transparent huge page
+	// support. The memory is still usable, so the error is ignored.
+	syscall.Madvise(b[off:off+length], syscall.MADV_HUGEPAGE)
+	p := unsafe.Pointer(&b[off])
+	mappings.Lock()
+	if mappings.m == nil {
+		mappings.m = make(map[unsafe.Pointer][]byte)
+	}
+	mappings.m[p] = b
+	mappings.Unlock()
+	return p, nil
+}
+
+// unmap unmaps the mapping made by mapHuge that starts at p.
+func unmap(p unsafe.Pointer) error {
+	mappings.Lock()
+	b, ok := mappings.m[p]
+	delete(mappings.m, p)
+	mappings.Unlock()
+	if !ok {
+		return ErrNotMapped
+	}
+	return syscall.Munmap(b)
+}
@@ -0,0 +1,73 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"math"
+	"testing"
+	"unsafe"
+)
+
+func TestHuge(t *testing.T) {
+	const n = 3 << 20
+	s32, err := HugeFloat32s(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	s64, err := HugeFloat64s(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c64, err := HugeComplex64s(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c128, err := HugeComplex128s(n)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, p := range []unsafe.Pointer{unsafe.Pointer(&s32[0]), unsafe.Pointer(&s64[0]), unsafe.Pointer(&c64[0]), unsafe.Pointer(&c128[0])} {
+		if uintptr(p)%hugePage != 0 {
+			t.Errorf("slice %d at %p is not aligned to a huge page", i, p)
+		}
+	}
+	if len(s32) != n || len(s64) != n || len(c64) != n || len(c128) != n {
+		t.Errorf("unexpected lengths: %d %d %d %d", len(s32), len(s64), len(c64), len(c128))
+	}
+	for _, i := range []int{0, n / 2, n - 1} {
+		if s32[i] != 0 || s64[i] != 0 || c64[i] != 0 || c128[i] != 0 {
+			t.Errorf("element %d not zero", i)
+		}
+		s32[i], s64[i], c64[i], c128[i] = 1, 1, 1, 1
+	}
+
+	for _, s := range []interface{}{s32, s64, c64, c128} {
+		if err := Free(s); err != nil {
+			t.Errorf("unexpected error freeing %T: %v", s, err)
+		}
+		if err := Free(s); err != ErrNotMapped {
+			t.Errorf("unexpected error freeing %T twice: got %v want %v", s, err, ErrNotMapped)
+		}
+	}
+
+	// A subslice not starting at the first element was not mapped.
+	s, err := HugeFloat64s(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := Free(s[1:]); err != ErrNotMapped {
+		t.Errorf("unexpected error freeing subslice: got %v want %v", err, ErrNotMapped)
+	}
+	if err := Free(s); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if s, err := HugeFloat32s(0); s != nil || err != nil {
+		t.Errorf("unexpected result for zero length: %v, %v", s, err)
+	}
+	if s, err := HugeComplex128s(math.MaxInt / 16); s != nil || err == nil {
+		t.Errorf("unexpected result for impossible length: len=%d, %v", len(s), err)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package cblas
+
+import "unsafe"
+
+func mapHuge(n, size int) (unsafe.Pointer, error) {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	return nil, ErrNoHugePages
+}
+
+func unmap(p unsafe.Pointer) error {
+	return ErrNotMapped
+}
@@ -0,0 +1,80 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"testing"
+	"unsafe"
+)
+
+func TestMake(t *testing.T) {
+	for _, align := range []Alignment{1, 8, Align16, Align32, Align64, AlignPage} {
+		for _, n := range []int{0, 1, 3, 100} {
+			var ptrs []unsafe.Pointer
+			s32, s64 := MakeFloat32s(n, align), MakeFloat64s(n, align)
+			c64, c128 := MakeComplex64s(n, align), MakeComplex128s(n, align)
+			lens := []int{len(s32), len(s64), len(c64), len(c128)}
+			if n != 0 {
+				ptrs = []unsafe.Pointer{unsafe.Pointer(&s32[0]), unsafe.Pointer(&s64[0]), unsafe.Pointer(&c64[0]), unsafe.Pointer(&c128[0])}
+			}
+			for i, l := range lens {
+				if l != n {
+					t.Errorf("align=%d n=%d: slice %d has length %d", align, n, i, l)
+				}
+			}
+			for i, p := range ptrs {
+				if uintptr(p)%uintptr(align) != 0 {
+					t.Errorf("align=%d n=%d: slice %d at %p is not aligned", align, n, i, p)
+				}
+			}
+			for i := range s64 {
+				if s32[i] != 0 || s64[i] != 0 || c64[i] != 0 || c128[i] != 0 {
+					t.Errorf("align=%d n=%d: element %d not zero", align, n, i)
+				}
+				// The whole slice must be writable.
+				s32[i], s64[i], c64[i], c128[i] = 1, 1, 1, 1
+			}
+			if n != 0 {
+				if err := Free(s64); err != ErrNotMapped {
+					t.Errorf("align=%d n=%d: unexpected error freeing Go memory: got %v want %v", align, n, err, ErrNotMapped)
+				}
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		n      int
+		align  Alignment
+		panics string
+	}{
+		{n: -1, align: Align16, panics: "cblas: n < 0"},
+		{n: 1, align: 0, panics: "cblas: illegal alignment"},
+		{n: 1, align: 24, panics: "cblas: illegal alignment"},
+		{n: 1, align: -16, panics: "cblas: illegal alignment"},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != test.panics {
+					t.Errorf("n=%d align=%d: unexpected panic: got %v want %q", test.n, test.align, r, test.panics)
+				}
+			}()
+			MakeFloat64s(test.n, test.align)
+		}()
+	}
+}
+
+func TestFreeEmpty(t *testing.T) {
+	for _, s := range []interface{}{[]float32(nil), []float64{}, []complex64(nil), []complex128{}} {
+		if err := Free(s); err != nil {
+			t.Errorf("unexpected error freeing %T: %v", s, err)
+		}
+	}
+	defer func() {
+		if r := recover(); r != "cblas: unsupported type" {
+			t.Errorf("unexpected panic: got %v", r)
+		}
+	}()
+	Free([]int{1})
+}
@@ -0,0 +1,369 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include "cblas.h"
+
+enum {
+	op_daxpy,
+	op_dscal,
+	op_dcopy,
+	op_dswap,
+	op_drot,
+	op_ddot,
+	op_dnrm2,
+	op_dasum,
+	op_idamax,
+	op_dgemv,
+	op_dger,
+};
+
+typedef struct {
+	int op, order, trans;
+	int m, n, incX, incY, lda;
+	int result;
+	double alpha, beta;
+	double *x, *y, *a;
+} batch_op;
+
+static void run_batch(const batch_op *ops, int len, double *values, int *indices) {
+	for (int i = 0; i < len; i++) {
+		const batch_op *p = &ops[i];
+		switch (p->op) {
+		case op_daxpy:
+			cblas_daxpy(p->n, p->alpha, p->x, p->incX, p->y, p->incY);
+			break;
+		case op_dscal:
+			cblas_dscal(p->n, p->alpha, p->x, p->incX);
+			break;
+		case op_dcopy:
+			cblas_dcopy(p->n, p->x, p->incX, p->y, p->incY);
+			break;
+		case op_dswap:
+			cblas_dswap(p->n, p->x, p->incX, p->y, p->incY);
+			break;
+		case op_drot:
+			cblas_drot(p->n, p->x, p->incX, p->y, p->incY, p->alpha, p->beta);
+			break;
+		case op_ddot:
+			values[p->result] = cblas_ddot(p->n, p->x, p->incX, p->y, p->incY);
+			break;
+		case op_dnrm2:
+			values[p->result] = cblas_dnrm2(p->n, p->x, p->incX);
+			break;
+		case op_dasum:
+			values[p->result] = cblas_dasum(p->n, p->x, p->incX);
+			break;
+		case op_idamax:
+			indices[p->result] = cblas_idamax(p->n, p->x, p->incX);
+			break;
+		case op_dgemv:
+			cblas_dgemv((enum CBLAS_ORDER)p->order, (enum CBLAS_TRANSPOSE)p->trans, p->m, p->n,
+				p->alpha, p->a, p->lda, p->x, p->incX, p->beta, p->y, p->incY);
+			break;
+		case op_dger:
+			cblas_dger((enum CBLAS_ORDER)p->order, p->m, p->n,
+				p->alpha, p->x, p->incX, p->y, p->incY, p->a, p->lda);
+			break;
+		}
+	}
+}
+*/
+import "C"
+
+import (
+	"runtime"
+
+	"github.com/gonum/blas"
+)
+
+// Batch records a sequence of double precision Level 1 and Level 2
+// operations and runs them in a single call into C, saving the cost of a
+// cgo call for each operation. The arguments of each operation are
+// checked as for the Blas method of the same name when it is recorded,
+// and as there a Dscal with a negative increment does nothing and a
+// Dnrm2, Dasum or Idamax with a negative increment gives zero or -1.
+// The operations refer to the slices they are given, not to copies, and
+// are run in the order they were recorded, so the results of one
+// operation may be used by the next. A Batch may be run many times; an
+// iterative solver would record the operations of one iteration and run
+// them once for each.
+//
+// The reductions return the index of their result in the Results of a
+// run. The library is called directly, so calls below the thresholds of
+// the Go kernels are not run in Go, except that a Batch holding a Dnrm2
+// is run by calling the Blas methods one by one when the library's nrm2
+// is not used, as described by SetNrm2Mode.
+//
+// The zero Batch is empty and ready to use. A Batch must not be used
+// concurrently.
+type Batch struct {
+	ops     []batchOp
+	values  int
+	indices int
+	nrm2    bool
+
+	// none holds the indices of the results of Idamax calls with a
+	// negative increment, which are -1.
+	none []int
+
+	// cops holds the operations passed to C, kept between runs to
+	// avoid allocation.
+	cops []C.batch_op
+}
+
+// batchOp is an operation recorded by a Batch. The scalars of Drot are
+// held in alpha and beta.
+type batchOp struct {
+	op                    C.int
+	o                     blas.Order
+	tA                    blas.Transpose
+	m, n, incX, incY, lda int
+	result                int
+	alpha, beta           float64
+	x, y, a               []float64
+}
+
+// Results holds the results of the reductions of a run of a Batch.
+type Results struct {
+	// Values holds the results of Ddot, Dnrm2 and Dasum, indexed by
+	// the index returned when they were recorded.
+	Values []float64
+
+	// Indices holds the results of Idamax, indexed by the index
+	// returned when it was recorded.
+	Indices []int
+}
+
+// Len returns the number of operations recorded in the batch.
+func (b *Batch) Len() int { return len(b.ops) }
+
+// Reset removes all operations from the batch.
+func (b *Batch) Reset() {
+	for i := range b.cops {
+		b.cops[i] = C.batch_op{}
+	}
+	*b = Batch{ops: b.ops[:0], cops: b.cops[:0], none: b.none[:0]}
+}
+
+// Daxpy records y = alpha * x + y.
+func (b *Batch) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	b.ops = append(b.ops, batchOp{op: C.op_daxpy, n: n, alpha: alpha, x: x, incX: incX, y: y, incY: incY})
+}
+
+// Dscal records x = alpha * x.
+func (b *Batch) Dscal(n int, alpha float64, x []float64, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	b.ops = append(b.ops, batchOp{op: C.op_dscal, n: n, alpha: alpha, x: x, incX: incX})
+}
+
+// Dcopy records y = x.
+func (b *Batch) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	b.ops = append(b.ops, batchOp{op: C.op_dcopy, n: n, x: x, incX: incX, y: y, incY: incY})
+}
+
+// Dswap records the exchange of x and y.
+func (b *Batch) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	b.ops = append(b.ops, batchOp{op: C.op_dswap, n: n, x: x, incX: incX, y: y, incY: incY})
+}
+
+// Drot records the application of the plane rotation (c, s) to x and y.
+func (b *Batch) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	b.ops = append(b.ops, batchOp{op: C.op_drot, n: n, x: x, incX: incX, y: y, incY: incY, alpha: c, beta: s})
+}
+
+// Ddot records the dot product of x and y and returns the index of its
+// result in Results.Values.
+func (b *Batch) Ddot(n int, x []float64, incX int, y []float64, incY int) int {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	b.ops = append(b.ops, batchOp{op: C.op_ddot, n: n, x: x, incX: incX, y: y, incY: incY, result: b.values})
+	b.values++
+	return b.values - 1
+}
+
+// Dnrm2 records the Euclidean norm of x and returns the index of its
+// result in Results.Values.
+func (b *Batch) Dnrm2(n int, x []float64, incX int) int {
+	i := b.values
+	b.values++
+	if !checkReduction(n, len(x), incX) {
+		return i
+	}
+	b.ops = append(b.ops, batchOp{op: C.op_dnrm2, n: n, x: x, incX: incX, result: i})
+	b.nrm2 = true
+	return i
+}
+
+// Dasum records the sum of the absolute values of x and returns the index
+// of its result in Results.Values.
+func (b *Batch) Dasum(n int, x []float64, incX int) int {
+	i := b.values
+	b.values++
+	if !checkReduction(n, len(x), incX) {
+		return i
+	}
+	b.ops = append(b.ops, batchOp{op: C.op_dasum, n: n, x: x, incX: incX, result: i})
+	return i
+}
+
+// Idamax records the index of the element of x with the largest absolute
+// value and returns the index of its result in Results.Indices.
+func (b *Batch) Idamax(n int, x []float64, incX int) int {
+	i := b.indices
+	b.indices++
+	if !checkReduction(n, len(x), incX) {
+		b.none = append(b.none, i)
+		return i
+	}
+	b.ops = append(b.ops, batchOp{op: C.op_idamax, n: n, x: x, incX: incX, result: i})
+	return i
+}
+
+// Dgemv records y = alpha * op(A) * x + beta * y.
+func (b *Batch) Dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if m < 0 {
+		panic("cblas: m < 0")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	b.ops = append(b.ops, batchOp{op: C.op_dgemv, o: o, tA: tA, m: m, n: n, alpha: alpha, a: a, lda: lda, x: x, incX: incX, beta: beta, y: y, incY: incY})
+}
+
+// Dger records A = alpha * x * y^T + A.
+func (b *Batch) Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
+	}
+	if m < 0 {
+		panic("cblas: m < 0")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	b.ops = append(b.ops, batchOp{op: C.op_dger, o: o, m: m, n: n, alpha: alpha, x: x, incX: incX, y: y, incY: incY, a: a, lda: lda})
+}
+
+// Run runs the operations of the batch in order and returns the results
+// of its reductions.
+func (b *Batch) Run() Results {
+	r := Results{
+		Values:  make([]float64, b.values),
+		Indices: make([]int, b.indices),
+	}
+	for _, i := range b.none {
+		r.Indices[i] = -1
+	}
+	if len(b.ops) == 0 {
+		return r
+	}
+	if b.nrm2 && safeNrm2() {
+		b.runGo(r)
+		return r
+	}
+
+	// The C operations point to the Go slices, which must be pinned
+	// for the call since the operations are themselves in Go memory.
+	var pin runtime.Pinner
+	defer pin.Unpin()
+	ptr := func(s []float64) *C.double {
+		if len(s) == 0 {
+			return nil
+		}
+		pin.Pin(&s[0])
+		return (*C.double)(&s[0])
+	}
+	b.cops = b.cops[:0]
+	for _, op := range b.ops {
+		b.cops = append(b.cops, C.batch_op{
+			op:     op.op,
+			order:  C.int(op.o),
+			trans:  C.int(op.tA),
+			m:      C.int(op.m),
+			n:      C.int(op.n),
+			incX:   C.int(op.incX),
+			incY:   C.int(op.incY),
+			lda:    C.int(op.lda),
+			result: C.int(op.result),
+			alpha:  C.double(op.alpha),
+			beta:   C.double(op.beta),
+			x:      ptr(op.x),
+			y:      ptr(op.y),
+			a:      ptr(op.a),
+		})
+	}
+	var values *C.double
+	if len(r.Values) != 0 {
+		values = (*C.double)(&r.Values[0])
+	}
+	indices := make([]C.int, len(r.Indices)+1)
+	for i, v := range r.Indices {
+		indices[i] = C.int(v)
+	}
+	C.run_batch(&b.cops[0], C.int(len(b.cops)), values, &indices[0])
+	for i := range r.Indices {
+		r.Indices[i] = int(indices[i])
+	}
+	return r
+}
+
+// runGo runs the operations of the batch with the Blas methods.
+func (b *Batch) runGo(r Results) {
+	var impl Blas
+	for _, op := range b.ops {
+		switch op.op {
+		case C.op_daxpy:
+			impl.Daxpy(op.n, op.alpha, op.x, op.incX, op.y, op.incY)
+		case C.op_dscal:
+			impl.Dscal(op.n, op.alpha, op.x, op.incX)
+		case C.op_dcopy:
+			impl.Dcopy(op.n, op.x, op.incX, op.y, op.incY)
+		case C.op_dswap:
+			impl.Dswap(op.n, op.x, op.incX, op.y, op.incY)
+		case C.op_drot:
+			impl.Drot(op.n, op.x, op.incX, op.y, op.incY, op.alpha, op.beta)
+		case C.op_ddot:
+			r.Values[op.result] = impl.Ddot(op.n, op.x, op.incX, op.y, op.incY)
+		case C.op_dnrm2:
+			r.Values[op.result] = impl.Dnrm2(op.n, op.x, op.incX)
+		case C.op_dasum:
+			r.Values[op.result] = impl.Dasum(op.n, op.x, op.incX)
+		case C.op_idamax:
+			r.Indices[op.result] = impl.Idamax(op.n, op.x, op.incX)
+		case C.op_dgemv:
+			impl.Dgemv(op.o, op.tA, op.m, op.n, op.alpha, op.a, op.lda, op.x, op.incX, op.beta, op.y, op.incY)
+		case C.op_dger:
+			impl.Dger(op.o, op.m, op.n, op.alpha, op.x, op.incX, op.y, op.incY, op.a, op.lda)
+		}
+	}
+}
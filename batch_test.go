@@ -0,0 +1,195 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// batcher is the recording interface of Batch.
+type batcher interface {
+	Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int)
+	Dscal(n int, alpha float64, x []float64, incX int)
+	Dcopy(n int, x []float64, incX int, y []float64, incY int)
+	Dswap(n int, x []float64, incX int, y []float64, incY int)
+	Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64)
+	Ddot(n int, x []float64, incX int, y []float64, incY int) int
+	Dnrm2(n int, x []float64, incX int) int
+	Dasum(n int, x []float64, incX int) int
+	Idamax(n int, x []float64, incX int) int
+	Dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int)
+	Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int)
+}
+
+// direct is a batcher that calls the Blas methods immediately, collecting
+// the results of the reductions.
+type direct struct {
+	Blas
+	r Results
+}
+
+func (d *direct) Ddot(n int, x []float64, incX int, y []float64, incY int) int {
+	d.r.Values = append(d.r.Values, d.Blas.Ddot(n, x, incX, y, incY))
+	return len(d.r.Values) - 1
+}
+
+func (d *direct) Dnrm2(n int, x []float64, incX int) int {
+	d.r.Values = append(d.r.Values, d.Blas.Dnrm2(n, x, incX))
+	return len(d.r.Values) - 1
+}
+
+func (d *direct) Dasum(n int, x []float64, incX int) int {
+	d.r.Values = append(d.r.Values, d.Blas.Dasum(n, x, incX))
+	return len(d.r.Values) - 1
+}
+
+func (d *direct) Idamax(n int, x []float64, incX int) int {
+	d.r.Indices = append(d.r.Indices, d.Blas.Idamax(n, x, incX))
+	return len(d.r.Indices) - 1
+}
+
+// batchOperands are the operands of the operations recorded by
+// recordBatch.
+type batchOperands struct {
+	x, y, w, a []float64
+}
+
+func newBatchOperands(rnd *rand.Rand, o blas.Order) *batchOperands {
+	// A is 3×4 with a leading dimension one larger than needed and
+	// without padding after its last row or column.
+	return &batchOperands{
+		x: random(rnd, 6),
+		y: random(rnd, 11),
+		w: random(rnd, 6),
+		a: random(rnd, size(o, 3, 4, ld(o, 3, 4))-1),
+	}
+}
+
+func (v *batchOperands) clone() *batchOperands {
+	return &batchOperands{
+		x: append([]float64(nil), v.x...),
+		y: append([]float64(nil), v.y...),
+		w: append([]float64(nil), v.w...),
+		a: append([]float64(nil), v.a...),
+	}
+}
+
+// recordBatch records a sequence of operations on v with positive and
+// negative increments, whose results depend on those before them.
+func recordBatch(b batcher, o blas.Order, v *batchOperands) {
+	const n = 6
+	lda := ld(o, 3, 4)
+	b.Dcopy(n, v.x, 1, v.w, -1)
+	b.Daxpy(n, 0.5, v.x, 1, v.y, -2)
+	b.Dscal(n, 2, v.y, 2)
+	b.Dscal(n, 3, v.x, -1)
+	b.Drot(n, v.x, 1, v.y, 2, 0.6, 0.8)
+	b.Dswap(n, v.w, 1, v.x, -1)
+	b.Ddot(n, v.x, -1, v.y, 2)
+	b.Dnrm2(n, v.y, 2)
+	b.Dnrm2(n, v.y, -2)
+	b.Dasum(n, v.x, 1)
+	b.Dasum(n, v.x, -1)
+	b.Idamax(n, v.y, 2)
+	b.Idamax(n, v.y, -2)
+	b.Dgemv(o, blas.NoTrans, 3, 4, 1.5, v.a, lda, v.x, 1, 0.5, v.y, -1)
+	b.Dger(o, 3, 4, -0.5, v.y, 3, v.w, -1, v.a, lda)
+	b.Dgemv(o, blas.Trans, 3, 4, 1, v.a, lda, v.x, -2, 0, v.w, 1)
+	b.Ddot(n, v.w, 1, v.x, 1)
+	b.Idamax(4, v.a, 1)
+}
+
+// TestBatch tests that running a Batch gives the results of calling the
+// Blas methods one by one, with the library's and with the portable nrm2.
+func TestBatch(t *testing.T) {
+	defer SetNrm2Mode(Nrm2Auto)
+	for _, mode := range []Nrm2Mode{Nrm2Library, Nrm2Safe} {
+		SetNrm2Mode(mode)
+		for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+			rnd := rand.New(rand.NewSource(1))
+			got := newBatchOperands(rnd, o)
+			want := got.clone()
+
+			var b Batch
+			recordBatch(&b, o, got)
+			for run := 0; run < 2; run++ {
+				d := &direct{}
+				recordBatch(d, o, want)
+				r := b.Run()
+				desc := fmt.Sprintf("mode=%d order=%v run=%d", mode, o, run)
+				same(t, desc+" x", got.x, want.x, 6)
+				same(t, desc+" y", got.y, want.y, 6)
+				same(t, desc+" w", got.w, want.w, 6)
+				same(t, desc+" a", got.a, want.a, 6)
+				same(t, desc+" values", r.Values, d.r.Values, 6)
+				if fmt.Sprint(r.Indices) != fmt.Sprint(d.r.Indices) {
+					t.Errorf("%s: unexpected indices: got %v want %v", desc, r.Indices, d.r.Indices)
+				}
+			}
+			for _, i := range []int{2, 4} {
+				if r := b.Run(); r.Values[i] != 0 {
+					t.Errorf("unexpected result for negative increment: got %v want 0", r.Values[i])
+				}
+			}
+			if r := b.Run(); r.Indices[1] != -1 {
+				t.Errorf("unexpected index for negative increment: got %v want -1", r.Indices[1])
+			}
+
+			b.Reset()
+			if b.Len() != 0 {
+				t.Errorf("unexpected length after Reset: %d", b.Len())
+			}
+			if r := b.Run(); len(r.Values) != 0 || len(r.Indices) != 0 {
+				t.Errorf("unexpected results after Reset: %+v", r)
+			}
+		}
+	}
+}
+
+// TestBatchCheck tests that the arguments of the Level 2 operations of a
+// Batch are checked as for Blas, with a 2×3 A.
+func TestBatchCheck(t *testing.T) {
+	x, y := make([]float64, 3), make([]float64, 3)
+	for _, test := range []struct {
+		o      blas.Order
+		tA     blas.Transpose
+		lda    int
+		lenA   int
+		panics string
+	}{
+		{o: blas.RowMajor, tA: 'x', lda: 3, lenA: 6, panics: "cblas: illegal transpose"},
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 2, lenA: 6, panics: "cblas: index out of range"},
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 4, lenA: 6, panics: "cblas: index out of range"},
+		{o: blas.ColMajor, tA: blas.NoTrans, lda: 1, lenA: 6, panics: "cblas: index out of range"},
+		{o: blas.ColMajor, tA: blas.NoTrans, lda: 3, lenA: 7, panics: "cblas: index out of range"},
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 4, lenA: 7},
+		{o: blas.ColMajor, tA: blas.Trans, lda: 3, lenA: 8},
+	} {
+		for _, name := range []string{"Dgemv", "Dger"} {
+			if name == "Dger" && test.tA != blas.NoTrans {
+				continue
+			}
+			func() {
+				defer func() {
+					r := recover()
+					if r == nil && test.panics != "" || r != nil && r != test.panics {
+						t.Errorf("%s %v %v lda=%d len=%d: unexpected panic: got %v want %q", name, test.o, test.tA, test.lda, test.lenA, r, test.panics)
+					}
+				}()
+				var b Batch
+				a := make([]float64, test.lenA)
+				if name == "Dgemv" {
+					b.Dgemv(test.o, test.tA, 2, 3, 1, a, test.lda, x, 1, 0, y, -1)
+				} else {
+					b.Dger(test.o, 2, 3, 1, x, -1, y, 1, a, test.lda)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// BenchmarkCalls benchmarks calls of Blas methods on small operands held
+// in arrays local to each iteration, measuring the cost of calling into C.
+// The thresholds of the Go kernels are set to zero while it runs so that
+// every call is made to the library.
+//
+// When built with go1.24 or later the CBLAS functions are marked noescape
+// and nocallback, the operands stay on the stack and the calls do not
+// allocate. With earlier versions the operands of each iteration are
+// allocated on the heap.
+func BenchmarkCalls(b *testing.B) {
+	for _, name := range Thresholds() {
+		defer SetThreshold(name, SetThreshold(name, 0))
+	}
+	var impl Blas
+	for _, n := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("Ddot/N=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				x := [16]float64{1, 2, 3}
+				y := [16]float64{3, 2, 1}
+				sink += impl.Ddot(n, x[:], 1, y[:], 1)
+			}
+		})
+	}
+	b.Run("Daxpy/N=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x := [4]float64{1, 2, 3, 4}
+			y := [4]float64{4, 3, 2, 1}
+			impl.Daxpy(4, 0.5, x[:], 1, y[:], 1)
+			sink += y[0]
+		}
+	})
+	b.Run("Dscal/N=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x := [4]float64{1, 2, 3, 4}
+			impl.Dscal(4, 0.5, x[:], 1)
+			sink += x[0]
+		}
+	})
+	b.Run("Dnrm2/N=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x := [4]float64{1, 2, 3, 4}
+			sink += impl.Dnrm2(4, x[:], 1)
+		}
+	})
+	b.Run("Dgemv/N=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			a := [16]float64{1, 2, 3, 4, 5, 6, 7, 8}
+			x := [4]float64{1, 2, 3, 4}
+			y := [4]float64{}
+			impl.Dgemv(blas.RowMajor, blas.NoTrans, 4, 4, 1, a[:], 4, x[:], 1, 0, y[:], 1)
+			sink += y[0]
+		}
+	})
+	b.Run("Zdotc/N=4", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x := [4]complex128{1, 2i, 3, 4i}
+			y := [4]complex128{4i, 3, 2i, 1}
+			sink += real(impl.Zdotc(4, x[:], 1, y[:], 1))
+		}
+	})
+}
+
+// sink receives the results of the benchmarked calls.
+var sink float64
@@ -9,7 +9,6 @@ package cblas
 
 /*
 #cgo CFLAGS: -g -O2 -fPIC -m64 -pthread
-#cgo LDFLAGS: -L/usr/lib/ -lblas
 #include "cblas.h"
 */
 import "C"
@@ -34,6 +33,24 @@ func max(a, b int) int {
 	return b
 }
 
+// checkOrdered checks an r×c matrix in the order o with leading dimension
+// ld held in a slice of length l.
+func checkOrdered(o blas.Order, r, c, l, ld int) {
+	if o == blas.ColMajor {
+		r, c = c, r
+	}
+	checkMatrix(r, c, l, ld)
+}
+
+// Blas implements the blas interfaces by calling the CBLAS library.
+//
+// The arguments of each method are checked before the library is called,
+// and illegal arguments cause a panic with a "cblas: " message rather than
+// a call to the error handler of the library. A negative increment steps
+// backwards through a vector, starting from its last element, as defined
+// by BLAS; earlier versions of this package panicked instead. As in the
+// reference BLAS, the nrm2 and asum methods return zero, the scal methods
+// do nothing and the i?amax methods return -1 for a negative increment.
 type Blas struct{}
 
 // Special cases...
@@ -48,16 +65,9 @@ func (Blas) Srotmg(d1 float32, d2 float32, b1 float32, b2 float32) (p *blas.Srot
 	return p, d1, d2, b1
 }
 func (Blas) Srotm(n int, x []float32, incX int, y []float32, incY int, p *blas.SrotmParams) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_srotm(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(unsafe.Pointer(p)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_srotm(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY), (*C.float)(unsafe.Pointer(p)))
 }
 func (Blas) Drotg(a float64, b float64) (c float64, s float64, r float64, z float64) {
 	C.cblas_drotg((*C.double)(&a), (*C.double)(&b), (*C.double)(&c), (*C.double)(&s))
@@ -69,482 +79,302 @@ func (Blas) Drotmg(d1 float64, d2 float64, b1 float64, b2 float64) (p *blas.Drot
 	return p, d1, d2, b1
 }
 func (Blas) Drotm(n int, x []float64, incX int, y []float64, incY int, p *blas.DrotmParams) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_drotm(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(unsafe.Pointer(p)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_drotm(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY), (*C.double)(unsafe.Pointer(p)))
 }
 func (Blas) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cdotu_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotu))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotu_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotu))
 	return dotu
 }
 func (Blas) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cdotc_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotc))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotc_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotc))
 	return dotc
 }
 func (Blas) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zdotu_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotu))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotu_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotu))
 	return dotu
 }
 func (Blas) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zdotc_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotc))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotc_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotc))
 	return dotc
 }
 
 func (Blas) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	return float32(C.cblas_sdsdot(C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float32(C.cblas_sdsdot(C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY)))
 }
 func (Blas) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	return float64(C.cblas_dsdot(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float64(C.cblas_dsdot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY)))
 }
 func (Blas) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	if below(&smallSdot, n) {
+		return sdot(n, x, incX, y, incY)
 	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	return float32(C.cblas_sdot(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY)))
+	return float32(C.cblas_sdot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY)))
 }
 func (Blas) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	if below(&smallDdot, n) {
+		return ddot(n, x, incX, y, incY)
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	return float64(C.cblas_ddot(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY)))
+	return float64(C.cblas_ddot(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY)))
 }
 func (Blas) Snrm2(n int, x []float32, incX int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if safeNrm2() {
+		return snrm2(n, x, incX)
 	}
-	return float32(C.cblas_snrm2(C.int(n), (*C.float)(&x[0]), C.int(incX)))
+	return float32(C.cblas_snrm2(C.int(n), f32(x), C.int(incX)))
 }
 func (Blas) Sasum(n int, x []float32, incX int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	return float32(C.cblas_sasum(C.int(n), (*C.float)(&x[0]), C.int(incX)))
+	return float32(C.cblas_sasum(C.int(n), f32(x), C.int(incX)))
 }
 func (Blas) Dnrm2(n int, x []float64, incX int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if safeNrm2() {
+		return dnrm2(n, x, incX)
 	}
-	return float64(C.cblas_dnrm2(C.int(n), (*C.double)(&x[0]), C.int(incX)))
+	return float64(C.cblas_dnrm2(C.int(n), f64(x), C.int(incX)))
 }
 func (Blas) Dasum(n int, x []float64, incX int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	return float64(C.cblas_dasum(C.int(n), (*C.double)(&x[0]), C.int(incX)))
+	return float64(C.cblas_dasum(C.int(n), f64(x), C.int(incX)))
 }
 func (Blas) Scnrm2(n int, x []complex64, incX int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if safeNrm2() {
+		return scnrm2(n, x, incX)
 	}
-	return float32(C.cblas_scnrm2(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return float32(C.cblas_scnrm2(C.int(n), c64(x), C.int(incX)))
 }
 func (Blas) Scasum(n int, x []complex64, incX int) float32 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	return float32(C.cblas_scasum(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return float32(C.cblas_scasum(C.int(n), c64(x), C.int(incX)))
 }
 func (Blas) Dznrm2(n int, x []complex128, incX int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if safeNrm2() {
+		return dznrm2(n, x, incX)
 	}
-	return float64(C.cblas_dznrm2(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return float64(C.cblas_dznrm2(C.int(n), c128(x), C.int(incX)))
 }
 func (Blas) Dzasum(n int, x []complex128, incX int) float64 {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return 0
 	}
-	return float64(C.cblas_dzasum(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return float64(C.cblas_dzasum(C.int(n), c128(x), C.int(incX)))
 }
 func (Blas) Isamax(n int, x []float32, incX int) int {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return -1
 	}
-	return int(C.cblas_isamax(C.int(n), (*C.float)(&x[0]), C.int(incX)))
+	return int(C.cblas_isamax(C.int(n), f32(x), C.int(incX)))
 }
 func (Blas) Idamax(n int, x []float64, incX int) int {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return -1
 	}
-	return int(C.cblas_idamax(C.int(n), (*C.double)(&x[0]), C.int(incX)))
+	return int(C.cblas_idamax(C.int(n), f64(x), C.int(incX)))
 }
 func (Blas) Icamax(n int, x []complex64, incX int) int {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return -1
 	}
-	return int(C.cblas_icamax(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return int(C.cblas_icamax(C.int(n), c64(x), C.int(incX)))
 }
 func (Blas) Izamax(n int, x []complex128, incX int) int {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return -1
 	}
-	return int(C.cblas_izamax(C.int(n), unsafe.Pointer(&x[0]), C.int(incX)))
+	return int(C.cblas_izamax(C.int(n), c128(x), C.int(incX)))
 }
 func (Blas) Sswap(n int, x []float32, incX int, y []float32, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_sswap(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_sswap(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY))
 }
 func (Blas) Scopy(n int, x []float32, incX int, y []float32, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_scopy(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_scopy(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY))
 }
 func (Blas) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	if below(&smallSaxpy, n) {
+		saxpy(n, alpha, x, incX, y, incY)
+		return
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_saxpy(C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY))
+	C.cblas_saxpy(C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY))
 }
 func (Blas) Dswap(n int, x []float64, incX int, y []float64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dswap(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dswap(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY))
 }
 func (Blas) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dcopy(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dcopy(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY))
 }
 func (Blas) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	if below(&smallDaxpy, n) {
+		daxpy(n, alpha, x, incX, y, incY)
+		return
 	}
-	C.cblas_daxpy(C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY))
+	C.cblas_daxpy(C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY))
 }
 func (Blas) Cswap(n int, x []complex64, incX int, y []complex64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cswap(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cswap(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY))
 }
 func (Blas) Ccopy(n int, x []complex64, incX int, y []complex64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ccopy(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_ccopy(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY))
 }
 func (Blas) Caxpy(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_caxpy(C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_caxpy(C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY))
 }
 func (Blas) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zswap(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zswap(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY))
 }
 func (Blas) Zcopy(n int, x []complex128, incX int, y []complex128, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zcopy(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zcopy(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY))
 }
 func (Blas) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zaxpy(C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zaxpy(C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY))
 }
 func (Blas) Srot(n int, x []float32, incX int, y []float32, incY int, c float32, s float32) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_srot(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), C.float(c), C.float(s))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_srot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY), C.float(c), C.float(s))
 }
 func (Blas) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_drot(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), C.double(c), C.double(s))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_drot(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY), C.double(c), C.double(s))
 }
 func (Blas) Sscal(n int, alpha float32, x []float32, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if below(&smallSscal, n) {
+		sscal(n, alpha, x, incX)
+		return
 	}
-	C.cblas_sscal(C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX))
+	C.cblas_sscal(C.int(n), C.float(alpha), f32(x), C.int(incX))
 }
 func (Blas) Dscal(n int, alpha float64, x []float64, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if below(&smallDscal, n) {
+		dscal(n, alpha, x, incX)
+		return
 	}
-	C.cblas_dscal(C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX))
+	C.cblas_dscal(C.int(n), C.double(alpha), f64(x), C.int(incX))
 }
 func (Blas) Cscal(n int, alpha complex64, x []complex64, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	C.cblas_cscal(C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX))
+	C.cblas_cscal(C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX))
 }
 func (Blas) Zscal(n int, alpha complex128, x []complex128, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	C.cblas_zscal(C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX))
+	C.cblas_zscal(C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX))
 }
 func (Blas) Csscal(n int, alpha float32, x []complex64, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	C.cblas_csscal(C.int(n), C.float(alpha), unsafe.Pointer(&x[0]), C.int(incX))
+	C.cblas_csscal(C.int(n), C.float(alpha), c64(x), C.int(incX))
 }
 func (Blas) Zdscal(n int, alpha float64, x []complex128, incX int) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+	if !checkReduction(n, len(x), incX) {
+		return
 	}
-	C.cblas_zdscal(C.int(n), C.double(alpha), unsafe.Pointer(&x[0]), C.int(incX))
+	C.cblas_zdscal(C.int(n), C.double(alpha), c128(x), C.int(incX))
 }
 func (Blas) Sgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	if below(&smallSgemv, m*n) {
+		sgemv(o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return
 	}
-	C.cblas_sgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), C.float(beta), (*C.float)(&y[0]), C.int(incY))
+	C.cblas_sgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
 }
 func (Blas) Sgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -557,25 +387,20 @@ func (Blas) Sgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int,
 	if kU < 0 {
 		panic("cblas: kU < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < kL+kU+1 {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	if o == blas.RowMajor {
+		checkBand(m, n, kL, kU, len(a), lda)
+	} else {
+		checkBand(n, m, kU, kL, len(a), lda)
 	}
-	C.cblas_sgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), C.float(beta), (*C.float)(&y[0]), C.int(incY))
+	C.cblas_sgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
 }
 func (Blas) Strmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -584,19 +409,15 @@ func (Blas) Strmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_strmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_strmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f32(a), C.int(lda), f32(x), C.int(incX))
 }
 func (Blas) Stbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -605,6 +426,9 @@ func (Blas) Stbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -614,13 +438,9 @@ func (Blas) Stbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_stbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_stbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), f32(a), C.int(lda), f32(x), C.int(incX))
 }
 func (Blas) Stpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -629,19 +449,15 @@ func (Blas) Stpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_stpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.float)(&ap[0]), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_stpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f32(ap), f32(x), C.int(incX))
 }
 func (Blas) Strsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -650,19 +466,15 @@ func (Blas) Strsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_strsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_strsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f32(a), C.int(lda), f32(x), C.int(incX))
 }
 func (Blas) Stbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -671,6 +483,9 @@ func (Blas) Stbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -680,13 +495,9 @@ func (Blas) Stbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_stbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_stbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), f32(a), C.int(lda), f32(x), C.int(incX))
 }
 func (Blas) Stpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -695,54 +506,51 @@ func (Blas) Stpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_stpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.float)(&ap[0]), (*C.float)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_stpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f32(ap), f32(x), C.int(incX))
 }
 func (Blas) Dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	if below(&smallDgemv, m*n) {
+		dgemv(o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+		return
 	}
-	C.cblas_dgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), C.double(beta), (*C.double)(&y[0]), C.int(incY))
+	C.cblas_dgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
 }
 func (Blas) Dgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -755,25 +563,20 @@ func (Blas) Dgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int,
 	if kU < 0 {
 		panic("cblas: kU < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < kL+kU+1 {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	if o == blas.RowMajor {
+		checkBand(m, n, kL, kU, len(a), lda)
+	} else {
+		checkBand(n, m, kU, kL, len(a), lda)
 	}
-	C.cblas_dgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), C.double(beta), (*C.double)(&y[0]), C.int(incY))
+	C.cblas_dgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
 }
 func (Blas) Dtrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -782,19 +585,15 @@ func (Blas) Dtrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dtrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f64(a), C.int(lda), f64(x), C.int(incX))
 }
 func (Blas) Dtbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -803,6 +602,9 @@ func (Blas) Dtbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -812,13 +614,9 @@ func (Blas) Dtbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_dtbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), f64(a), C.int(lda), f64(x), C.int(incX))
 }
 func (Blas) Dtpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -827,19 +625,15 @@ func (Blas) Dtpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.double)(&ap[0]), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_dtpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f64(ap), f64(x), C.int(incX))
 }
 func (Blas) Dtrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -848,19 +642,15 @@ func (Blas) Dtrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dtrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f64(a), C.int(lda), f64(x), C.int(incX))
 }
 func (Blas) Dtbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -869,6 +659,9 @@ func (Blas) Dtbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -878,13 +671,9 @@ func (Blas) Dtbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_dtbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), f64(a), C.int(lda), f64(x), C.int(incX))
 }
 func (Blas) Dtpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -893,54 +682,47 @@ func (Blas) Dtpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dtpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), (*C.double)(&ap[0]), (*C.double)(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_dtpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), f64(ap), f64(x), C.int(incX))
 }
 func (Blas) Cgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_cgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
 }
 func (Blas) Cgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -953,25 +735,20 @@ func (Blas) Cgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int,
 	if kU < 0 {
 		panic("cblas: kU < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < kL+kU+1 {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	if o == blas.RowMajor {
+		checkBand(m, n, kL, kU, len(a), lda)
+	} else {
+		checkBand(n, m, kU, kL, len(a), lda)
 	}
-	C.cblas_cgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	C.cblas_cgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
 }
 func (Blas) Ctrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -980,19 +757,15 @@ func (Blas) Ctrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ctrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c64(a), C.int(lda), c64(x), C.int(incX))
 }
 func (Blas) Ctbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1001,6 +774,9 @@ func (Blas) Ctbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -1010,13 +786,9 @@ func (Blas) Ctbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_ctbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), c64(a), C.int(lda), c64(x), C.int(incX))
 }
 func (Blas) Ctpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1025,19 +797,15 @@ func (Blas) Ctpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_ctpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c64(ap), c64(x), C.int(incX))
 }
 func (Blas) Ctrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1046,19 +814,15 @@ func (Blas) Ctrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ctrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c64(a), C.int(lda), c64(x), C.int(incX))
 }
 func (Blas) Ctbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1067,6 +831,9 @@ func (Blas) Ctbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -1076,13 +843,9 @@ func (Blas) Ctbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_ctbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), c64(a), C.int(lda), c64(x), C.int(incX))
 }
 func (Blas) Ctpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1091,54 +854,47 @@ func (Blas) Ctpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ctpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_ctpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c64(ap), c64(x), C.int(incX))
 }
 func (Blas) Zgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_zgemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
 }
 func (Blas) Zgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -1151,25 +907,20 @@ func (Blas) Zgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int,
 	if kU < 0 {
 		panic("cblas: kU < 0")
 	}
-	if incX <= 0 || incY <= 0 {
-		panic("cblas: index out of range")
-	}
 	var lenX, lenY int
 	if tA == blas.NoTrans {
 		lenX, lenY = n, m
 	} else {
 		lenX, lenY = m, n
 	}
-	if (lenX-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if (lenY-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < kL+kU+1 {
-		panic("cblas: index out of range")
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	if o == blas.RowMajor {
+		checkBand(m, n, kL, kU, len(a), lda)
+	} else {
+		checkBand(n, m, kU, kL, len(a), lda)
 	}
-	C.cblas_zgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	C.cblas_zgbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
 }
 func (Blas) Ztrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1178,19 +929,15 @@ func (Blas) Ztrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ztrmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c128(a), C.int(lda), c128(x), C.int(incX))
 }
 func (Blas) Ztbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1199,6 +946,9 @@ func (Blas) Ztbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -1208,13 +958,9 @@ func (Blas) Ztbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_ztbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), c128(a), C.int(lda), c128(x), C.int(incX))
 }
 func (Blas) Ztpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1223,19 +969,15 @@ func (Blas) Ztpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_ztpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c128(ap), c128(x), C.int(incX))
 }
 func (Blas) Ztrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1244,19 +986,15 @@ func (Blas) Ztrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ztrsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c128(a), C.int(lda), c128(x), C.int(incX))
 }
 func (Blas) Ztbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1265,6 +1003,9 @@ func (Blas) Ztbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -1274,13 +1015,9 @@ func (Blas) Ztbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_ztbsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), C.int(k), c128(a), C.int(lda), c128(x), C.int(incX))
 }
 func (Blas) Ztpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1289,19 +1026,15 @@ func (Blas) Ztpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ztpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_ztpsv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(n), c128(ap), c128(x), C.int(incX))
 }
 func (Blas) Ssymv(o blas.Order, ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1310,19 +1043,10 @@ func (Blas) Ssymv(o blas.Order, ul blas.Uplo, n int, alpha float32, a []float32,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssymv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), C.float(beta), (*C.float)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ssymv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
 }
 func (Blas) Ssbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1337,16 +1061,10 @@ func (Blas) Ssbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float32, a []f
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&x[0]), C.int(incX), C.float(beta), (*C.float)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_ssbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
 }
 func (Blas) Sspmv(o blas.Order, ul blas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1355,19 +1073,10 @@ func (Blas) Sspmv(o blas.Order, ul blas.Uplo, n int, alpha float32, ap []float32
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_sspmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&ap[0]), (*C.float)(&x[0]), C.int(incX), C.float(beta), (*C.float)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_sspmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(ap), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
 }
 func (Blas) Sger(o blas.Order, m int, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1379,16 +1088,10 @@ func (Blas) Sger(o blas.Order, m int, n int, alpha float32, x []float32, incX in
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_sger(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_sger(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(a), C.int(lda))
 }
 func (Blas) Ssyr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1397,16 +1100,9 @@ func (Blas) Ssyr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssyr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ssyr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(a), C.int(lda))
 }
 func (Blas) Sspr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1415,16 +1111,9 @@ func (Blas) Sspr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_sspr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_sspr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(ap))
 }
 func (Blas) Ssyr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1433,19 +1122,10 @@ func (Blas) Ssyr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssyr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ssyr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(a), C.int(lda))
 }
 func (Blas) Sspr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, ap []float32) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1454,19 +1134,10 @@ func (Blas) Sspr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_sspr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_sspr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(ap))
 }
 func (Blas) Dsymv(o blas.Order, ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1475,19 +1146,10 @@ func (Blas) Dsymv(o blas.Order, ul blas.Uplo, n int, alpha float64, a []float64,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsymv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), C.double(beta), (*C.double)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dsymv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
 }
 func (Blas) Dsbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1502,37 +1164,22 @@ func (Blas) Dsbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float64, a []f
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&x[0]), C.int(incX), C.double(beta), (*C.double)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_dsbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
 }
-func (Blas) Dspmv(o blas.Order, ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
-	if o != blas.RowMajor && o != blas.ColMajor {
-		panic("cblas: illegal order")
-	}
-	if ul != blas.Upper && ul != blas.Lower {
-		panic("cblas: illegal triangle")
-	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
+func (Blas) Dspmv(o blas.Order, ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
 	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
+	if ul != blas.Upper && ul != blas.Lower {
+		panic("cblas: illegal triangle")
 	}
-	C.cblas_dspmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&ap[0]), (*C.double)(&x[0]), C.int(incX), C.double(beta), (*C.double)(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_dspmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(ap), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
 }
 func (Blas) Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1544,16 +1191,10 @@ func (Blas) Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX in
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dger(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_dger(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(a), C.int(lda))
 }
 func (Blas) Dsyr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1562,16 +1203,9 @@ func (Blas) Dsyr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsyr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dsyr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(a), C.int(lda))
 }
 func (Blas) Dspr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1580,16 +1214,9 @@ func (Blas) Dspr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dspr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_dspr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(ap))
 }
 func (Blas) Dsyr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1598,19 +1225,10 @@ func (Blas) Dsyr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsyr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dsyr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(a), C.int(lda))
 }
 func (Blas) Dspr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, ap []float64) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1619,19 +1237,10 @@ func (Blas) Dspr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64,
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dspr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_dspr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(ap))
 }
 func (Blas) Chemv(o blas.Order, ul blas.Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1640,19 +1249,10 @@ func (Blas) Chemv(o blas.Order, ul blas.Uplo, n int, alpha complex64, a []comple
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_chemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
 }
 func (Blas) Chbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1667,16 +1267,10 @@ func (Blas) Chbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex64, a [
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_chbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
 }
 func (Blas) Chpmv(o blas.Order, ul blas.Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1685,19 +1279,10 @@ func (Blas) Chpmv(o blas.Order, ul blas.Uplo, n int, alpha complex64, ap []compl
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_chpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c64(ap), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
 }
 func (Blas) Cgeru(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1709,16 +1294,10 @@ func (Blas) Cgeru(o blas.Order, m int, n int, alpha complex64, x []complex64, in
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cgeru(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_cgeru(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
 }
 func (Blas) Cgerc(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1730,16 +1309,10 @@ func (Blas) Cgerc(o blas.Order, m int, n int, alpha complex64, x []complex64, in
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cgerc(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_cgerc(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
 }
 func (Blas) Cher(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1748,16 +1321,9 @@ func (Blas) Cher(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cher(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_cher(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), c64(x), C.int(incX), c64(a), C.int(lda))
 }
 func (Blas) Chpr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, ap []complex64) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1766,16 +1332,9 @@ func (Blas) Chpr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chpr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_chpr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.float(alpha), c64(x), C.int(incX), c64(ap))
 }
 func (Blas) Cher2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1784,19 +1343,10 @@ func (Blas) Cher2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []comple
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cher2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_cher2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
 }
 func (Blas) Chpr2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1805,19 +1355,10 @@ func (Blas) Chpr2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []comple
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chpr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_chpr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(ap))
 }
 func (Blas) Zhemv(o blas.Order, ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1826,19 +1367,10 @@ func (Blas) Zhemv(o blas.Order, ul blas.Uplo, n int, alpha complex128, a []compl
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_zhemv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
 }
 func (Blas) Zhbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1853,16 +1385,10 @@ func (Blas) Zhbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex128, a
 	if k < 0 {
 		panic("cblas: k < 0")
 	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < k+1 {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkBand(n, n, k, 0, len(a), lda)
+	C.cblas_zhbmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
 }
 func (Blas) Zhpmv(o blas.Order, ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1871,19 +1397,10 @@ func (Blas) Zhpmv(o blas.Order, ul blas.Uplo, n int, alpha complex128, ap []comp
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&ap[0]), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&beta), unsafe.Pointer(&y[0]), C.int(incY))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_zhpmv(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c128(ap), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
 }
 func (Blas) Zgeru(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1895,16 +1412,10 @@ func (Blas) Zgeru(o blas.Order, m int, n int, alpha complex128, x []complex128,
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zgeru(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_zgeru(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
 }
 func (Blas) Zgerc(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1916,16 +1427,10 @@ func (Blas) Zgerc(o blas.Order, m int, n int, alpha complex128, x []complex128,
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if incX <= 0 || (m-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zgerc(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkOrdered(o, m, n, len(a), lda)
+	C.cblas_zgerc(C.enum_CBLAS_ORDER(o), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
 }
 func (Blas) Zher(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1934,16 +1439,9 @@ func (Blas) Zher(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex12
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zher(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_zher(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), c128(x), C.int(incX), c128(a), C.int(lda))
 }
 func (Blas) Zhpr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1952,16 +1450,9 @@ func (Blas) Zhpr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex12
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhpr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_zhpr(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), C.double(alpha), c128(x), C.int(incX), c128(ap))
 }
 func (Blas) Zher2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1970,19 +1461,10 @@ func (Blas) Zher2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []compl
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	if lda*n > len(a) || lda < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zher2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&a[0]), C.int(lda))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_zher2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
 }
 func (Blas) Zhpr2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -1991,24 +1473,21 @@ func (Blas) Zhpr2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []compl
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*(n+1)/2 > len(ap) {
-		panic("cblas: index out of range")
-	}
-	if incX <= 0 || (n-1)*incX >= len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || (n-1)*incY >= len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhpr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&ap[0]))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_zhpr2(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(ap))
 }
 func (Blas) Sgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -2029,28 +1508,10 @@ func (Blas) Sgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n i
 	} else {
 		rowB, colB = n, k
 	}
-	if o == blas.RowMajor {
-		if lda*rowA > len(a) || lda < max(1, colA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*rowB > len(b) || ldb < max(1, colB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*m > len(c) || ldc < max(1, n) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*colA > len(a) || lda < max(1, rowA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*colB > len(b) || ldb < max(1, rowB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*n > len(c) || ldc < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_sgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), C.float(beta), (*C.float)(&c[0]), C.int(ldc))
+	checkOrdered(o, rowA, colA, len(a), lda)
+	checkOrdered(o, rowB, colB, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_sgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
 }
 func (Blas) Ssymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2074,25 +1535,10 @@ func (Blas) Ssymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha f
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), C.float(beta), (*C.float)(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_ssymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
 }
 func (Blas) Ssyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2101,6 +1547,9 @@ func (Blas) Ssyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2113,19 +1562,9 @@ func (Blas) Ssyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), (*C.float)(&a[0]), C.int(lda), C.float(beta), (*C.float)(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_ssyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), C.float(beta), f32(c), C.int(ldc))
 }
 func (Blas) Ssyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2134,6 +1573,9 @@ func (Blas) Ssyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2146,25 +1588,10 @@ func (Blas) Ssyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_ssyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb), C.float(beta), (*C.float)(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_ssyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
 }
 func (Blas) Strmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2176,6 +1603,9 @@ func (Blas) Strmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2191,22 +1621,9 @@ func (Blas) Strmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_strmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_strmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb))
 }
 func (Blas) Strsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2218,6 +1635,9 @@ func (Blas) Strsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2227,15 +1647,26 @@ func (Blas) Strsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	var k int
+	if s == blas.Left {
+		k = m
+	} else {
+		k = n
 	}
-	C.cblas_strsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.float(alpha), (*C.float)(&a[0]), C.int(lda), (*C.float)(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_strsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb))
 }
 func (Blas) Dgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -2256,28 +1687,10 @@ func (Blas) Dgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n i
 	} else {
 		rowB, colB = n, k
 	}
-	if o == blas.RowMajor {
-		if lda*rowA > len(a) || lda < max(1, colA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*rowB > len(b) || ldb < max(1, colB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*m > len(c) || ldc < max(1, n) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*colA > len(a) || lda < max(1, rowA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*colB > len(b) || ldb < max(1, rowB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*n > len(c) || ldc < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_dgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), C.double(beta), (*C.double)(&c[0]), C.int(ldc))
+	checkOrdered(o, rowA, colA, len(a), lda)
+	checkOrdered(o, rowB, colB, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_dgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
 }
 func (Blas) Dsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2301,25 +1714,10 @@ func (Blas) Dsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha f
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), C.double(beta), (*C.double)(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_dsymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
 }
 func (Blas) Dsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2328,6 +1726,9 @@ func (Blas) Dsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2340,19 +1741,9 @@ func (Blas) Dsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), (*C.double)(&a[0]), C.int(lda), C.double(beta), (*C.double)(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_dsyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), C.double(beta), f64(c), C.int(ldc))
 }
 func (Blas) Dsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2361,6 +1752,9 @@ func (Blas) Dsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2373,25 +1767,10 @@ func (Blas) Dsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_dsyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb), C.double(beta), (*C.double)(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_dsyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
 }
 func (Blas) Dtrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2403,6 +1782,9 @@ func (Blas) Dtrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2418,22 +1800,9 @@ func (Blas) Dtrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_dtrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_dtrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb))
 }
 func (Blas) Dtrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2445,6 +1814,9 @@ func (Blas) Dtrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2454,15 +1826,26 @@ func (Blas) Dtrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	var k int
+	if s == blas.Left {
+		k = m
+	} else {
+		k = n
 	}
-	C.cblas_dtrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.double(alpha), (*C.double)(&a[0]), C.int(lda), (*C.double)(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_dtrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb))
 }
 func (Blas) Cgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -2483,28 +1866,10 @@ func (Blas) Cgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n i
 	} else {
 		rowB, colB = n, k
 	}
-	if o == blas.RowMajor {
-		if lda*rowA > len(a) || lda < max(1, colA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*rowB > len(b) || ldb < max(1, colB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*m > len(c) || ldc < max(1, n) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*colA > len(a) || lda < max(1, rowA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*colB > len(b) || ldb < max(1, rowB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*n > len(c) || ldc < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_cgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, rowA, colA, len(a), lda)
+	checkOrdered(o, rowB, colB, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_cgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
 }
 func (Blas) Csymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2528,25 +1893,10 @@ func (Blas) Csymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha c
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_csymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_csymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
 }
 func (Blas) Csyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2555,6 +1905,9 @@ func (Blas) Csyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2567,19 +1920,9 @@ func (Blas) Csyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_csyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_csyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), unsafe.Pointer(&beta), c64(c), C.int(ldc))
 }
 func (Blas) Csyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2588,6 +1931,9 @@ func (Blas) Csyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2600,25 +1946,10 @@ func (Blas) Csyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_csyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_csyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
 }
 func (Blas) Ctrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2630,6 +1961,9 @@ func (Blas) Ctrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2645,22 +1979,9 @@ func (Blas) Ctrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_ctrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_ctrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb))
 }
 func (Blas) Ctrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2672,6 +1993,9 @@ func (Blas) Ctrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2681,15 +2005,26 @@ func (Blas) Ctrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	var k int
+	if s == blas.Left {
+		k = m
+	} else {
+		k = n
 	}
-	C.cblas_ctrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_ctrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb))
 }
 func (Blas) Zgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
 		panic("cblas: illegal order")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if m < 0 {
 		panic("cblas: m < 0")
 	}
@@ -2710,28 +2045,10 @@ func (Blas) Zgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n i
 	} else {
 		rowB, colB = n, k
 	}
-	if o == blas.RowMajor {
-		if lda*rowA > len(a) || lda < max(1, colA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*rowB > len(b) || ldb < max(1, colB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*m > len(c) || ldc < max(1, n) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*colA > len(a) || lda < max(1, rowA) {
-			panic("cblas: index out of range")
-		}
-		if ldb*colB > len(b) || ldb < max(1, rowB) {
-			panic("cblas: index out of range")
-		}
-		if ldc*n > len(c) || ldc < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_zgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, rowA, colA, len(a), lda)
+	checkOrdered(o, rowB, colB, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_zgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
 }
 func (Blas) Zsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2755,25 +2072,10 @@ func (Blas) Zsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha c
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zsymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_zsymm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
 }
 func (Blas) Zsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2782,6 +2084,9 @@ func (Blas) Zsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2794,19 +2099,9 @@ func (Blas) Zsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zsyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zsyrk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), unsafe.Pointer(&beta), c128(c), C.int(ldc))
 }
 func (Blas) Zsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2815,6 +2110,9 @@ func (Blas) Zsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.Trans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2827,25 +2125,10 @@ func (Blas) Zsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zsyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zsyr2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
 }
 func (Blas) Ztrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2857,6 +2140,9 @@ func (Blas) Ztrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2872,22 +2158,9 @@ func (Blas) Ztrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	C.cblas_ztrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_ztrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb))
 }
 func (Blas) Ztrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2899,6 +2172,9 @@ func (Blas) Ztrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if d != blas.NonUnit && d != blas.Unit {
 		panic("cblas: illegal diagonal")
 	}
@@ -2908,10 +2184,15 @@ func (Blas) Ztrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
-	if lda*n > len(a) || lda < max(1, m) {
-		panic("cblas: index out of range")
+	var k int
+	if s == blas.Left {
+		k = m
+	} else {
+		k = n
 	}
-	C.cblas_ztrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	C.cblas_ztrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb))
 }
 func (Blas) Chemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2935,25 +2216,10 @@ func (Blas) Chemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha c
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_chemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_chemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
 }
 func (Blas) Cherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2962,6 +2228,9 @@ func (Blas) Cherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -2974,19 +2243,9 @@ func (Blas) Cherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cherk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), unsafe.Pointer(&a[0]), C.int(lda), C.float(beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_cherk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.float(alpha), c64(a), C.int(lda), C.float(beta), c64(c), C.int(ldc))
 }
 func (Blas) Cher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -2995,6 +2254,9 @@ func (Blas) Cher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -3007,25 +2269,10 @@ func (Blas) Cher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cher2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), C.float(beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_cher2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), C.float(beta), c64(c), C.int(ldc))
 }
 func (Blas) Zhemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -3049,25 +2296,10 @@ func (Blas) Zhemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha c
 	} else {
 		k = n
 	}
-	if o == blas.RowMajor {
-		if lda*n > len(a) || lda < max(1, m) {
-			panic("cblas: index out of range")
-		}
-		if ldb*k > len(b) || ldb < max(1, k) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*k > len(a) || lda < max(1, k) {
-			panic("cblas: index out of range")
-		}
-		if ldb*n > len(b) || ldb < max(1, m) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, m) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zhemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), unsafe.Pointer(&beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkMatrix(k, k, len(a), lda)
+	checkOrdered(o, m, n, len(b), ldb)
+	checkOrdered(o, m, n, len(c), ldc)
+	C.cblas_zhemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
 }
 func (Blas) Zherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -3076,6 +2308,9 @@ func (Blas) Zherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -3088,19 +2323,9 @@ func (Blas) Zherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, al
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zherk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), unsafe.Pointer(&a[0]), C.int(lda), C.double(beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zherk(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), C.double(alpha), c128(a), C.int(lda), C.double(beta), c128(c), C.int(ldc))
 }
 func (Blas) Zher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
 	if o != blas.RowMajor && o != blas.ColMajor {
@@ -3109,6 +2334,9 @@ func (Blas) Zher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	if ul != blas.Upper && ul != blas.Lower {
 		panic("cblas: illegal triangle")
 	}
+	if t != blas.NoTrans && t != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
 	if n < 0 {
 		panic("cblas: n < 0")
 	}
@@ -3121,23 +2349,8 @@ func (Blas) Zher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, a
 	} else {
 		row, col = k, n
 	}
-	if o == blas.RowMajor {
-		if lda*col > len(a) || lda < max(1, row) {
-			panic("cblas: index out of range")
-		}
-		if ldb*col > len(b) || ldb < max(1, row) {
-			panic("cblas: index out of range")
-		}
-	} else {
-		if lda*row > len(a) || lda < max(1, col) {
-			panic("cblas: index out of range")
-		}
-		if ldb*row > len(b) || ldb < max(1, col) {
-			panic("cblas: index out of range")
-		}
-	}
-	if ldc*n > len(c) || ldc < max(1, n) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zher2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), unsafe.Pointer(&a[0]), C.int(lda), unsafe.Pointer(&b[0]), C.int(ldb), C.double(beta), unsafe.Pointer(&c[0]), C.int(ldc))
+	checkOrdered(o, row, col, len(a), lda)
+	checkOrdered(o, row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zher2k(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(t), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), C.double(beta), c128(c), C.int(ldc))
 }
@@ -0,0 +1,21 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/cblas/blastest"
+)
+
+func TestFloat32(t *testing.T)    { blastest.TestFloat32(t, Blas{}) }
+func TestFloat64(t *testing.T)    { blastest.TestFloat64(t, Blas{}) }
+func TestComplex64(t *testing.T)  { blastest.TestComplex64(t, Blas{}) }
+func TestComplex128(t *testing.T) { blastest.TestComplex128(t, Blas{}) }
+
+func BenchmarkFloat32(b *testing.B)    { blastest.BenchFloat32(b, Blas{}) }
+func BenchmarkFloat64(b *testing.B)    { blastest.BenchFloat64(b, Blas{}) }
+func BenchmarkComplex64(b *testing.B)  { blastest.BenchComplex64(b, Blas{}) }
+func BenchmarkComplex128(b *testing.B) { blastest.BenchComplex128(b, Blas{}) }
@@ -0,0 +1,216 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// Problem sizes used by the benchmarks.
+var (
+	level1Sizes = []int{10, 1000, 100000}
+	level2Sizes = []int{10, 100, 1000}
+	level3Sizes = []int{10, 100, 300}
+)
+
+func bench(b *testing.B, name string, n int, f func()) {
+	b.Run(fmt.Sprintf("%s/N=%d", name, n), func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f()
+		}
+	})
+}
+
+// The triangular matrices used by the benchmarks are diagonally dominant
+// so that repeated solves do not overflow. The right-hand sides of solves
+// are restored before each call.
+
+// BenchFloat32 benchmarks a selection of the blas.Float32 routines of impl.
+func BenchFloat32(b *testing.B, impl blas.Float32) {
+	rnd := rand.New(rand.NewSource(1))
+	vec := func(n int) []float32 {
+		v := make([]float32, n)
+		for i := range v {
+			v[i] = float32(rnd.Float64() - 0.5)
+		}
+		return v
+	}
+	tri := func(n int) []float32 {
+		a := vec(n * n)
+		for i := 0; i < n; i++ {
+			a[i*n+i] = float32(n)
+		}
+		return a
+	}
+	o := blas.RowMajor
+	for _, n := range level1Sizes {
+		x, y := vec(n), vec(n)
+		bench(b, "Sdot", n, func() { impl.Sdot(n, x, 1, y, 1) })
+		bench(b, "Saxpy", n, func() { impl.Saxpy(n, 0.5, x, 1, y, 1) })
+		bench(b, "Snrm2", n, func() { impl.Snrm2(n, x, 1) })
+	}
+	for _, n := range level2Sizes {
+		a, x, y, t := vec(n*n), vec(n), vec(n), tri(n)
+		w := make([]float32, n)
+		bench(b, "Sgemv", n, func() { impl.Sgemv(o, blas.NoTrans, n, n, 1, a, n, x, 1, 0, y, 1) })
+		bench(b, "Sger", n, func() { impl.Sger(o, n, n, 1, x, 1, y, 1, a, n) })
+		bench(b, "Strsv", n, func() {
+			copy(w, x)
+			impl.Strsv(o, blas.Lower, blas.NoTrans, blas.NonUnit, n, t, n, w, 1)
+		})
+	}
+	for _, n := range level3Sizes {
+		a, m, c, t := vec(n*n), vec(n*n), vec(n*n), tri(n)
+		w := make([]float32, n*n)
+		bench(b, "Sgemm", n, func() { impl.Sgemm(o, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, m, n, 0, c, n) })
+		bench(b, "Ssyrk", n, func() { impl.Ssyrk(o, blas.Upper, blas.NoTrans, n, n, 1, a, n, 0, c, n) })
+		bench(b, "Strsm", n, func() {
+			copy(w, m)
+			impl.Strsm(o, blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, n, n, 1, t, n, w, n)
+		})
+	}
+}
+
+// BenchFloat64 benchmarks a selection of the blas.Float64 routines of impl.
+func BenchFloat64(b *testing.B, impl blas.Float64) {
+	rnd := rand.New(rand.NewSource(1))
+	vec := func(n int) []float64 {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = rnd.Float64() - 0.5
+		}
+		return v
+	}
+	tri := func(n int) []float64 {
+		a := vec(n * n)
+		for i := 0; i < n; i++ {
+			a[i*n+i] = float64(n)
+		}
+		return a
+	}
+	o := blas.RowMajor
+	for _, n := range level1Sizes {
+		x, y := vec(n), vec(n)
+		bench(b, "Ddot", n, func() { impl.Ddot(n, x, 1, y, 1) })
+		bench(b, "Daxpy", n, func() { impl.Daxpy(n, 0.5, x, 1, y, 1) })
+		bench(b, "Dnrm2", n, func() { impl.Dnrm2(n, x, 1) })
+	}
+	for _, n := range level2Sizes {
+		a, x, y, t := vec(n*n), vec(n), vec(n), tri(n)
+		w := make([]float64, n)
+		bench(b, "Dgemv", n, func() { impl.Dgemv(o, blas.NoTrans, n, n, 1, a, n, x, 1, 0, y, 1) })
+		bench(b, "Dger", n, func() { impl.Dger(o, n, n, 1, x, 1, y, 1, a, n) })
+		bench(b, "Dtrsv", n, func() {
+			copy(w, x)
+			impl.Dtrsv(o, blas.Lower, blas.NoTrans, blas.NonUnit, n, t, n, w, 1)
+		})
+	}
+	for _, n := range level3Sizes {
+		a, m, c, t := vec(n*n), vec(n*n), vec(n*n), tri(n)
+		w := make([]float64, n*n)
+		bench(b, "Dgemm", n, func() { impl.Dgemm(o, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, m, n, 0, c, n) })
+		bench(b, "Dsyrk", n, func() { impl.Dsyrk(o, blas.Upper, blas.NoTrans, n, n, 1, a, n, 0, c, n) })
+		bench(b, "Dtrsm", n, func() {
+			copy(w, m)
+			impl.Dtrsm(o, blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, n, n, 1, t, n, w, n)
+		})
+	}
+}
+
+// BenchComplex64 benchmarks a selection of the blas.Complex64 routines of impl.
+func BenchComplex64(b *testing.B, impl blas.Complex64) {
+	rnd := rand.New(rand.NewSource(1))
+	vec := func(n int) []complex64 {
+		v := make([]complex64, n)
+		for i := range v {
+			v[i] = complex(float32(rnd.Float64()-0.5), float32(rnd.Float64()-0.5))
+		}
+		return v
+	}
+	tri := func(n int) []complex64 {
+		a := vec(n * n)
+		for i := 0; i < n; i++ {
+			a[i*n+i] = complex(float32(n), 0)
+		}
+		return a
+	}
+	o := blas.RowMajor
+	for _, n := range level1Sizes {
+		x, y := vec(n), vec(n)
+		bench(b, "Cdotu", n, func() { impl.Cdotu(n, x, 1, y, 1) })
+		bench(b, "Caxpy", n, func() { impl.Caxpy(n, 0.5, x, 1, y, 1) })
+		bench(b, "Scnrm2", n, func() { impl.Scnrm2(n, x, 1) })
+	}
+	for _, n := range level2Sizes {
+		a, x, y, t := vec(n*n), vec(n), vec(n), tri(n)
+		w := make([]complex64, n)
+		bench(b, "Cgemv", n, func() { impl.Cgemv(o, blas.NoTrans, n, n, 1, a, n, x, 1, 0, y, 1) })
+		bench(b, "Cgeru", n, func() { impl.Cgeru(o, n, n, 1, x, 1, y, 1, a, n) })
+		bench(b, "Ctrsv", n, func() {
+			copy(w, x)
+			impl.Ctrsv(o, blas.Lower, blas.NoTrans, blas.NonUnit, n, t, n, w, 1)
+		})
+	}
+	for _, n := range level3Sizes {
+		a, m, c, t := vec(n*n), vec(n*n), vec(n*n), tri(n)
+		w := make([]complex64, n*n)
+		bench(b, "Cgemm", n, func() { impl.Cgemm(o, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, m, n, 0, c, n) })
+		bench(b, "Cherk", n, func() { impl.Cherk(o, blas.Upper, blas.NoTrans, n, n, 1, a, n, 0, c, n) })
+		bench(b, "Ctrsm", n, func() {
+			copy(w, m)
+			impl.Ctrsm(o, blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, n, n, 1, t, n, w, n)
+		})
+	}
+}
+
+// BenchComplex128 benchmarks a selection of the blas.Complex128 routines of impl.
+func BenchComplex128(b *testing.B, impl blas.Complex128) {
+	rnd := rand.New(rand.NewSource(1))
+	vec := func(n int) []complex128 {
+		v := make([]complex128, n)
+		for i := range v {
+			v[i] = complex(rnd.Float64()-0.5, rnd.Float64()-0.5)
+		}
+		return v
+	}
+	tri := func(n int) []complex128 {
+		a := vec(n * n)
+		for i := 0; i < n; i++ {
+			a[i*n+i] = complex(float64(n), 0)
+		}
+		return a
+	}
+	o := blas.RowMajor
+	for _, n := range level1Sizes {
+		x, y := vec(n), vec(n)
+		bench(b, "Zdotu", n, func() { impl.Zdotu(n, x, 1, y, 1) })
+		bench(b, "Zaxpy", n, func() { impl.Zaxpy(n, 0.5, x, 1, y, 1) })
+		bench(b, "Dznrm2", n, func() { impl.Dznrm2(n, x, 1) })
+	}
+	for _, n := range level2Sizes {
+		a, x, y, t := vec(n*n), vec(n), vec(n), tri(n)
+		w := make([]complex128, n)
+		bench(b, "Zgemv", n, func() { impl.Zgemv(o, blas.NoTrans, n, n, 1, a, n, x, 1, 0, y, 1) })
+		bench(b, "Zgeru", n, func() { impl.Zgeru(o, n, n, 1, x, 1, y, 1, a, n) })
+		bench(b, "Ztrsv", n, func() {
+			copy(w, x)
+			impl.Ztrsv(o, blas.Lower, blas.NoTrans, blas.NonUnit, n, t, n, w, 1)
+		})
+	}
+	for _, n := range level3Sizes {
+		a, m, c, t := vec(n*n), vec(n*n), vec(n*n), tri(n)
+		w := make([]complex128, n*n)
+		bench(b, "Zgemm", n, func() { impl.Zgemm(o, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, m, n, 0, c, n) })
+		bench(b, "Zherk", n, func() { impl.Zherk(o, blas.Upper, blas.NoTrans, n, n, 1, a, n, 0, c, n) })
+		bench(b, "Ztrsm", n, func() {
+			copy(w, m)
+			impl.Ztrsm(o, blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, n, n, 1, t, n, w, n)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package blastest provides conformance tests and benchmarks for
+// implementations of the blas interfaces.
+//
+// The test functions run every routine of an interface over a range of
+// orders, dimensions, band widths, increments and scalar multipliers,
+// comparing the results with a reference computation and checking that
+// storage outside of the operands is not modified. They also check that
+// illegal orders, triangles, diagonals and sides, negative dimensions and
+// zero increments cause a panic, as they do for the cblas package.
+// Negative increments, which BLAS defines as stepping backwards through
+// a vector, are not tested here; the xblat package runs the reference
+// tests that cover them.
+//
+// The fuzz functions run the same tests with argument values, leading
+// dimensions and slice lengths chosen by the fuzzer, and check that calls
+// with illegal arguments panic and that no call writes past the end of a
+// slice.
+//
+// A package providing an implementation would use them from its own
+// tests:
+//
+//	func TestFloat64(t *testing.T) {
+//		blastest.TestFloat64(t, Implementation{})
+//	}
+//
+//	func FuzzFloat64(f *testing.F) {
+//		blastest.FuzzFloat64(f, Implementation{})
+//	}
+//
+//	func BenchmarkFloat64(b *testing.B) {
+//		blastest.BenchFloat64(b, Implementation{})
+//	}
+package blastest
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestFloat32 tests the blas.Float32 routines of impl.
+func TestFloat32(t *testing.T, impl blas.Float32) {
+	newSuite(impl, false, true).test(t, float32Routines)
+}
+
+// TestFloat64 tests the blas.Float64 routines of impl.
+func TestFloat64(t *testing.T, impl blas.Float64) {
+	newSuite(impl, false, false).test(t, float64Routines)
+}
+
+// TestComplex64 tests the blas.Complex64 routines of impl.
+func TestComplex64(t *testing.T, impl blas.Complex64) {
+	newSuite(impl, true, true).test(t, complex64Routines)
+}
+
+// TestComplex128 tests the blas.Complex128 routines of impl.
+func TestComplex128(t *testing.T, impl blas.Complex128) {
+	newSuite(impl, true, false).test(t, complex128Routines)
+}
+
+var float32Routines = []routine{
+	{"Srotg", testRotg},
+	{"Srotmg", testRotmg},
+	{"Srot", testRot},
+	{"Srotm", testRotm},
+	{"Sdsdot", testSdsdot},
+	{"Sdot", testDot},
+	{"Snrm2", testNrm2},
+	{"Sasum", testAsum},
+	{"Isamax", testIamax},
+	{"Sswap", testSwap},
+	{"Scopy", testCopy},
+	{"Saxpy", testAxpy},
+	{"Sscal", testScal},
+
+	{"Sgemv", testGemv},
+	{"Sgbmv", testGbmv},
+	{"Ssymv", testSymv},
+	{"Ssbmv", testSbmv},
+	{"Sspmv", testSpmv},
+	{"Strmv", testTrmv},
+	{"Stbmv", testTbmv},
+	{"Stpmv", testTpmv},
+	{"Strsv", testTrsv},
+	{"Stbsv", testTbsv},
+	{"Stpsv", testTpsv},
+	{"Sger", testGer},
+	{"Ssyr", testSyr},
+	{"Sspr", testSpr},
+	{"Ssyr2", testSyr2},
+	{"Sspr2", testSpr2},
+
+	{"Sgemm", testGemm},
+	{"Ssymm", testSymm},
+	{"Ssyrk", testSyrk},
+	{"Ssyr2k", testSyr2k},
+	{"Strmm", testTrmm},
+	{"Strsm", testTrsm},
+}
+
+var float64Routines = []routine{
+	{"Drotg", testRotg},
+	{"Drotmg", testRotmg},
+	{"Drot", testRot},
+	{"Drotm", testRotm},
+	{"Dsdot", testDsdot},
+	{"Ddot", testDot},
+	{"Dnrm2", testNrm2},
+	{"Dasum", testAsum},
+	{"Idamax", testIamax},
+	{"Dswap", testSwap},
+	{"Dcopy", testCopy},
+	{"Daxpy", testAxpy},
+	{"Dscal", testScal},
+
+	{"Dgemv", testGemv},
+	{"Dgbmv", testGbmv},
+	{"Dsymv", testSymv},
+	{"Dsbmv", testSbmv},
+	{"Dspmv", testSpmv},
+	{"Dtrmv", testTrmv},
+	{"Dtbmv", testTbmv},
+	{"Dtpmv", testTpmv},
+	{"Dtrsv", testTrsv},
+	{"Dtbsv", testTbsv},
+	{"Dtpsv", testTpsv},
+	{"Dger", testGer},
+	{"Dsyr", testSyr},
+	{"Dspr", testSpr},
+	{"Dsyr2", testSyr2},
+	{"Dspr2", testSpr2},
+
+	{"Dgemm", testGemm},
+	{"Dsymm", testSymm},
+	{"Dsyrk", testSyrk},
+	{"Dsyr2k", testSyr2k},
+	{"Dtrmm", testTrmm},
+	{"Dtrsm", testTrsm},
+}
+
+var complex64Routines = []routine{
+	{"Cdotu", testDot},
+	{"Cdotc", testDot},
+	{"Scnrm2", testNrm2},
+	{"Scasum", testAsum},
+	{"Icamax", testIamax},
+	{"Cswap", testSwap},
+	{"Ccopy", testCopy},
+	{"Caxpy", testAxpy},
+	{"Cscal", testScal},
+	{"Csscal", testRealScal},
+
+	{"Cgemv", testGemv},
+	{"Cgbmv", testGbmv},
+	{"Chemv", testSymv},
+	{"Chbmv", testSbmv},
+	{"Chpmv", testSpmv},
+	{"Ctrmv", testTrmv},
+	{"Ctbmv", testTbmv},
+	{"Ctpmv", testTpmv},
+	{"Ctrsv", testTrsv},
+	{"Ctbsv", testTbsv},
+	{"Ctpsv", testTpsv},
+	{"Cgeru", testGer},
+	{"Cgerc", testGer},
+	{"Cher", testSyr},
+	{"Chpr", testSpr},
+	{"Cher2", testSyr2},
+	{"Chpr2", testSpr2},
+
+	{"Cgemm", testGemm},
+	{"Csymm", testSymm},
+	{"Chemm", testSymm},
+	{"Csyrk", testSyrk},
+	{"Cherk", testSyrk},
+	{"Csyr2k", testSyr2k},
+	{"Cher2k", testSyr2k},
+	{"Ctrmm", testTrmm},
+	{"Ctrsm", testTrsm},
+}
+
+var complex128Routines = []routine{
+	{"Zdotu", testDot},
+	{"Zdotc", testDot},
+	{"Dznrm2", testNrm2},
+	{"Dzasum", testAsum},
+	{"Izamax", testIamax},
+	{"Zswap", testSwap},
+	{"Zcopy", testCopy},
+	{"Zaxpy", testAxpy},
+	{"Zscal", testScal},
+	{"Zdscal", testRealScal},
+
+	{"Zgemv", testGemv},
+	{"Zgbmv", testGbmv},
+	{"Zhemv", testSymv},
+	{"Zhbmv", testSbmv},
+	{"Zhpmv", testSpmv},
+	{"Ztrmv", testTrmv},
+	{"Ztbmv", testTbmv},
+	{"Ztpmv", testTpmv},
+	{"Ztrsv", testTrsv},
+	{"Ztbsv", testTbsv},
+	{"Ztpsv", testTpsv},
+	{"Zgeru", testGer},
+	{"Zgerc", testGer},
+	{"Zher", testSyr},
+	{"Zhpr", testSpr},
+	{"Zher2", testSyr2},
+	{"Zhpr2", testSpr2},
+
+	{"Zgemm", testGemm},
+	{"Zsymm", testSymm},
+	{"Zhemm", testSymm},
+	{"Zsyrk", testSyrk},
+	{"Zherk", testSyrk},
+	{"Zsyr2k", testSyr2k},
+	{"Zher2k", testSyr2k},
+	{"Ztrmm", testTrmm},
+	{"Ztrsm", testTrsm},
+}
@@ -0,0 +1,205 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// FuzzFloat32 fuzzes the blas.Float32 routines of impl.
+func FuzzFloat32(f *testing.F, impl blas.Float32) {
+	fuzz(f, impl, false, true, float32Routines)
+}
+
+// FuzzFloat64 fuzzes the blas.Float64 routines of impl.
+func FuzzFloat64(f *testing.F, impl blas.Float64) {
+	fuzz(f, impl, false, false, float64Routines)
+}
+
+// FuzzComplex64 fuzzes the blas.Complex64 routines of impl.
+func FuzzComplex64(f *testing.F, impl blas.Complex64) {
+	fuzz(f, impl, true, true, complex64Routines)
+}
+
+// FuzzComplex128 fuzzes the blas.Complex128 routines of impl.
+func FuzzComplex128(f *testing.F, impl blas.Complex128) {
+	fuzz(f, impl, true, false, complex128Routines)
+}
+
+// fuzz runs the test of a routine chosen by the fuzzer with the argument
+// values to test taken from the fuzzer's input. Leading dimensions and
+// the lengths of slices are chosen at random from the legal values near
+// their minimum. Each call is preceded by a call with one argument made
+// illegal, which must panic:
+//
+//   - an order, triangle, diagonal or side is set to zero,
+//   - a dimension is set to -1,
+//   - an increment is set to zero,
+//   - a leading dimension is set to one less than its minimum, or
+//   - a slice is truncated to one less than the length of storage holding
+//     the elements of its operand, unless a dimension or band width is
+//     zero so that the operand may not be referenced.
+//
+// The legal call may panic instead of completing, since an implementation
+// may impose stricter requirements than BLAS. Every panic must be a string
+// of the form "pkg: description", as used by the cblas package, rather
+// than a runtime error, and no call may write past the end of a slice.
+func fuzz(f *testing.F, impl interface{}, complex, single bool, routines []routine) {
+	for i := range routines {
+		f.Add(uint8(i), uint8(3), uint8(5), uint8(7), uint8(3), uint8(i*7), uint8(i%4), int64(i))
+	}
+	f.Fuzz(func(t *testing.T, r, m, n, k, inc, enums, mut uint8, seed int64) {
+		s := newSuite(impl, complex, single)
+		s.dims = values(int(m%13), int(n%13))
+		s.bands = values(int(k%5), int(k/5%5))
+		s.incs = values(int(inc%3)+1, int(inc/3%3)+1)
+		s.orders = []blas.Order{orders[enums&1]}
+		s.transposes = []blas.Transpose{transposes[enums>>1%3]}
+		s.uplos = []blas.Uplo{uplos[enums>>3&1]}
+		s.diags = []blas.Diag{diags[enums>>4&1]}
+		s.sides = []blas.Side{sides[enums>>5&1]}
+		s.fuzzer = &fuzzer{mut: int(mut), operands: make(map[*complex128]operand)}
+		s.rnd = rand.New(rand.NewSource(seed))
+		rt := routines[int(r)%len(routines)]
+		rt.test(s, t, rt.name)
+	})
+}
+
+func values(a, b int) []int {
+	if a == b {
+		return []int{a}
+	}
+	return []int{a, b}
+}
+
+// fuzzer holds the state of a suite run by a fuzz target.
+type fuzzer struct {
+	mut int // Selects the argument made illegal before each call, or zero for none.
+
+	// operands holds the operands passed to the implementation,
+	// keyed by their first element.
+	operands map[*complex128]operand
+}
+
+// operand describes the storage of a vector or matrix operand.
+type operand struct {
+	footprint int  // Length of storage holding the elements.
+	minLD     int  // Smallest legal leading dimension, or zero for none.
+	vector    bool // Whether the operand is a vector.
+}
+
+// size returns the length of a slice holding footprint elements.
+func (s *suite) size(footprint int) int {
+	return footprint + s.rnd.Intn(3)
+}
+
+// leading returns a leading dimension no smaller than min.
+func (s *suite) leading(min int) int {
+	return min + s.rnd.Intn(4)
+}
+
+func (s *suite) register(v []complex128, op operand) {
+	if len(v) != 0 {
+		s.operands[&v[0]] = op
+	}
+}
+
+// mutation is an illegal value for the ith argument of a call.
+type mutation struct {
+	i int
+	v interface{}
+}
+
+// illegal calls the named method with one argument of args made illegal,
+// reporting an error and returning false if the call does not panic with
+// a message.
+func (s *suite) illegal(t *testing.T, name string, args []interface{}) bool {
+	t.Helper()
+	if s.mut == 0 {
+		return true
+	}
+	var muts, short []mutation
+	empty := false
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case blas.Order:
+			muts = append(muts, mutation{i, blas.Order(0)})
+		case blas.Uplo:
+			muts = append(muts, mutation{i, blas.Uplo(0)})
+		case blas.Diag:
+			muts = append(muts, mutation{i, blas.Diag(0)})
+		case blas.Side:
+			muts = append(muts, mutation{i, blas.Side(0)})
+		case int:
+			muts = append(muts, mutation{i, -1})
+			empty = empty || arg == 0
+		case []complex128:
+			var op operand
+			ok := len(arg) != 0
+			if ok {
+				op, ok = s.operands[&arg[0]]
+			}
+			if ok && op.footprint > 0 {
+				short = append(short, mutation{i, arg[:op.footprint-1]})
+			}
+			if i+1 == len(args) {
+				break
+			}
+			if _, isInt := args[i+1].(int); !isInt {
+				break
+			}
+			// The increment or leading dimension of the operand.
+			i++
+			switch {
+			case !ok:
+			case op.vector:
+				muts = append(muts, mutation{i, 0})
+			case op.minLD > 0:
+				muts = append(muts, mutation{i, op.minLD - 1})
+			}
+		}
+	}
+	if !empty {
+		muts = append(muts, short...)
+	}
+	if len(muts) == 0 {
+		return true
+	}
+	m := muts[(s.mut-1)%len(muts)]
+	bad := make([]interface{}, len(args))
+	copy(bad, args)
+	bad[m.i] = m.v
+	r := func() (r interface{}) {
+		defer func() {
+			r = recover()
+		}()
+		s.call(name, bad...)
+		return nil
+	}()
+	switch {
+	case r == nil:
+		t.Errorf("%s: expected panic for illegal argument %d", s.describe(name, bad), m.i+1)
+		return false
+	case !isMessage(r):
+		t.Errorf("%s: unexpected panic for illegal argument %d: %v", s.describe(name, bad), m.i+1, r)
+		return false
+	}
+	return true
+}
+
+// isMessage returns whether the panic value r is a string of the form
+// "pkg: description".
+func isMessage(r interface{}) bool {
+	m, ok := r.(string)
+	if !ok {
+		return false
+	}
+	i := strings.Index(m, ": ")
+	return i > 0 && !strings.ContainsAny(m[:i], " \t\n")
+}
@@ -0,0 +1,471 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// vectorPanics checks that the level 1 call args, whose first argument is n
+// and whose vector increments follow each vector, panics when n is
+// negative or an increment is zero.
+func (s *suite) vectorPanics(t *testing.T, name string, args []interface{}) {
+	if !s.panics(t, name, args, 0, -1) {
+		return
+	}
+	for i, arg := range args {
+		if _, ok := arg.([]complex128); ok {
+			if !s.panics(t, name, args, i+1, 0) {
+				return
+			}
+		}
+	}
+}
+
+func testRotg(s *suite, t *testing.T, name string) {
+	cases := [][2]float64{
+		{0.3, 0.4}, {0.4, 0.3}, {-0.3, 0.4}, {-0.4, 0.3}, {-0.3, -0.4},
+		{0, 0}, {0, 1}, {1, 0}, {5e-20, 1e-20}, {4e20, -3e20},
+	}
+	for _, c := range cases {
+		a, b := real(s.round(complex(c[0], 0))), real(s.round(complex(c[1], 0)))
+		args := []interface{}{a, b}
+		desc := s.describe(name, args)
+		res, ok := s.run(t, desc, name, args...)
+		if !ok {
+			return
+		}
+		wc, ws, wr, wz := rotg(a, b)
+		for i, want := range []float64{wc, ws, wr, wz} {
+			got := res[i].(complex128)
+			if !s.near(got, complex(want, 0), math.Abs(want)+1) {
+				t.Errorf("%s: unexpected %s: got %v want %v", desc, []string{"c", "s", "r", "z"}[i], real(got), want)
+			}
+		}
+	}
+}
+
+// rotg is the reference DROTG.
+func rotg(a, b float64) (c, s, r, z float64) {
+	roe := b
+	if math.Abs(a) > math.Abs(b) {
+		roe = a
+	}
+	scale := math.Abs(a) + math.Abs(b)
+	if scale == 0 {
+		return 1, 0, 0, 0
+	}
+	r = scale * math.Hypot(a/scale, b/scale)
+	r = math.Copysign(r, roe)
+	c = a / r
+	s = b / r
+	z = 1
+	if math.Abs(a) > math.Abs(b) {
+		z = s
+	}
+	if math.Abs(b) >= math.Abs(a) && c != 0 {
+		z = 1 / c
+	}
+	return c, s, r, z
+}
+
+func testRotmg(s *suite, t *testing.T, name string) {
+	// The rotation is checked by its defining properties rather than
+	// against reference values, since the scaling steps may differ.
+	cases := [][4]float64{
+		{0.1, 0.3, 1.2, 0.2},
+		{0.7, 0.2, 0.6, 4.2},
+		{4, 2, 8, 4},
+		{1, 3, 2, 0},
+		{2, 1, 0, 3},
+		{6e-10, 2e-2, 1e5, 10},
+		{4e10, 2e-2, 1e-5, 10},
+		{2e-10, 4e-2, 1e5, 10},
+		{2e10, 4e-2, 1e-5, 10},
+	}
+	for _, c := range cases {
+		var v [4]float64
+		for i := range v {
+			v[i] = real(s.round(complex(c[i], 0)))
+		}
+		args := []interface{}{v[0], v[1], v[2], v[3]}
+		desc := s.describe(name, args)
+		res, ok := s.run(t, desc, name, args...)
+		if !ok {
+			return
+		}
+		p := res[0].(*blas.DrotmParams)
+		d := [2]float64{real(res[1].(complex128)), real(res[2].(complex128))}
+		b1 := real(res[3].(complex128))
+		h, ok := rotmH(p)
+		if !ok {
+			t.Errorf("%s: invalid flag %v", desc, p.Flag)
+			continue
+		}
+
+		// H^T * diag(d) * H must equal the original weights.
+		for i := 0; i < 2; i++ {
+			for j := 0; j < 2; j++ {
+				var m, g float64
+				for k := 0; k < 2; k++ {
+					m += h[k][i] * d[k] * h[k][j]
+					g += math.Abs(h[k][i] * d[k] * h[k][j])
+				}
+				var want float64
+				if i == j {
+					want = v[i]
+				}
+				if !s.near(complex(m, 0), complex(want, 0), g+math.Abs(want)) {
+					t.Errorf("%s: H^T*D*H[%d][%d] = %v, want %v", desc, i, j, m, want)
+				}
+			}
+		}
+		// H must map (x1, y1) to (b1, 0).
+		x := [2]float64{v[2], v[3]}
+		for i, want := range []float64{b1, 0} {
+			y := h[i][0]*x[0] + h[i][1]*x[1]
+			g := math.Abs(h[i][0]*x[0]) + math.Abs(h[i][1]*x[1])
+			if !s.near(complex(y, 0), complex(want, 0), g) {
+				t.Errorf("%s: (H*x)[%d] = %v, want %v", desc, i, y, want)
+			}
+		}
+	}
+}
+
+// rotmH returns the rotation matrix described by p.
+func rotmH(p *blas.DrotmParams) (h [2][2]float64, ok bool) {
+	switch p.Flag {
+	case -2:
+		return [2][2]float64{{1, 0}, {0, 1}}, true
+	case -1:
+		return [2][2]float64{{p.H[0], p.H[2]}, {p.H[1], p.H[3]}}, true
+	case 0:
+		return [2][2]float64{{1, p.H[2]}, {p.H[1], 1}}, true
+	case 1:
+		return [2][2]float64{{p.H[0], 1}, {-1, p.H[3]}}, true
+	}
+	return h, false
+}
+
+func testRot(s *suite, t *testing.T, name string) {
+	cs := [][2]float64{{1, 0}, {0, 1}, {0.6, 0.8}, {-0.8, 0.6}}
+	for _, n := range s.dims {
+		for _, incX := range s.incs {
+			for _, incY := range s.incs {
+				for _, v := range cs {
+					h := [2][2]float64{{v[0], v[1]}, {-v[1], v[0]}}
+					if !s.checkRot(t, name, n, incX, incY, h, func(x, y []complex128) []interface{} {
+						return []interface{}{n, x, incX, y, incY, v[0], v[1]}
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}
+	x, y := s.vector(1, 1), s.vector(1, 1)
+	s.vectorPanics(t, name, []interface{}{1, x, 1, y, 1, 1.0, 0.0})
+}
+
+func testRotm(s *suite, t *testing.T, name string) {
+	for _, n := range s.dims {
+		for _, incX := range s.incs {
+			for _, incY := range s.incs {
+				for _, flag := range []float64{-2, -1, 0, 1} {
+					p := &blas.DrotmParams{Flag: flag}
+					for i := range p.H {
+						p.H[i] = real(s.value()) * 2
+					}
+					h, _ := rotmH(p)
+					if !s.checkRot(t, name, n, incX, incY, h, func(x, y []complex128) []interface{} {
+						return []interface{}{n, x, incX, y, incY, p}
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}
+	x, y := s.vector(1, 1), s.vector(1, 1)
+	s.vectorPanics(t, name, []interface{}{1, x, 1, y, 1, &blas.DrotmParams{Flag: -2}})
+}
+
+// checkRot tests a call that applies the plane rotation h to x and y.
+func (s *suite) checkRot(t *testing.T, name string, n, incX, incY int, h [2][2]float64, args func(x, y []complex128) []interface{}) bool {
+	x, y := s.vector(n, incX), s.vector(n, incY)
+	xs, ys := clone(x), clone(y)
+	call := args(x, y)
+	desc := s.describe(name, call)
+	if _, ok := s.run(t, desc, name, call...); !ok {
+		return false
+	}
+	xv, yv := elems(xs, n, incX), elems(ys, n, incY)
+	wx, wy := make([]complex128, n), make([]complex128, n)
+	gx, gy := make([]float64, n), make([]float64, n)
+	for i := range xv {
+		wx[i] = complex(h[0][0], 0)*xv[i] + complex(h[0][1], 0)*yv[i]
+		wy[i] = complex(h[1][0], 0)*xv[i] + complex(h[1][1], 0)*yv[i]
+		gx[i] = math.Abs(h[0][0])*cmplx.Abs(xv[i]) + math.Abs(h[0][1])*cmplx.Abs(yv[i])
+		gy[i] = math.Abs(h[1][0])*cmplx.Abs(xv[i]) + math.Abs(h[1][1])*cmplx.Abs(yv[i])
+	}
+	return s.checkVector(t, desc, "x", x, xs, n, incX, wx, gx) &&
+		s.checkVector(t, desc, "y", y, ys, n, incY, wy, gy)
+}
+
+func testDot(s *suite, t *testing.T, name string) {
+	conj := strings.HasSuffix(name, "dotc")
+	s.checkDot(t, name, func(n int, x []complex128, incX int, y []complex128, incY int) ([]interface{}, complex128, float64) {
+		var want complex128
+		var g float64
+		for i := 0; i < n; i++ {
+			p := conjIf(conj, x[i*incX]) * y[i*incY]
+			want += p
+			g += cmplx.Abs(p)
+		}
+		return []interface{}{n, x, incX, y, incY}, want, g
+	})
+}
+
+func testSdsdot(s *suite, t *testing.T, name string) {
+	for _, alpha := range s.alphas() {
+		s.checkDot(t, name, func(n int, x []complex128, incX int, y []complex128, incY int) ([]interface{}, complex128, float64) {
+			want := alpha
+			g := cmplx.Abs(alpha)
+			for i := 0; i < n; i++ {
+				p := x[i*incX] * y[i*incY]
+				want += p
+				g += cmplx.Abs(p)
+			}
+			return []interface{}{n, alpha, x, incX, y, incY}, want, g
+		})
+	}
+}
+
+func testDsdot(s *suite, t *testing.T, name string) {
+	s.checkDot(t, name, func(n int, x []complex128, incX int, y []complex128, incY int) ([]interface{}, complex128, float64) {
+		// The vectors are single precision.
+		for _, v := range [][]complex128{x, y} {
+			for i := range v {
+				v[i] = complex(float64(float32(real(v[i]))), 0)
+			}
+		}
+		var want complex128
+		var g float64
+		for i := 0; i < n; i++ {
+			p := x[i*incX] * y[i*incY]
+			want += p
+			g += cmplx.Abs(p)
+		}
+		return []interface{}{n, x, incX, y, incY}, want, g
+	})
+}
+
+// checkDot tests a dot product routine. The function args returns the
+// arguments of the call and the expected result with the magnitude of
+// its terms.
+func (s *suite) checkDot(t *testing.T, name string, args func(n int, x []complex128, incX int, y []complex128, incY int) ([]interface{}, complex128, float64)) {
+	for _, n := range s.dims {
+		for _, incX := range s.incs {
+			for _, incY := range s.incs {
+				x, y := s.vector(n, incX), s.vector(n, incY)
+				call, want, g := args(n, x, incX, y, incY)
+				xs, ys := clone(x), clone(y)
+				desc := s.describe(name, call)
+				res, ok := s.run(t, desc, name, call...)
+				if !ok || !s.unchanged(t, desc, "x", x, xs) || !s.unchanged(t, desc, "y", y, ys) {
+					return
+				}
+				if got := res[0].(complex128); !s.near(got, want, g) {
+					t.Errorf("%s: unexpected result: got %v want %v", desc, got, want)
+					return
+				}
+			}
+		}
+	}
+	x, y := s.vector(1, 1), s.vector(1, 1)
+	call, _, _ := args(1, x, 1, y, 1)
+	s.vectorPanics(t, name, call)
+}
+
+func testNrm2(s *suite, t *testing.T, name string) {
+	s.checkReduction(t, name, func(x []complex128) (complex128, float64) {
+		var ss float64
+		for _, v := range x {
+			ss += real(v)*real(v) + imag(v)*imag(v)
+		}
+		return complex(math.Sqrt(ss), 0), math.Sqrt(ss)
+	})
+}
+
+func testAsum(s *suite, t *testing.T, name string) {
+	s.checkReduction(t, name, func(x []complex128) (complex128, float64) {
+		var sum float64
+		for _, v := range x {
+			sum += math.Abs(real(v)) + math.Abs(imag(v))
+		}
+		return complex(sum, 0), sum
+	})
+}
+
+// checkReduction tests a routine that reduces a vector to a real value.
+func (s *suite) checkReduction(t *testing.T, name string, ref func(x []complex128) (complex128, float64)) {
+	for _, n := range s.dims {
+		for _, inc := range s.incs {
+			x := s.vector(n, inc)
+			xs := clone(x)
+			call := []interface{}{n, x, inc}
+			desc := s.describe(name, call)
+			res, ok := s.run(t, desc, name, call...)
+			if !ok || !s.unchanged(t, desc, "x", x, xs) {
+				return
+			}
+			want, g := ref(elems(xs, n, inc))
+			if got := res[0].(complex128); !s.near(got, want, g) {
+				t.Errorf("%s: unexpected result: got %v want %v", desc, real(got), real(want))
+				return
+			}
+		}
+	}
+	s.vectorPanics(t, name, []interface{}{1, s.vector(1, 1), 1})
+}
+
+func testIamax(s *suite, t *testing.T, name string) {
+	for _, n := range s.dims {
+		for _, inc := range s.incs {
+			x := s.vector(n, inc)
+			xs := clone(x)
+			call := []interface{}{n, x, inc}
+			desc := s.describe(name, call)
+			res, ok := s.run(t, desc, name, call...)
+			if !ok || !s.unchanged(t, desc, "x", x, xs) {
+				return
+			}
+			if n == 0 {
+				// The result for an empty vector
+				// is implementation defined.
+				continue
+			}
+			want := 0
+			var best float64
+			for i, v := range elems(xs, n, inc) {
+				if a := math.Abs(real(v)) + math.Abs(imag(v)); a > best {
+					want, best = i, a
+				}
+			}
+			if got := res[0].(int); got != want {
+				t.Errorf("%s: unexpected result: got %d want %d", desc, got, want)
+				return
+			}
+		}
+	}
+	s.vectorPanics(t, name, []interface{}{1, s.vector(1, 1), 1})
+}
+
+func testSwap(s *suite, t *testing.T, name string) {
+	s.checkUpdate(t, name, []complex128{0}, func(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) []interface{} {
+		return []interface{}{n, x, incX, y, incY}
+	}, func(alpha, x, y complex128) (complex128, complex128, float64, float64) {
+		return y, x, 0, 0
+	})
+}
+
+func testCopy(s *suite, t *testing.T, name string) {
+	s.checkUpdate(t, name, []complex128{0}, func(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) []interface{} {
+		return []interface{}{n, x, incX, y, incY}
+	}, func(alpha, x, y complex128) (complex128, complex128, float64, float64) {
+		return x, x, 0, 0
+	})
+}
+
+func testAxpy(s *suite, t *testing.T, name string) {
+	s.checkUpdate(t, name, s.alphas(), func(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) []interface{} {
+		return []interface{}{n, alpha, x, incX, y, incY}
+	}, func(alpha, x, y complex128) (complex128, complex128, float64, float64) {
+		return x, alpha*x + y, 0, cmplx.Abs(alpha*x) + cmplx.Abs(y)
+	})
+}
+
+// checkUpdate tests a routine that updates the elements of x and y
+// pairwise. The function ref returns the expected values of the updated
+// elements and the magnitudes of their terms.
+func (s *suite) checkUpdate(t *testing.T, name string, alphas []complex128, args func(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) []interface{}, ref func(alpha, x, y complex128) (complex128, complex128, float64, float64)) {
+	for _, n := range s.dims {
+		for _, incX := range s.incs {
+			for _, incY := range s.incs {
+				for _, alpha := range alphas {
+					x, y := s.vector(n, incX), s.vector(n, incY)
+					xs, ys := clone(x), clone(y)
+					call := args(n, alpha, x, incX, y, incY)
+					desc := s.describe(name, call)
+					if _, ok := s.run(t, desc, name, call...); !ok {
+						return
+					}
+					wx, wy := make([]complex128, n), make([]complex128, n)
+					gx, gy := make([]float64, n), make([]float64, n)
+					for i := 0; i < n; i++ {
+						wx[i], wy[i], gx[i], gy[i] = ref(alpha, xs[i*incX], ys[i*incY])
+					}
+					if !s.checkVector(t, desc, "x", x, xs, n, incX, wx, gx) ||
+						!s.checkVector(t, desc, "y", y, ys, n, incY, wy, gy) {
+						return
+					}
+				}
+			}
+		}
+	}
+	x, y := s.vector(1, 1), s.vector(1, 1)
+	s.vectorPanics(t, name, args(1, alphas[0], x, 1, y, 1))
+}
+
+func testScal(s *suite, t *testing.T, name string) {
+	for _, alpha := range s.alphas() {
+		if !s.checkScal(t, name, alpha, func(n int, x []complex128, inc int) []interface{} {
+			return []interface{}{n, alpha, x, inc}
+		}) {
+			return
+		}
+	}
+	s.vectorPanics(t, name, []interface{}{1, complex128(1), s.vector(1, 1), 1})
+}
+
+// testRealScal tests the scaling of a complex vector by a real scalar.
+func testRealScal(s *suite, t *testing.T, name string) {
+	for _, alpha := range s.reals() {
+		if !s.checkScal(t, name, complex(alpha, 0), func(n int, x []complex128, inc int) []interface{} {
+			return []interface{}{n, alpha, x, inc}
+		}) {
+			return
+		}
+	}
+	s.vectorPanics(t, name, []interface{}{1, 1.0, s.vector(1, 1), 1})
+}
+
+func (s *suite) checkScal(t *testing.T, name string, alpha complex128, args func(n int, x []complex128, inc int) []interface{}) bool {
+	for _, n := range s.dims {
+		for _, inc := range s.incs {
+			x := s.vector(n, inc)
+			xs := clone(x)
+			call := args(n, x, inc)
+			desc := s.describe(name, call)
+			if _, ok := s.run(t, desc, name, call...); !ok {
+				return false
+			}
+			want, g := make([]complex128, n), make([]float64, n)
+			for i, v := range elems(xs, n, inc) {
+				want[i] = alpha * v
+				g[i] = cmplx.Abs(want[i])
+			}
+			if !s.checkVector(t, desc, "x", x, xs, n, inc, want, g) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,481 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"math/cmplx"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func testGemv(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, tA := range s.transposes {
+			for _, m := range s.dims {
+				for _, n := range s.dims {
+					a := s.matrix(denseLayout(o, m, n), general)
+					if !s.checkMV(t, name, a, tA, func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{} {
+						return []interface{}{o, tA, m, n, alpha, a.data, a.ld, x, incX, beta, y, incY}
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.NoTrans, 1, 1, complex128(1), a, 1, x, 1, complex128(0), y, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 8, 0) &&
+		s.panics(t, name, args, 11, 0)
+}
+
+func testGbmv(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, tA := range s.transposes {
+			for _, m := range s.dims {
+				for _, n := range s.dims {
+					for _, kl := range s.bands {
+						for _, ku := range s.bands {
+							a := s.matrix(bandLayout(o, m, n, kl, ku), general)
+							if !s.checkMV(t, name, a, tA, func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{} {
+								return []interface{}{o, tA, m, n, kl, ku, alpha, a.data, a.ld, x, incX, beta, y, incY}
+							}) {
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(2, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.NoTrans, 1, 1, 0, 0, complex128(1), a, 1, x, 1, complex128(0), y, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 4, -1) &&
+		s.panics(t, name, args, 5, -1) &&
+		s.panics(t, name, args, 10, 0) &&
+		s.panics(t, name, args, 13, 0)
+}
+
+// symmetricShape returns the shape of the matrix operand of
+// the routine: hermitian for complex types, otherwise symmetric.
+func (s *suite) symmetricShape() shape {
+	if s.complex {
+		return hermitian
+	}
+	return symmetric
+}
+
+// testSymv tests DSYMV and ZHEMV.
+func testSymv(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, n := range s.dims {
+				a := s.matrix(triangleLayout(o, ul, n), s.symmetricShape())
+				if !s.checkMV(t, name, a, blas.NoTrans, func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{} {
+					return []interface{}{o, ul, n, alpha, a.data, a.ld, x, incX, beta, y, incY}
+				}) {
+					return
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, 1, complex128(1), a, 1, x, 1, complex128(0), y, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 7, 0) &&
+		s.panics(t, name, args, 10, 0)
+}
+
+// testSbmv tests DSBMV and ZHBMV.
+func testSbmv(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, n := range s.dims {
+				for _, k := range s.bands {
+					a := s.matrix(triangleBandLayout(o, ul, n, k), s.symmetricShape())
+					if !s.checkMV(t, name, a, blas.NoTrans, func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{} {
+						return []interface{}{o, ul, n, k, alpha, a.data, a.ld, x, incX, beta, y, incY}
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, 1, 0, complex128(1), a, 1, x, 1, complex128(0), y, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 8, 0) &&
+		s.panics(t, name, args, 11, 0)
+}
+
+// testSpmv tests DSPMV and ZHPMV.
+func testSpmv(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, n := range s.dims {
+				a := s.matrix(packedLayout(o, ul, n), s.symmetricShape())
+				if !s.checkMV(t, name, a, blas.NoTrans, func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{} {
+					return []interface{}{o, ul, n, alpha, a.data, x, incX, beta, y, incY}
+				}) {
+					return
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, 1, complex128(1), a, x, 1, complex128(0), y, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 6, 0) &&
+		s.panics(t, name, args, 9, 0)
+}
+
+// checkMV tests a routine computing y = alpha*op(A)*x + beta*y for each
+// combination of increments and scalars. The function args returns the
+// arguments of the call.
+func (s *suite) checkMV(t *testing.T, name string, a matrix, tA blas.Transpose, args func(alpha complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) []interface{}) bool {
+	lenX, lenY := a.n, a.m
+	if tA != blas.NoTrans {
+		lenX, lenY = lenY, lenX
+	}
+	for _, incX := range s.incs {
+		for _, incY := range s.incs {
+			for _, alpha := range s.alphas() {
+				for _, beta := range s.betas() {
+					x, y := s.vector(lenX, incX), s.vector(lenY, incY)
+					as, xs, ys := clone(a.data), clone(x), clone(y)
+					call := args(alpha, x, incX, beta, y, incY)
+					desc := s.describe(name, call)
+					if _, ok := s.run(t, desc, name, call...); !ok ||
+						!s.unchanged(t, desc, "A", a.data, as) ||
+						!s.unchanged(t, desc, "x", x, xs) {
+						return false
+					}
+					xv, yv := elems(xs, lenX, incX), elems(ys, lenY, incY)
+					want, g := make([]complex128, lenY), make([]float64, lenY)
+					for i := range want {
+						if a.m == 0 || a.n == 0 {
+							// Nothing is done for an empty matrix.
+							want[i] = yv[i]
+							continue
+						}
+						var v complex128
+						var mag float64
+						for j := range xv {
+							p := op(tA, a.a, i, j) * xv[j]
+							v += p
+							mag += cmplx.Abs(p)
+						}
+						want[i] = alpha*v + beta*yv[i]
+						g[i] = cmplx.Abs(alpha)*mag + cmplx.Abs(beta*yv[i])
+					}
+					if !s.checkVector(t, desc, "y", y, ys, lenY, incY, want, g) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+func testTrmv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, dense, false) }
+func testTbmv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, band, false) }
+func testTpmv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, packed, false) }
+func testTrsv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, dense, true) }
+func testTbsv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, band, true) }
+func testTpsv(s *suite, t *testing.T, name string) { s.triangularMV(t, name, packed, true) }
+
+// triangularMV tests the triangular matrix-vector product and solve
+// routines with the given matrix storage.
+func (s *suite) triangularMV(t *testing.T, name string, st storage, solve bool) {
+	ks := []int{0}
+	if st == band {
+		ks = s.bands
+	}
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, tA := range s.transposes {
+				for _, d := range s.diags {
+					for _, n := range s.dims {
+						for _, k := range ks {
+							var l layout
+							switch st {
+							case dense:
+								l = triangleLayout(o, ul, n)
+							case band:
+								l = triangleBandLayout(o, ul, n, k)
+							case packed:
+								l = packedLayout(o, ul, n)
+							}
+							l.unit = d == blas.Unit
+							a := s.matrix(l, triangular)
+							for _, inc := range s.incs {
+								x := s.vector(n, inc)
+								as, xs := clone(a.data), clone(x)
+								var call []interface{}
+								switch st {
+								case dense:
+									call = []interface{}{o, ul, tA, d, n, a.data, a.ld, x, inc}
+								case band:
+									call = []interface{}{o, ul, tA, d, n, k, a.data, a.ld, x, inc}
+								case packed:
+									call = []interface{}{o, ul, tA, d, n, a.data, x, inc}
+								}
+								desc := s.describe(name, call)
+								if _, ok := s.run(t, desc, name, call...); !ok ||
+									!s.unchanged(t, desc, "A", a.data, as) {
+									return
+								}
+								if !s.checkTriangular(t, desc, a.a, tA, x, xs, n, inc, solve) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// The arguments are placed in the order of the dense routines, with
+	// k and lda removed for packed storage, to check for panics.
+	a, x := s.vector(2, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, blas.NoTrans, blas.NonUnit, 1, a, 1, x, 1}
+	inc := 8
+	switch st {
+	case band:
+		args = []interface{}{blas.RowMajor, blas.Upper, blas.NoTrans, blas.NonUnit, 1, 0, a, 1, x, 1}
+		inc = 9
+		if !s.panics(t, name, args, 5, -1) {
+			return
+		}
+	case packed:
+		args = []interface{}{blas.RowMajor, blas.Upper, blas.NoTrans, blas.NonUnit, 1, a, x, 1}
+		inc = 7
+	}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 3, blas.Diag(0)) &&
+		s.panics(t, name, args, 4, -1) &&
+		s.panics(t, name, args, inc, 0)
+}
+
+// checkTriangular checks the result x of a triangular matrix-vector
+// product or solve with the n×n matrix a, given the original vector xs.
+// For a solve, op(A)*x must reproduce the original vector.
+func (s *suite) checkTriangular(t *testing.T, desc string, a [][]complex128, tA blas.Transpose, x, xs []complex128, n, inc int, solve bool) bool {
+	in := elems(xs, n, inc)
+	if solve {
+		in = elems(x, n, inc)
+	}
+	prod, g := make([]complex128, n), make([]float64, n)
+	for i := range prod {
+		for j := range in {
+			p := op(tA, a, i, j) * in[j]
+			prod[i] += p
+			g[i] += cmplx.Abs(p)
+		}
+	}
+	if !solve {
+		return s.checkVector(t, desc, "x", x, xs, n, inc, prod, g)
+	}
+	// Check only the unreferenced elements of x.
+	if !s.checkVector(t, desc, "x", x, xs, n, inc, in, make([]float64, n)) {
+		return false
+	}
+	for i, want := range elems(xs, n, inc) {
+		if !s.near(prod[i], want, g[i]) {
+			t.Errorf("%s: unexpected (op(A)*x)[%d]: got %v want %v", desc, i, prod[i], want)
+			return false
+		}
+	}
+	return true
+}
+
+// testGer tests DGER, ZGERU and ZGERC.
+func testGer(s *suite, t *testing.T, name string) {
+	conj := strings.HasSuffix(name, "gerc")
+	for _, o := range s.orders {
+		for _, m := range s.dims {
+			for _, n := range s.dims {
+				for _, incX := range s.incs {
+					for _, incY := range s.incs {
+						for _, alpha := range s.alphas() {
+							a := s.matrix(denseLayout(o, m, n), general)
+							x, y := s.vector(m, incX), s.vector(n, incY)
+							as, xs, ys := clone(a.data), clone(x), clone(y)
+							call := []interface{}{o, m, n, alpha, x, incX, y, incY, a.data, a.ld}
+							desc := s.describe(name, call)
+							if _, ok := s.run(t, desc, name, call...); !ok ||
+								!s.unchanged(t, desc, "x", x, xs) ||
+								!s.unchanged(t, desc, "y", y, ys) {
+								return
+							}
+							xv, yv := elems(xs, m, incX), elems(ys, n, incY)
+							want, g := full(m, n)
+							for i := range want {
+								for j := range want[i] {
+									p := alpha * xv[i] * conjIf(conj, yv[j])
+									want[i][j] = p + a.a[i][j]
+									g[i][j] = cmplx.Abs(p) + cmplx.Abs(a.a[i][j])
+								}
+							}
+							if !s.checkMatrix(t, desc, "A", a.layout, a.data, as, want, g) {
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, 1, 1, complex128(1), x, 1, y, 1, a, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, -1) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 5, 0) &&
+		s.panics(t, name, args, 7, 0)
+}
+
+// testSyr tests DSYR and ZHER.
+func testSyr(s *suite, t *testing.T, name string) { s.rankOne(t, name, false) }
+
+// testSpr tests DSPR and ZHPR.
+func testSpr(s *suite, t *testing.T, name string) { s.rankOne(t, name, true) }
+
+// rankOne tests the symmetric or hermitian rank one updates
+// A = alpha*x*x^T + A and A = alpha*x*x^H + A.
+func (s *suite) rankOne(t *testing.T, name string, isPacked bool) {
+	herm := s.complex
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, n := range s.dims {
+				for _, inc := range s.incs {
+					for _, alpha := range s.reals() {
+						l := triangleLayout(o, ul, n)
+						if isPacked {
+							l = packedLayout(o, ul, n)
+						}
+						a := s.matrix(l, s.symmetricShape())
+						x := s.vector(n, inc)
+						as, xs := clone(a.data), clone(x)
+						call := []interface{}{o, ul, n, alpha, x, inc, a.data, a.ld}
+						if isPacked {
+							call = []interface{}{o, ul, n, alpha, x, inc, a.data}
+						}
+						desc := s.describe(name, call)
+						if _, ok := s.run(t, desc, name, call...); !ok ||
+							!s.unchanged(t, desc, "x", x, xs) {
+							return
+						}
+						xv := elems(xs, n, inc)
+						want, g := full(n, n)
+						for i := range want {
+							for j := range want[i] {
+								p := complex(alpha, 0) * xv[i] * conjIf(herm, xv[j])
+								want[i][j] = p + a.a[i][j]
+								g[i][j] = cmplx.Abs(p) + cmplx.Abs(a.a[i][j])
+							}
+						}
+						if !s.checkMatrix(t, desc, "A", a.layout, a.data, as, want, g) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+	a, x := s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, 1, 1.0, x, 1, a, 1}
+	if isPacked {
+		args = args[:7]
+	}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 5, 0)
+}
+
+// testSyr2 tests DSYR2 and ZHER2.
+func testSyr2(s *suite, t *testing.T, name string) { s.rankTwo(t, name, false) }
+
+// testSpr2 tests DSPR2 and ZHPR2.
+func testSpr2(s *suite, t *testing.T, name string) { s.rankTwo(t, name, true) }
+
+// rankTwo tests the symmetric or hermitian rank two updates
+// A = alpha*x*y^T + alpha*y*x^T + A and
+// A = alpha*x*y^H + conj(alpha)*y*x^H + A.
+func (s *suite) rankTwo(t *testing.T, name string, isPacked bool) {
+	herm := s.complex
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, n := range s.dims {
+				for _, incX := range s.incs {
+					for _, incY := range s.incs {
+						for _, alpha := range s.alphas() {
+							l := triangleLayout(o, ul, n)
+							if isPacked {
+								l = packedLayout(o, ul, n)
+							}
+							a := s.matrix(l, s.symmetricShape())
+							x, y := s.vector(n, incX), s.vector(n, incY)
+							as, xs, ys := clone(a.data), clone(x), clone(y)
+							call := []interface{}{o, ul, n, alpha, x, incX, y, incY, a.data, a.ld}
+							if isPacked {
+								call = []interface{}{o, ul, n, alpha, x, incX, y, incY, a.data}
+							}
+							desc := s.describe(name, call)
+							if _, ok := s.run(t, desc, name, call...); !ok ||
+								!s.unchanged(t, desc, "x", x, xs) ||
+								!s.unchanged(t, desc, "y", y, ys) {
+								return
+							}
+							xv, yv := elems(xs, n, incX), elems(ys, n, incY)
+							want, g := full(n, n)
+							for i := range want {
+								for j := range want[i] {
+									p := alpha * xv[i] * conjIf(herm, yv[j])
+									q := conjIf(herm, alpha) * yv[i] * conjIf(herm, xv[j])
+									want[i][j] = p + q + a.a[i][j]
+									g[i][j] = cmplx.Abs(p) + cmplx.Abs(q) + cmplx.Abs(a.a[i][j])
+								}
+							}
+							if !s.checkMatrix(t, desc, "A", a.layout, a.data, as, want, g) {
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, x, y := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, 1, complex128(1), x, 1, y, 1, a, 1}
+	if isPacked {
+		args = args[:9]
+	}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 2, -1) &&
+		s.panics(t, name, args, 5, 0) &&
+		s.panics(t, name, args, 7, 0)
+}
@@ -0,0 +1,394 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"math/cmplx"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// dimsOf returns the dimensions of an m×n matrix
+// stored transposed if t is not blas.NoTrans.
+func dimsOf(t blas.Transpose, m, n int) (int, int) {
+	if t == blas.NoTrans {
+		return m, n
+	}
+	return n, m
+}
+
+func testGemm(s *suite, t *testing.T, name string) {
+	for _, o := range s.orders {
+		for _, tA := range s.transposes {
+			for _, tB := range s.transposes {
+				for _, m := range s.dims {
+					for _, n := range s.dims {
+						for _, k := range s.dims {
+							for _, alpha := range s.alphas() {
+								for _, beta := range s.betas() {
+									ra, ca := dimsOf(tA, m, k)
+									rb, cb := dimsOf(tB, k, n)
+									a := s.matrix(denseLayout(o, ra, ca), general)
+									b := s.matrix(denseLayout(o, rb, cb), general)
+									c := s.matrix(denseLayout(o, m, n), general)
+									as, bs, cs := clone(a.data), clone(b.data), clone(c.data)
+									call := []interface{}{o, tA, tB, m, n, k, alpha, a.data, a.ld, b.data, b.ld, beta, c.data, c.ld}
+									desc := s.describe(name, call)
+									if _, ok := s.run(t, desc, name, call...); !ok ||
+										!s.unchanged(t, desc, "A", a.data, as) ||
+										!s.unchanged(t, desc, "B", b.data, bs) {
+										return
+									}
+									want, g := full(m, n)
+									for i := range want {
+										for j := range want[i] {
+											var v complex128
+											var mag float64
+											for l := 0; l < k; l++ {
+												p := op(tA, a.a, i, l) * op(tB, b.a, l, j)
+												v += p
+												mag += cmplx.Abs(p)
+											}
+											want[i][j] = alpha*v + beta*c.a[i][j]
+											g[i][j] = cmplx.Abs(alpha)*mag + cmplx.Abs(beta*c.a[i][j])
+										}
+									}
+									if !s.checkMatrix(t, desc, "C", c.layout, c.data, cs, want, g) {
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, b, c := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.NoTrans, blas.NoTrans, 1, 1, 1, complex128(1), a, 1, b, 1, complex128(0), c, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 4, -1) &&
+		s.panics(t, name, args, 5, -1)
+}
+
+// testSymm tests DSYMM, ZSYMM and ZHEMM.
+func testSymm(s *suite, t *testing.T, name string) {
+	sh := symmetric
+	if strings.HasSuffix(name, "hemm") {
+		sh = hermitian
+	}
+	for _, o := range s.orders {
+		for _, side := range s.sides {
+			for _, ul := range s.uplos {
+				for _, m := range s.dims {
+					for _, n := range s.dims {
+						for _, alpha := range s.alphas() {
+							for _, beta := range s.betas() {
+								na := m
+								if side == blas.Right {
+									na = n
+								}
+								a := s.matrix(triangleLayout(o, ul, na), sh)
+								b := s.matrix(denseLayout(o, m, n), general)
+								c := s.matrix(denseLayout(o, m, n), general)
+								as, bs, cs := clone(a.data), clone(b.data), clone(c.data)
+								call := []interface{}{o, side, ul, m, n, alpha, a.data, a.ld, b.data, b.ld, beta, c.data, c.ld}
+								desc := s.describe(name, call)
+								if _, ok := s.run(t, desc, name, call...); !ok ||
+									!s.unchanged(t, desc, "A", a.data, as) ||
+									!s.unchanged(t, desc, "B", b.data, bs) {
+									return
+								}
+								want, g := full(m, n)
+								for i := range want {
+									for j := range want[i] {
+										var v complex128
+										var mag float64
+										for l := 0; l < na; l++ {
+											var p complex128
+											if side == blas.Left {
+												p = a.a[i][l] * b.a[l][j]
+											} else {
+												p = b.a[i][l] * a.a[l][j]
+											}
+											v += p
+											mag += cmplx.Abs(p)
+										}
+										want[i][j] = alpha*v + beta*c.a[i][j]
+										g[i][j] = cmplx.Abs(alpha)*mag + cmplx.Abs(beta*c.a[i][j])
+									}
+								}
+								if !s.checkMatrix(t, desc, "C", c.layout, c.data, cs, want, g) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, b, c := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Left, blas.Upper, 1, 1, complex128(1), a, 1, b, 1, complex128(0), c, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Side(0)) &&
+		s.panics(t, name, args, 2, blas.Uplo(0)) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 4, -1)
+}
+
+// rankKTransposes returns the transpose arguments valid for a
+// symmetric or hermitian rank k update.
+func (s *suite) rankKTransposes(herm bool) []blas.Transpose {
+	switch {
+	case herm:
+		return []blas.Transpose{blas.NoTrans, blas.ConjTrans}
+	case s.complex:
+		return []blas.Transpose{blas.NoTrans, blas.Trans}
+	}
+	return s.transposes
+}
+
+// testSyrk tests DSYRK, ZSYRK and ZHERK.
+func testSyrk(s *suite, t *testing.T, name string) {
+	herm := strings.HasSuffix(name, "herk")
+	sh := symmetric
+	alphas, betas := s.alphas(), s.betas()
+	if herm {
+		sh = hermitian
+		alphas, betas = nil, nil
+		for _, v := range s.reals() {
+			alphas = append(alphas, complex(v, 0))
+			betas = append(betas, complex(v, 0))
+		}
+	}
+	scalar := func(v complex128) interface{} {
+		if herm {
+			return real(v)
+		}
+		return v
+	}
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, tA := range s.rankKTransposes(herm) {
+				for _, n := range s.dims {
+					for _, k := range s.dims {
+						for _, alpha := range alphas {
+							for _, beta := range betas {
+								ra, ca := dimsOf(tA, n, k)
+								a := s.matrix(denseLayout(o, ra, ca), general)
+								c := s.matrix(triangleLayout(o, ul, n), sh)
+								as, cs := clone(a.data), clone(c.data)
+								call := []interface{}{o, ul, tA, n, k, scalar(alpha), a.data, a.ld, scalar(beta), c.data, c.ld}
+								desc := s.describe(name, call)
+								if _, ok := s.run(t, desc, name, call...); !ok ||
+									!s.unchanged(t, desc, "A", a.data, as) {
+									return
+								}
+								want, g := full(n, n)
+								for i := range want {
+									for j := range want[i] {
+										var v complex128
+										var mag float64
+										for l := 0; l < k; l++ {
+											p := op(tA, a.a, i, l) * conjIf(herm, op(tA, a.a, j, l))
+											v += p
+											mag += cmplx.Abs(p)
+										}
+										want[i][j] = alpha*v + beta*c.a[i][j]
+										g[i][j] = cmplx.Abs(alpha)*mag + cmplx.Abs(beta*c.a[i][j])
+									}
+								}
+								if !s.checkMatrix(t, desc, "C", c.layout, c.data, cs, want, g) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, c := s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, blas.NoTrans, 1, 1, scalar(1), a, 1, scalar(0), c, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 4, -1)
+}
+
+// testSyr2k tests DSYR2K, ZSYR2K and ZHER2K.
+func testSyr2k(s *suite, t *testing.T, name string) {
+	herm := strings.HasSuffix(name, "her2k")
+	sh := symmetric
+	betas := s.betas()
+	if herm {
+		sh = hermitian
+		betas = nil
+		for _, v := range s.reals() {
+			betas = append(betas, complex(v, 0))
+		}
+	}
+	scalar := func(v complex128) interface{} {
+		if herm {
+			return real(v)
+		}
+		return v
+	}
+	for _, o := range s.orders {
+		for _, ul := range s.uplos {
+			for _, tA := range s.rankKTransposes(herm) {
+				for _, n := range s.dims {
+					for _, k := range s.dims {
+						for _, alpha := range s.alphas() {
+							for _, beta := range betas {
+								ra, ca := dimsOf(tA, n, k)
+								a := s.matrix(denseLayout(o, ra, ca), general)
+								b := s.matrix(denseLayout(o, ra, ca), general)
+								c := s.matrix(triangleLayout(o, ul, n), sh)
+								as, bs, cs := clone(a.data), clone(b.data), clone(c.data)
+								call := []interface{}{o, ul, tA, n, k, alpha, a.data, a.ld, b.data, b.ld, scalar(beta), c.data, c.ld}
+								desc := s.describe(name, call)
+								if _, ok := s.run(t, desc, name, call...); !ok ||
+									!s.unchanged(t, desc, "A", a.data, as) ||
+									!s.unchanged(t, desc, "B", b.data, bs) {
+									return
+								}
+								want, g := full(n, n)
+								for i := range want {
+									for j := range want[i] {
+										var v complex128
+										var mag float64
+										for l := 0; l < k; l++ {
+											p := alpha * op(tA, a.a, i, l) * conjIf(herm, op(tA, b.a, j, l))
+											q := conjIf(herm, alpha) * op(tA, b.a, i, l) * conjIf(herm, op(tA, a.a, j, l))
+											v += p + q
+											mag += cmplx.Abs(p) + cmplx.Abs(q)
+										}
+										want[i][j] = v + beta*c.a[i][j]
+										g[i][j] = mag + cmplx.Abs(beta*c.a[i][j])
+									}
+								}
+								if !s.checkMatrix(t, desc, "C", c.layout, c.data, cs, want, g) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, b, c := s.vector(1, 1), s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Upper, blas.NoTrans, 1, 1, complex128(1), a, 1, b, 1, scalar(0), c, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Uplo(0)) &&
+		s.panics(t, name, args, 3, -1) &&
+		s.panics(t, name, args, 4, -1)
+}
+
+func testTrmm(s *suite, t *testing.T, name string) { s.triangularMM(t, name, false) }
+func testTrsm(s *suite, t *testing.T, name string) { s.triangularMM(t, name, true) }
+
+// triangularMM tests the triangular matrix-matrix product and solve routines.
+func (s *suite) triangularMM(t *testing.T, name string, solve bool) {
+	for _, o := range s.orders {
+		for _, side := range s.sides {
+			for _, ul := range s.uplos {
+				for _, tA := range s.transposes {
+					for _, d := range s.diags {
+						for _, m := range s.dims {
+							for _, n := range s.dims {
+								for _, alpha := range s.alphas() {
+									na := m
+									if side == blas.Right {
+										na = n
+									}
+									l := triangleLayout(o, ul, na)
+									l.unit = d == blas.Unit
+									a := s.matrix(l, triangular)
+									b := s.matrix(denseLayout(o, m, n), general)
+									as, bs := clone(a.data), clone(b.data)
+									call := []interface{}{o, side, ul, tA, d, m, n, alpha, a.data, a.ld, b.data, b.ld}
+									desc := s.describe(name, call)
+									if _, ok := s.run(t, desc, name, call...); !ok ||
+										!s.unchanged(t, desc, "A", a.data, as) {
+										return
+									}
+									if !s.checkTriangularMM(t, desc, a.a, b, bs, side, tA, alpha, solve) {
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	a, b := s.vector(1, 1), s.vector(1, 1)
+	args := []interface{}{blas.RowMajor, blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, 1, 1, complex128(1), a, 1, b, 1}
+	_ = s.panics(t, name, args, 0, blas.Order(0)) &&
+		s.panics(t, name, args, 1, blas.Side(0)) &&
+		s.panics(t, name, args, 2, blas.Uplo(0)) &&
+		s.panics(t, name, args, 4, blas.Diag(0)) &&
+		s.panics(t, name, args, 5, -1) &&
+		s.panics(t, name, args, 6, -1)
+}
+
+// checkTriangularMM checks the result held in b of a triangular matrix
+// product or solve with the matrix a, given the original storage of b in
+// bs. For a solve, op(A)*X or X*op(A) must reproduce alpha*B.
+func (s *suite) checkTriangularMM(t *testing.T, desc string, a [][]complex128, b matrix, bs []complex128, side blas.Side, tA blas.Transpose, alpha complex128, solve bool) bool {
+	m, n := b.m, b.n
+	x, _ := full(m, n)
+	for i := range x {
+		for j := range x[i] {
+			x[i][j] = b.data[b.index(i, j)]
+		}
+	}
+	in, scale := b.a, alpha
+	if solve {
+		in, scale = x, 1
+	}
+	prod, g := full(m, n)
+	for i := range prod {
+		for j := range prod[i] {
+			var mag float64
+			for l := range a {
+				var p complex128
+				if side == blas.Left {
+					p = op(tA, a, i, l) * in[l][j]
+				} else {
+					p = in[i][l] * op(tA, a, l, j)
+				}
+				prod[i][j] += p
+				mag += cmplx.Abs(p)
+			}
+			prod[i][j] *= scale
+			g[i][j] = cmplx.Abs(scale) * mag
+		}
+	}
+	if !solve {
+		return s.checkMatrix(t, desc, "B", b.layout, b.data, bs, prod, g)
+	}
+	// Check only the storage outside of B.
+	if !s.checkMatrix(t, desc, "B", b.layout, b.data, bs, x, g) {
+		return false
+	}
+	for i := range prod {
+		for j := range prod[i] {
+			want := alpha * b.a[i][j]
+			if !s.near(prod[i][j], want, g[i][j]+cmplx.Abs(want)) {
+				t.Errorf("%s: unexpected (op(A)*X)[%d][%d]: got %v want %v", desc, i, j, prod[i][j], want)
+				return false
+			}
+		}
+	}
+	return true
+}
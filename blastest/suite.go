@@ -0,0 +1,720 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blastest
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+const (
+	// thresh is the largest acceptable ratio of the error in a result
+	// to the relative machine precision times the magnitude of the
+	// terms contributing to it.
+	thresh = 16
+
+	rogue = -1e10 // Value placed in storage that must not be referenced.
+
+	// guard is the number of elements after the end of each slice passed
+	// to the implementation that are checked for writes.
+	guard = 16
+)
+
+var (
+	dims       = []int{0, 1, 2, 3, 5, 9}
+	bands      = []int{0, 1, 2, 4}
+	incs       = []int{1, 2}
+	orders     = []blas.Order{blas.RowMajor, blas.ColMajor}
+	transposes = []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans}
+	uplos      = []blas.Uplo{blas.Upper, blas.Lower}
+	diags      = []blas.Diag{blas.NonUnit, blas.Unit}
+	sides      = []blas.Side{blas.Left, blas.Right}
+)
+
+// routine is a routine name and the test to run for it.
+type routine struct {
+	name string
+	test func(s *suite, t *testing.T, name string)
+}
+
+// suite runs the conformance tests for an implementation of one of the
+// blas interfaces. Routines are called by name, and all data is held as
+// complex128 values that are converted to and from the element type of
+// the implementation around each call.
+type suite struct {
+	impl    reflect.Value
+	complex bool    // Whether the element type is complex.
+	single  bool    // Whether the element type is single precision.
+	eps     float64 // Relative machine precision of the element type.
+	rnd     *rand.Rand
+
+	// The argument values to test.
+	dims, bands, incs []int
+	orders            []blas.Order
+	transposes        []blas.Transpose
+	uplos             []blas.Uplo
+	diags             []blas.Diag
+	sides             []blas.Side
+
+	*fuzzer // Non-nil when the suite is run by a fuzz target.
+}
+
+func newSuite(impl interface{}, complex, single bool) *suite {
+	s := &suite{
+		impl:    reflect.ValueOf(impl),
+		complex: complex,
+		single:  single,
+
+		dims:       dims,
+		bands:      bands,
+		incs:       incs,
+		orders:     orders,
+		transposes: transposes,
+		uplos:      uplos,
+		diags:      diags,
+		sides:      sides,
+	}
+	if single {
+		s.eps = float64(math.Nextafter32(1, 2) - 1)
+	} else {
+		s.eps = math.Nextafter(1, 2) - 1
+	}
+	return s
+}
+
+func (s *suite) test(t *testing.T, routines []routine) {
+	for _, r := range routines {
+		r := r
+		t.Run(r.name, func(t *testing.T) {
+			s.rnd = rand.New(rand.NewSource(1))
+			r.test(s, t, r.name)
+		})
+	}
+}
+
+// overrun is the panic value used by call when the implementation
+// writes past the end of a slice argument.
+type overrun struct {
+	name string
+	arg  int
+}
+
+func (e overrun) String() string {
+	return fmt.Sprintf("%s wrote past the end of argument %d", e.name, e.arg+1)
+}
+
+// call calls the named method of the implementation. Arguments of type
+// []complex128, complex128 and float64 are converted to the types of the
+// corresponding parameters, and converted slices are copied back after
+// the call. Converted slices are followed by guard elements set to rogue,
+// and call panics with an overrun if any are modified. Floating point and
+// complex results are returned as complex128 and *blas.SrotmParams results
+// are returned as *blas.DrotmParams.
+func (s *suite) call(name string, args ...interface{}) []interface{} {
+	m := s.impl.MethodByName(name)
+	if !m.IsValid() {
+		panic("blastest: no method " + name)
+	}
+	typ := m.Type()
+	in := make([]reflect.Value, len(args))
+	var after []func()
+	for i, arg := range args {
+		pt := typ.In(i)
+		switch arg := arg.(type) {
+		case []complex128:
+			i := i
+			v := reflect.MakeSlice(pt, len(arg), len(arg)+guard)
+			for j, e := range arg {
+				setElem(v.Index(j), e)
+			}
+			g := v.Slice(len(arg), len(arg)+guard)
+			for j := 0; j < guard; j++ {
+				setElem(g.Index(j), s.rogue())
+			}
+			in[i] = v
+			after = append(after, func() {
+				for j := 0; j < guard; j++ {
+					if elem(g.Index(j)) != s.round(s.rogue()) {
+						panic(overrun{name: name, arg: i})
+					}
+				}
+				for j := range arg {
+					arg[j] = elem(v.Index(j))
+				}
+			})
+		case complex128:
+			in[i] = reflect.New(pt).Elem()
+			setElem(in[i], arg)
+		case float64:
+			in[i] = reflect.New(pt).Elem()
+			setElem(in[i], complex(arg, 0))
+		case *blas.DrotmParams:
+			if pt != reflect.TypeOf(arg) {
+				p := &blas.SrotmParams{Flag: float32(arg.Flag)}
+				for k, h := range arg.H {
+					p.H[k] = float32(h)
+				}
+				in[i] = reflect.ValueOf(p)
+				break
+			}
+			in[i] = reflect.ValueOf(arg)
+		default:
+			in[i] = reflect.ValueOf(arg)
+		}
+	}
+	out := m.Call(in)
+	for _, f := range after {
+		f()
+	}
+	res := make([]interface{}, len(out))
+	for i, v := range out {
+		switch v.Kind() {
+		case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+			res[i] = elem(v)
+		case reflect.Int:
+			res[i] = int(v.Int())
+		default:
+			if p, ok := v.Interface().(*blas.SrotmParams); ok {
+				d := &blas.DrotmParams{Flag: float64(p.Flag)}
+				for k, h := range p.H {
+					d.H[k] = float64(h)
+				}
+				res[i] = d
+				break
+			}
+			res[i] = v.Interface()
+		}
+	}
+	return res
+}
+
+func setElem(v reflect.Value, x complex128) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(real(x))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(x)
+	default:
+		panic("blastest: bad element type")
+	}
+}
+
+func elem(v reflect.Value) complex128 {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return complex(v.Float(), 0)
+	}
+	return v.Complex()
+}
+
+// run calls the named method, reporting an error if the call panics.
+// When fuzzing, run first makes the call with an illegal argument and
+// a panic with a conventional message is not an error.
+func (s *suite) run(t *testing.T, desc, name string, args ...interface{}) (res []interface{}, ok bool) {
+	t.Helper()
+	if s.fuzzer != nil && !s.illegal(t, name, args) {
+		return nil, false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			ok = false
+			if _, bad := r.(overrun); bad {
+				t.Errorf("%s: %v", desc, r)
+				return
+			}
+			if s.fuzzer == nil || !isMessage(r) {
+				t.Errorf("%s: unexpected panic: %v", desc, r)
+			}
+		}
+	}()
+	return s.call(name, args...), true
+}
+
+// panics reports an error if calling the named method with args, with
+// the ith argument replaced by v, does not panic.
+func (s *suite) panics(t *testing.T, name string, args []interface{}, i int, v interface{}) bool {
+	t.Helper()
+	bad := make([]interface{}, len(args))
+	copy(bad, args)
+	bad[i] = v
+	r := func() (r interface{}) {
+		defer func() {
+			r = recover()
+		}()
+		s.call(name, bad...)
+		return nil
+	}()
+	switch r.(type) {
+	case nil:
+		t.Errorf("%s: expected panic for illegal argument %d", s.describe(name, bad), i+1)
+		return false
+	case overrun:
+		t.Errorf("%s: %v", s.describe(name, bad), r)
+		return false
+	}
+	return true
+}
+
+// describe returns a description of a call for use in error messages.
+func (s *suite) describe(name string, args []interface{}) string {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	buf.WriteByte('(')
+	for i, arg := range args {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		switch arg := arg.(type) {
+		case []complex128:
+			fmt.Fprintf(&buf, "[%d]", len(arg))
+		case complex128:
+			if s.complex {
+				fmt.Fprint(&buf, arg)
+			} else {
+				fmt.Fprint(&buf, real(arg))
+			}
+		case *blas.DrotmParams:
+			fmt.Fprintf(&buf, "%v", *arg)
+		default:
+			if n, ok := names[arg]; ok {
+				buf.WriteString(n)
+			} else {
+				fmt.Fprint(&buf, arg)
+			}
+		}
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+var names = map[interface{}]string{
+	blas.RowMajor:  "RowMajor",
+	blas.ColMajor:  "ColMajor",
+	blas.NoTrans:   "NoTrans",
+	blas.Trans:     "Trans",
+	blas.ConjTrans: "ConjTrans",
+	blas.Upper:     "Upper",
+	blas.Lower:     "Lower",
+	blas.NonUnit:   "NonUnit",
+	blas.Unit:      "Unit",
+	blas.Left:      "Left",
+	blas.Right:     "Right",
+}
+
+// round rounds x to the element type of the implementation.
+func (s *suite) round(x complex128) complex128 {
+	if !s.complex {
+		x = complex(real(x), 0)
+	}
+	if s.single {
+		x = complex(float64(float32(real(x))), float64(float32(imag(x))))
+	}
+	return x
+}
+
+// value returns a random value with parts in [-0.5, 0.5).
+func (s *suite) value() complex128 {
+	var im float64
+	if s.complex {
+		im = s.rnd.Float64() - 0.5
+	}
+	return s.round(complex(s.rnd.Float64()-0.5, im))
+}
+
+func (s *suite) rogue() complex128 {
+	if s.complex {
+		return complex(rogue, rogue)
+	}
+	return rogue
+}
+
+// alphas and betas return the values of the scalar multipliers
+// to test, following the example input of the reference testers.
+func (s *suite) alphas() []complex128 {
+	if s.complex {
+		return []complex128{0, 1, s.round(complex(0.7, -0.9))}
+	}
+	return []complex128{0, 1, s.round(0.7)}
+}
+
+func (s *suite) betas() []complex128 {
+	if s.complex {
+		return []complex128{0, 1, s.round(complex(1.3, -1.1))}
+	}
+	return []complex128{0, 1, s.round(0.9)}
+}
+
+// reals returns the values of real scalar multipliers to test.
+func (s *suite) reals() []float64 {
+	return []float64{0, 1, real(s.round(0.7))}
+}
+
+// vector returns a vector of n random elements with increment inc.
+// The elements between and after the vector elements are set to rogue.
+func (s *suite) vector(n, inc int) []complex128 {
+	size := max(n-1, 0)*inc + 1 + inc
+	if s.fuzzer != nil {
+		size = s.size(vectorFootprint(n, inc))
+	}
+	v := make([]complex128, size)
+	for i := range v {
+		v[i] = s.rogue()
+	}
+	for i := 0; i < n; i++ {
+		v[i*inc] = s.value()
+	}
+	if s.fuzzer != nil {
+		s.register(v, operand{footprint: vectorFootprint(n, inc), vector: true})
+	}
+	return v
+}
+
+// vectorFootprint returns the length of storage holding a vector
+// of n elements with increment inc.
+func vectorFootprint(n, inc int) int {
+	if n <= 0 {
+		return 0
+	}
+	return (n-1)*inc + 1
+}
+
+// elems returns the n elements of v with increment inc.
+func elems(v []complex128, n, inc int) []complex128 {
+	e := make([]complex128, n)
+	for i := range e {
+		e[i] = v[i*inc]
+	}
+	return e
+}
+
+func clone(v []complex128) []complex128 {
+	return append([]complex128(nil), v...)
+}
+
+// storage is a matrix storage scheme.
+type storage int
+
+const (
+	dense storage = iota
+	band
+	packed
+)
+
+// shape is the structure of a matrix.
+type shape int
+
+const (
+	general shape = iota
+	symmetric
+	hermitian
+	triangular
+)
+
+// layout describes how an m×n matrix is held in a slice.
+type layout struct {
+	o      blas.Order
+	st     storage
+	ul     blas.Uplo // Stored triangle, or zero if the whole matrix is stored.
+	unit   bool      // Whether the diagonal is implicitly unit and not stored.
+	m, n   int
+	kl, ku int // Band widths for band storage.
+	ld     int
+}
+
+// The leading dimensions used for test matrices are larger than both
+// dimensions to catch implementations that use the wrong dimension as
+// the stride.
+
+func denseLayout(o blas.Order, m, n int) layout {
+	return layout{o: o, st: dense, m: m, n: n, ld: max(m, n) + 1}
+}
+
+func triangleLayout(o blas.Order, ul blas.Uplo, n int) layout {
+	l := denseLayout(o, n, n)
+	l.ul = ul
+	return l
+}
+
+func bandLayout(o blas.Order, m, n, kl, ku int) layout {
+	return layout{o: o, st: band, m: m, n: n, kl: kl, ku: ku, ld: kl + ku + 2}
+}
+
+// triangleBandLayout returns the layout of the ul triangle
+// of an n×n matrix with k diagonals either side of the main diagonal.
+func triangleBandLayout(o blas.Order, ul blas.Uplo, n, k int) layout {
+	l := bandLayout(o, n, n, 0, k)
+	if ul == blas.Lower {
+		l = bandLayout(o, n, n, k, 0)
+	}
+	l.ul = ul
+	return l
+}
+
+func packedLayout(o blas.Order, ul blas.Uplo, n int) layout {
+	return layout{o: o, st: packed, ul: ul, m: n, n: n}
+}
+
+// len returns the length of the storage slice.
+func (l layout) len() int {
+	if l.st == packed {
+		return max(l.n*(l.n+1)/2, 1)
+	}
+	return max(l.ld*max(l.m, l.n), 1)
+}
+
+// minLD returns the smallest legal leading dimension.
+func (l layout) minLD() int {
+	switch l.st {
+	case band:
+		return l.kl + l.ku + 1
+	case packed:
+		return 0
+	}
+	if l.o == blas.RowMajor {
+		return max(l.n, 1)
+	}
+	return max(l.m, 1)
+}
+
+// footprint returns the length of storage holding the stored elements.
+func (l layout) footprint() int {
+	var n int
+	for i := 0; i < l.m; i++ {
+		for j := 0; j < l.n; j++ {
+			n = max(n, l.index(i, j)+1)
+		}
+	}
+	return n
+}
+
+// index returns the storage index of the (i, j) element,
+// or -1 if it is not stored.
+func (l layout) index(i, j int) int {
+	if (l.ul == blas.Upper && i > j) || (l.ul == blas.Lower && i < j) || (l.unit && i == j) {
+		return -1
+	}
+	switch l.st {
+	case band:
+		if j-i > l.ku || i-j > l.kl {
+			return -1
+		}
+		if l.o == blas.RowMajor {
+			return i*l.ld + l.kl + j - i
+		}
+		return j*l.ld + l.ku + i - j
+	case packed:
+		if l.o == blas.ColMajor {
+			if l.ul == blas.Upper {
+				return j*(j+1)/2 + i
+			}
+			return j*l.n - j*(j-1)/2 + i - j
+		}
+		if l.ul == blas.Upper {
+			return i*l.n - i*(i-1)/2 + j - i
+		}
+		return i*(i+1)/2 + j
+	}
+	if l.o == blas.RowMajor {
+		return i*l.ld + j
+	}
+	return j*l.ld + i
+}
+
+// matrix is a test matrix held both in full and in storage.
+type matrix struct {
+	layout
+	a    [][]complex128 // a[i][j] is the (i, j) element.
+	data []complex128   // The storage passed to the implementation.
+}
+
+// matrix returns a random matrix with the given layout and shape. Elements
+// outside the band of a band matrix are zero and triangular matrices have
+// a dominant diagonal. Storage that is not part of the matrix is set to
+// rogue.
+func (s *suite) matrix(l layout, sh shape) matrix {
+	a := make([][]complex128, l.m)
+	for i := range a {
+		a[i] = make([]complex128, l.n)
+	}
+	k := max(l.kl, l.ku)
+	for i := range a {
+		for j := range a[i] {
+			if l.st == band {
+				if sh == general && (j-i > l.ku || i-j > l.kl) {
+					continue
+				}
+				if sh != general && (j-i > k || i-j > k) {
+					continue
+				}
+			}
+			switch sh {
+			case general:
+				a[i][j] = s.value()
+			case symmetric, hermitian:
+				if i > j {
+					continue
+				}
+				v := s.value()
+				if i == j && sh == hermitian {
+					v = complex(real(v), 0)
+				}
+				a[i][j] = v
+				if sh == hermitian {
+					v = cmplx.Conj(v)
+				}
+				a[j][i] = v
+			case triangular:
+				if (l.ul == blas.Upper && i > j) || (l.ul == blas.Lower && i < j) {
+					continue
+				}
+				a[i][j] = s.value()
+				if i == j {
+					a[i][j] = s.round(a[i][j] + 1)
+					if l.unit {
+						a[i][j] = 1
+					}
+				}
+			}
+		}
+	}
+	size := l.len()
+	if s.fuzzer != nil {
+		if l.st != packed {
+			l.ld = s.leading(l.minLD())
+		}
+		size = s.size(l.footprint())
+	}
+	data := make([]complex128, size)
+	for i := range data {
+		data[i] = s.rogue()
+	}
+	for i := range a {
+		for j := range a[i] {
+			if p := l.index(i, j); p >= 0 {
+				data[p] = a[i][j]
+			}
+		}
+	}
+	if s.fuzzer != nil {
+		s.register(data, operand{footprint: l.footprint(), minLD: l.minLD()})
+	}
+	return matrix{layout: l, a: a, data: data}
+}
+
+// op returns the (i, j) element of op(A).
+func op(tA blas.Transpose, a [][]complex128, i, j int) complex128 {
+	switch tA {
+	case blas.NoTrans:
+		return a[i][j]
+	case blas.Trans:
+		return a[j][i]
+	}
+	return cmplx.Conj(a[j][i])
+}
+
+// conjIf returns the conjugate of x if c is true.
+func conjIf(c bool, x complex128) complex128 {
+	if c {
+		return cmplx.Conj(x)
+	}
+	return x
+}
+
+// full returns an m×n matrix of zeros.
+func full(m, n int) ([][]complex128, [][]float64) {
+	a := make([][]complex128, m)
+	g := make([][]float64, m)
+	for i := range a {
+		a[i] = make([]complex128, n)
+		g[i] = make([]float64, n)
+	}
+	return a, g
+}
+
+// near returns whether got is within the test threshold of want, given the
+// magnitude g of the terms contributing to want.
+func (s *suite) near(got, want complex128, g float64) bool {
+	return cmplx.Abs(got-want) <= thresh*s.eps*g
+}
+
+func equal(a, b complex128) bool {
+	return a == b || (cmplx.IsNaN(a) && cmplx.IsNaN(b))
+}
+
+// unchanged reports an error if got differs from the saved copy orig.
+func (s *suite) unchanged(t *testing.T, desc, param string, got, orig []complex128) bool {
+	t.Helper()
+	for i := range orig {
+		if !equal(got[i], orig[i]) {
+			t.Errorf("%s: %s modified at %d: got %v want %v", desc, param, i, got[i], orig[i])
+			return false
+		}
+	}
+	return true
+}
+
+// checkVector reports an error if the n elements of v with increment inc
+// differ from want by more than the test threshold, or if the other
+// elements of v differ from orig.
+func (s *suite) checkVector(t *testing.T, desc, param string, v, orig []complex128, n, inc int, want []complex128, g []float64) bool {
+	t.Helper()
+	for i := range v {
+		if i%inc == 0 && i/inc < n {
+			k := i / inc
+			if !s.near(v[i], want[k], g[k]) {
+				t.Errorf("%s: unexpected %s[%d]: got %v want %v", desc, param, k, v[i], want[k])
+				return false
+			}
+			continue
+		}
+		if !equal(v[i], orig[i]) {
+			t.Errorf("%s: %s modified outside the vector at %d", desc, param, i)
+			return false
+		}
+	}
+	return true
+}
+
+// checkMatrix reports an error if the elements of the matrix held in got
+// with layout l differ from want by more than the test threshold, or if
+// the storage that is not part of the matrix differs from orig.
+func (s *suite) checkMatrix(t *testing.T, desc, param string, l layout, got, orig []complex128, want [][]complex128, g [][]float64) bool {
+	t.Helper()
+	ref := make([]bool, len(got))
+	for i := 0; i < l.m; i++ {
+		for j := 0; j < l.n; j++ {
+			p := l.index(i, j)
+			if p < 0 {
+				continue
+			}
+			ref[p] = true
+			if !s.near(got[p], want[i][j], g[i][j]) {
+				t.Errorf("%s: unexpected %s[%d][%d]: got %v want %v", desc, param, i, j, got[p], want[i][j])
+				return false
+			}
+		}
+	}
+	for p := range got {
+		if !ref[p] && !equal(got[p], orig[p]) {
+			t.Errorf("%s: %s modified outside the matrix at %d", desc, param, p)
+			return false
+		}
+	}
+	return true
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
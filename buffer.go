@@ -0,0 +1,177 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include <stdlib.h>
+#include <string.h>
+
+static void *alloc_zeroed(size_t n) {
+	void *p;
+	if (posix_memalign(&p, 64, n) != 0) {
+		return NULL;
+	}
+	memset(p, 0, n);
+	return p;
+}
+*/
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+// The Buffer types hold vectors and matrices in C memory outside the Go
+// heap. The slice returned by Data may be passed to the Blas methods like
+// any other slice, but as it does not point into the Go heap the cgo
+// pointer checks are not applied to it, it is never pinned and it is not
+// scanned by the garbage collector. The memory is zeroed and aligned to
+// 64 bytes.
+//
+// The memory of a Buffer is freed by Close, or by a finalizer when the
+// Buffer becomes unreachable. A Buffer must be kept reachable while its
+// Data slice is in use, for example by deferring Close or by calling
+// runtime.KeepAlive after the last use. A Buffer must not be closed
+// concurrently with other uses.
+
+// Float32Buffer is a float32 vector held in C memory.
+type Float32Buffer struct {
+	p    unsafe.Pointer
+	data []float32
+}
+
+// NewFloat32Buffer returns a Float32Buffer holding n zeroed values.
+func NewFloat32Buffer(n int) *Float32Buffer {
+	p := calloc(n, 4)
+	b := &Float32Buffer{p: p, data: unsafe.Slice((*float32)(p), n)}
+	runtime.SetFinalizer(b, (*Float32Buffer).Close)
+	return b
+}
+
+// Data returns a view of the buffer's memory. It returns nil after Close.
+func (b *Float32Buffer) Data() []float32 { return b.data }
+
+// Len returns the number of values in the buffer.
+func (b *Float32Buffer) Len() int { return len(b.data) }
+
+// Close frees the buffer's memory. Closing a closed buffer is a no-op.
+func (b *Float32Buffer) Close() error {
+	free(&b.p)
+	b.data = nil
+	runtime.SetFinalizer(b, nil)
+	return nil
+}
+
+// Float64Buffer is a float64 vector held in C memory.
+type Float64Buffer struct {
+	p    unsafe.Pointer
+	data []float64
+}
+
+// NewFloat64Buffer returns a Float64Buffer holding n zeroed values.
+func NewFloat64Buffer(n int) *Float64Buffer {
+	p := calloc(n, 8)
+	b := &Float64Buffer{p: p, data: unsafe.Slice((*float64)(p), n)}
+	runtime.SetFinalizer(b, (*Float64Buffer).Close)
+	return b
+}
+
+// Data returns a view of the buffer's memory. It returns nil after Close.
+func (b *Float64Buffer) Data() []float64 { return b.data }
+
+// Len returns the number of values in the buffer.
+func (b *Float64Buffer) Len() int { return len(b.data) }
+
+// Close frees the buffer's memory. Closing a closed buffer is a no-op.
+func (b *Float64Buffer) Close() error {
+	free(&b.p)
+	b.data = nil
+	runtime.SetFinalizer(b, nil)
+	return nil
+}
+
+// Complex64Buffer is a complex64 vector held in C memory.
+type Complex64Buffer struct {
+	p    unsafe.Pointer
+	data []complex64
+}
+
+// NewComplex64Buffer returns a Complex64Buffer holding n zeroed values.
+func NewComplex64Buffer(n int) *Complex64Buffer {
+	p := calloc(n, 8)
+	b := &Complex64Buffer{p: p, data: unsafe.Slice((*complex64)(p), n)}
+	runtime.SetFinalizer(b, (*Complex64Buffer).Close)
+	return b
+}
+
+// Data returns a view of the buffer's memory. It returns nil after Close.
+func (b *Complex64Buffer) Data() []complex64 { return b.data }
+
+// Len returns the number of values in the buffer.
+func (b *Complex64Buffer) Len() int { return len(b.data) }
+
+// Close frees the buffer's memory. Closing a closed buffer is a no-op.
+func (b *Complex64Buffer) Close() error {
+	free(&b.p)
+	b.data = nil
+	runtime.SetFinalizer(b, nil)
+	return nil
+}
+
+// Complex128Buffer is a complex128 vector held in C memory.
+type Complex128Buffer struct {
+	p    unsafe.Pointer
+	data []complex128
+}
+
+// NewComplex128Buffer returns a Complex128Buffer holding n zeroed values.
+func NewComplex128Buffer(n int) *Complex128Buffer {
+	p := calloc(n, 16)
+	b := &Complex128Buffer{p: p, data: unsafe.Slice((*complex128)(p), n)}
+	runtime.SetFinalizer(b, (*Complex128Buffer).Close)
+	return b
+}
+
+// Data returns a view of the buffer's memory. It returns nil after Close.
+func (b *Complex128Buffer) Data() []complex128 { return b.data }
+
+// Len returns the number of values in the buffer.
+func (b *Complex128Buffer) Len() int { return len(b.data) }
+
+// Close frees the buffer's memory. Closing a closed buffer is a no-op.
+func (b *Complex128Buffer) Close() error {
+	free(&b.p)
+	b.data = nil
+	runtime.SetFinalizer(b, nil)
+	return nil
+}
+
+// calloc returns zeroed C memory for n elements of size bytes, or nil if n
+// is zero.
+func calloc(n, size int) unsafe.Pointer {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	if n == 0 {
+		return nil
+	}
+	if n > int(^uint(0)>>1)/size {
+		panic("cblas: buffer too large")
+	}
+	p := C.alloc_zeroed(C.size_t(n * size))
+	if p == nil {
+		panic("cblas: out of memory")
+	}
+	return p
+}
+
+// free frees the C memory at *p and clears *p.
+func free(p *unsafe.Pointer) {
+	if *p != nil {
+		C.free(*p)
+		*p = nil
+	}
+}
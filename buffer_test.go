@@ -0,0 +1,102 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/gonum/blas"
+)
+
+// TestBuffer tests that the Data of each Buffer type may be used in Blas
+// calls and that it is released by Close.
+func TestBuffer(t *testing.T) {
+	const n = 4
+	var impl Blas
+
+	fx, fy := NewFloat32Buffer(n), NewFloat32Buffer(n)
+	dx, dy := NewFloat64Buffer(n), NewFloat64Buffer(n)
+	cx, cy := NewComplex64Buffer(n), NewComplex64Buffer(n)
+	zx, zy := NewComplex128Buffer(n), NewComplex128Buffer(n)
+	for _, b := range []interface {
+		Len() int
+	}{fx, fy, dx, dy, cx, cy, zx, zy} {
+		if b.Len() != n {
+			t.Errorf("%T: unexpected length: got %d want %d", b, b.Len(), n)
+		}
+	}
+	for i, p := range []unsafe.Pointer{
+		unsafe.Pointer(&fx.Data()[0]), unsafe.Pointer(&dx.Data()[0]),
+		unsafe.Pointer(&cx.Data()[0]), unsafe.Pointer(&zx.Data()[0]),
+	} {
+		if uintptr(p)%64 != 0 {
+			t.Errorf("buffer %d at %p is not aligned to 64 bytes", i, p)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if fy.Data()[i] != 0 || dy.Data()[i] != 0 || cy.Data()[i] != 0 || zy.Data()[i] != 0 {
+			t.Errorf("element %d not zero", i)
+		}
+		v := float64(i + 1)
+		fx.Data()[i], dx.Data()[i] = float32(v), v
+		cx.Data()[i], zx.Data()[i] = complex(float32(v), 1), complex(v, 1)
+	}
+
+	impl.Saxpy(n, 2, fx.Data(), 1, fy.Data(), 1)
+	impl.Daxpy(n, 2, dx.Data(), 1, dy.Data(), 1)
+	impl.Caxpy(n, 2, cx.Data(), 1, cy.Data(), 1)
+	impl.Zaxpy(n, 2, zx.Data(), 1, zy.Data(), 1)
+	if got := impl.Sdot(n, fx.Data(), 1, fy.Data(), 1); got != 60 {
+		t.Errorf("unexpected Sdot: got %v want 60", got)
+	}
+	if got := impl.Ddot(n, dx.Data(), 1, dy.Data(), 1); got != 60 {
+		t.Errorf("unexpected Ddot: got %v want 60", got)
+	}
+	if got := impl.Cdotc(n, cx.Data(), 1, cy.Data(), 1); got != 68 {
+		t.Errorf("unexpected Cdotc: got %v want 68", got)
+	}
+	if got := impl.Zdotc(n, zx.Data(), 1, zy.Data(), 1); got != 68 {
+		t.Errorf("unexpected Zdotc: got %v want 68", got)
+	}
+	// A 2×2 product with all operands held in buffers.
+	impl.Dgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, dx.Data(), 2, dx.Data(), 2, 0, dy.Data(), 2)
+	if want := []float64{7, 10, 15, 22}; !sameFloat64s(dy.Data(), want) {
+		t.Errorf("unexpected Dgemm: got %v want %v", dy.Data(), want)
+	}
+
+	for _, b := range []interface {
+		Len() int
+		Close() error
+	}{fx, fy, dx, dy, cx, cy, zx, zy} {
+		for i := 0; i < 2; i++ {
+			if err := b.Close(); err != nil {
+				t.Errorf("%T: unexpected error from Close %d: %v", b, i+1, err)
+			}
+		}
+		if b.Len() != 0 {
+			t.Errorf("%T: unexpected length after Close: %d", b, b.Len())
+		}
+	}
+	if fx.Data() != nil || dx.Data() != nil || cx.Data() != nil || zx.Data() != nil {
+		t.Errorf("Data not nil after Close")
+	}
+}
+
+func TestBufferEmpty(t *testing.T) {
+	b := NewFloat64Buffer(0)
+	if b.Data() != nil || b.Len() != 0 {
+		t.Errorf("unexpected empty buffer: %v", b.Data())
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	defer func() {
+		if r := recover(); r != "cblas: n < 0" {
+			t.Errorf("unexpected panic: got %v", r)
+		}
+	}()
+	NewComplex128Buffer(-1)
+}
@@ -0,0 +1,91 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// blasreplay replays a log of BLAS calls written by a cblas.Recorder
+// against a backend and reports the calls whose results differ from
+// those recorded.
+//
+// Usage:
+//
+//	blasreplay [-backend name] [-ulp tol] [-v] log
+//
+// The backend is named as for cblas.Open, and defaults to the backend
+// selected by the CBLAS_BACKEND environment variable. The tolerance is
+// described by cblas.Shadow. Differences are written to standard output
+// and the exit status is non-zero if any call differs.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gonum/blas/cblas"
+)
+
+func main() {
+	backend := flag.String("backend", "", "name of the backend to replay the calls on")
+	ulp := flag.Float64("ulp", 0, "tolerance scale, see cblas.Shadow (0 for the default)")
+	verbose := flag.Bool("v", false, "print every call replayed")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: blasreplay [-backend name] [-ulp tol] [-v] log")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	name := *backend
+	if name == "" {
+		name = os.Getenv(cblas.EnvBackend)
+	}
+	if name == "" {
+		name = cblas.DefaultBackend
+	}
+	b, err := cblas.Open(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	defer f.Close()
+	r, err := cblas.NewReader(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var calls, failed int
+	for {
+		c, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "call %d: %v\n", calls+1, err)
+			os.Exit(2)
+		}
+		calls++
+		d := c.Replay(b, *ulp)
+		switch {
+		case d != nil:
+			failed++
+			fmt.Printf("call %d: %v\n", calls, d)
+		case *verbose:
+			fmt.Printf("call %d: %s ok\n", calls, c.Routine)
+		}
+	}
+	fmt.Printf("%d calls replayed, %d differ\n", calls, failed)
+	if failed != 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// blastune measures the thresholds below which the Go kernels of the cblas
+// package are faster than the linked library, and the fastest tile sizes
+// of its tiled routines, and writes them as a JSON profile.
+//
+// Usage:
+//
+//	blastune [-o profile] [-time d] [-v]
+//
+// The profile is written to standard output unless a file is named. Each
+// measurement takes about the given time, 20ms by default. With -v the
+// tuned values are compared with the current ones on standard error.
+//
+// The profile is used by naming it in the CBLAS_PROFILE environment
+// variable of programs using the cblas package, which apply it when the
+// package is initialized. A profile is only applied with the library it
+// was measured with.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gonum/blas/cblas"
+)
+
+func main() {
+	out := flag.String("o", "", "file to write the profile to (default standard output)")
+	budget := flag.Duration("time", 20*time.Millisecond, "time taken by each measurement")
+	verbose := flag.Bool("v", false, "compare the tuned values with the current ones")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: blastune [-o profile] [-time d] [-v]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := cblas.ProfileError(); err != nil {
+		fmt.Fprintf(os.Stderr, "ignoring %s: %v\n", cblas.EnvProfile, err)
+	}
+
+	cur := cblas.CurrentProfile()
+	p := cblas.Tune(*budget)
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "library %s\n", p.Library)
+		for _, name := range cblas.Thresholds() {
+			fmt.Fprintf(os.Stderr, "threshold %s: %d (was %d)\n", name, p.Thresholds[name], cur.Thresholds[name])
+		}
+		for _, name := range cblas.Tiles() {
+			if n, ok := p.Tiles[name]; ok {
+				fmt.Fprintf(os.Stderr, "tile %s: %d (was %d)\n", name, n, cur.Tiles[name])
+			}
+		}
+	}
+
+	if *out == "" {
+		err := p.Write(os.Stdout)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	err = p.Write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
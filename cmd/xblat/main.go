@@ -0,0 +1,57 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// xblat runs the Netlib reference BLAS tests against the cblas package.
+//
+// Usage:
+//
+//	xblat 1
+//	xblat 2 < dblat2.in
+//	xblat 3 < dblat3.in
+//
+// The level 2 and level 3 tests read their parameters from standard input
+// in either the Fortran or the CBLAS tester format. Results are written
+// to standard output and the exit status is non-zero if any test fails.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gonum/blas/cblas"
+	"github.com/gonum/blas/cblas/xblat"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: xblat 1|2|3")
+		os.Exit(2)
+	}
+
+	var ok bool
+	switch os.Args[1] {
+	case "1":
+		ok = xblat.Level1(os.Stdout, cblas.Blas{})
+	case "2":
+		p, err := xblat.ParseLevel2(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		ok = xblat.Level2(os.Stdout, p, cblas.Blas{})
+	case "3":
+		p, err := xblat.ParseLevel3(os.Stdin)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		ok = xblat.Level3(os.Stdout, p, cblas.Blas{})
+	default:
+		fmt.Fprintln(os.Stderr, "usage: xblat 1|2|3")
+		os.Exit(2)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
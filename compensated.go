@@ -0,0 +1,425 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import "math"
+
+// The Compensated variants of the Level 1 reductions are computed in Go
+// with error-free transformations, giving results as accurate as if they
+// had been computed in twice the working precision and then rounded, as
+// described by Ogita, Rump and Oishi, "Accurate sum and dot product",
+// SIAM J. Sci. Comput. 26(6), 2005. The single precision variants
+// accumulate the exact double precision products of their elements with
+// compensation, and so are more accurate still. The XP variants
+// accumulate in double-double arithmetic, as the extended precision
+// routines of XBLAS do, and are slower but more accurate than the
+// Compensated variants when the sum is very ill-conditioned.
+//
+// The arguments are checked as for the corresponding methods of Blas. As
+// there, a negative increment steps backwards through a vector and the
+// asum and nrm2 variants return zero for a negative increment.
+
+// twoSum returns s = fl(a+b) and the error e such that s+e = a+b exactly.
+func twoSum(a, b float64) (s, e float64) {
+	s = a + b
+	t := s - a
+	e = (a - (s - t)) + (b - t)
+	return s, e
+}
+
+// fastTwoSum is twoSum for |a| >= |b|.
+func fastTwoSum(a, b float64) (s, e float64) {
+	s = a + b
+	e = b - (s - a)
+	return s, e
+}
+
+// twoProd returns p = fl(a*b) and the error e such that p+e = a*b exactly.
+func twoProd(a, b float64) (p, e float64) {
+	p = a * b
+	return p, math.FMA(a, b, -p)
+}
+
+// sum2 is a compensated sum. The sum s is the ordinary floating point sum
+// and is returned when it is not finite, since the compensation c is then
+// NaN.
+type sum2 struct {
+	s, c float64
+}
+
+func (a *sum2) add(x float64) {
+	var e float64
+	a.s, e = twoSum(a.s, x)
+	a.c += e
+}
+
+func (a *sum2) addProd(x, y float64) {
+	p, e := twoProd(x, y)
+	a.add(p)
+	a.c += e
+}
+
+func (a *sum2) value() float64 {
+	if math.IsInf(a.s, 0) || math.IsNaN(a.s) {
+		return a.s
+	}
+	return a.s + a.c
+}
+
+// sqrt returns the square root of the sum, correctly rounded but for the
+// error of the sum.
+func (a *sum2) sqrt() float64 {
+	if a.s <= 0 || math.IsInf(a.s, 0) || math.IsNaN(a.s) {
+		return math.Sqrt(a.s)
+	}
+	s, c := fastTwoSum(a.s, a.c)
+	r := math.Sqrt(s)
+	return r + (math.FMA(-r, r, s)+c)/(2*r)
+}
+
+// dd is a double-double sum. The ordinary floating point sum is kept in
+// sum and returned when it is not finite, as for sum2.
+type dd struct {
+	hi, lo float64
+	sum    float64
+}
+
+func (a *dd) add(x, xe float64) {
+	a.sum += x
+	s, e := twoSum(a.hi, x)
+	t, f := twoSum(a.lo, xe)
+	e += t
+	s, e = fastTwoSum(s, e)
+	e += f
+	a.hi, a.lo = fastTwoSum(s, e)
+}
+
+func (a *dd) addProd(x, y float64) {
+	a.add(twoProd(x, y))
+}
+
+func (a *dd) value() float64 {
+	if math.IsInf(a.sum, 0) || math.IsNaN(a.sum) {
+		return a.sum
+	}
+	return a.hi + a.lo
+}
+
+// checkDot checks the vectors of a dot product and returns the offsets of
+// their first elements.
+func checkDot(n, incX, lenX, incY, lenY int) (kx, ky int) {
+	checkVector(n, lenX, incX)
+	checkVector(n, lenY, incY)
+	return start(n, incX), start(n, incY)
+}
+
+// scaleExp returns the exponent of a power of two by which the elements
+// of x may be scaled so that the sum of their squares neither overflows
+// nor loses accuracy by underflow, with the largest magnitude of the
+// elements. Scaling by a power of two is exact.
+func scaleExp(max float64) int {
+	if max == 0 || math.IsInf(max, 0) || math.IsNaN(max) {
+		return 0
+	}
+	_, e := math.Frexp(max)
+	return e
+}
+
+// SdotCompensated computes the dot product of x and y as Sdot does,
+// accumulating the exact products in double precision with compensation.
+func (Blas) SdotCompensated(n int, x []float32, incX int, y []float32, incY int) float32 {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(float64(x[kx+i*incX]) * float64(y[ky+i*incY]))
+	}
+	return float32(a.value())
+}
+
+// SdsdotCompensated computes alpha plus the dot product of x and y as
+// Sdsdot does, accumulating the exact products in double precision with
+// compensation.
+func (Blas) SdsdotCompensated(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	a := sum2{s: float64(alpha)}
+	for i := 0; i < n; i++ {
+		a.add(float64(x[kx+i*incX]) * float64(y[ky+i*incY]))
+	}
+	return float32(a.value())
+}
+
+// SasumCompensated computes the sum of the absolute values of the
+// elements of x as Sasum does, accumulating in double precision with
+// compensation.
+func (Blas) SasumCompensated(n int, x []float32, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(math.Abs(float64(x[i*incX])))
+	}
+	return float32(a.value())
+}
+
+// Snrm2Compensated computes the Euclidean norm of x as Snrm2 does,
+// accumulating the exact squares in double precision with compensation.
+func (Blas) Snrm2Compensated(n int, x []float32, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	// The squares of single precision values are exact and cannot
+	// overflow in double precision.
+	var a sum2
+	for i := 0; i < n; i++ {
+		v := float64(x[i*incX])
+		a.add(v * v)
+	}
+	return float32(a.sqrt())
+}
+
+// DdotCompensated computes the dot product of x and y as Ddot does, using
+// compensated summation of error-free products.
+func (Blas) DdotCompensated(n int, x []float64, incX int, y []float64, incY int) float64 {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.addProd(x[kx+i*incX], y[ky+i*incY])
+	}
+	return a.value()
+}
+
+// DdotXP computes the dot product of x and y as Ddot does, accumulating
+// in double-double arithmetic.
+func (Blas) DdotXP(n int, x []float64, incX int, y []float64, incY int) float64 {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var a dd
+	for i := 0; i < n; i++ {
+		a.addProd(x[kx+i*incX], y[ky+i*incY])
+	}
+	return a.value()
+}
+
+// DsdotCompensated computes the dot product of x and y as Dsdot does,
+// accumulating the exact products with compensation.
+func (Blas) DsdotCompensated(n int, x []float32, incX int, y []float32, incY int) float64 {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(float64(x[kx+i*incX]) * float64(y[ky+i*incY]))
+	}
+	return a.value()
+}
+
+// DasumCompensated computes the sum of the absolute values of the
+// elements of x as Dasum does, using compensated summation.
+func (Blas) DasumCompensated(n int, x []float64, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(math.Abs(x[i*incX]))
+	}
+	return a.value()
+}
+
+// Dnrm2Compensated computes the Euclidean norm of x as Dnrm2 does, using
+// compensated summation of the squares of the elements scaled by a power
+// of two.
+func (Blas) Dnrm2Compensated(n int, x []float64, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var max float64
+	for i := 0; i < n; i++ {
+		v := math.Abs(x[i*incX])
+		if math.IsNaN(v) {
+			return v
+		}
+		max = math.Max(max, v)
+	}
+	if max == 0 || math.IsInf(max, 1) {
+		return max
+	}
+	e := scaleExp(max)
+	var a sum2
+	for i := 0; i < n; i++ {
+		v := math.Ldexp(x[i*incX], -e)
+		a.addProd(v, v)
+	}
+	return math.Ldexp(a.sqrt(), e)
+}
+
+// CdotuCompensated computes the unconjugated dot product of x and y as
+// Cdotu does, accumulating the exact products in double precision with
+// compensation.
+func (Blas) CdotuCompensated(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im sum2
+	for i := 0; i < n; i++ {
+		xr, xi := float64(real(x[kx+i*incX])), float64(imag(x[kx+i*incX]))
+		yr, yi := float64(real(y[ky+i*incY])), float64(imag(y[ky+i*incY]))
+		re.add(xr * yr)
+		re.add(-xi * yi)
+		im.add(xr * yi)
+		im.add(xi * yr)
+	}
+	return complex(float32(re.value()), float32(im.value()))
+}
+
+// CdotcCompensated computes the dot product of the conjugate of x and y
+// as Cdotc does, accumulating the exact products in double precision with
+// compensation.
+func (Blas) CdotcCompensated(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im sum2
+	for i := 0; i < n; i++ {
+		xr, xi := float64(real(x[kx+i*incX])), float64(imag(x[kx+i*incX]))
+		yr, yi := float64(real(y[ky+i*incY])), float64(imag(y[ky+i*incY]))
+		re.add(xr * yr)
+		re.add(xi * yi)
+		im.add(xr * yi)
+		im.add(-xi * yr)
+	}
+	return complex(float32(re.value()), float32(im.value()))
+}
+
+// ScasumCompensated computes the sum of the absolute values of the real
+// and imaginary parts of the elements of x as Scasum does, accumulating
+// in double precision with compensation.
+func (Blas) ScasumCompensated(n int, x []complex64, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(math.Abs(float64(real(x[i*incX]))))
+		a.add(math.Abs(float64(imag(x[i*incX]))))
+	}
+	return float32(a.value())
+}
+
+// Scnrm2Compensated computes the Euclidean norm of x as Scnrm2 does,
+// accumulating the exact squares in double precision with compensation.
+func (Blas) Scnrm2Compensated(n int, x []complex64, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var a sum2
+	for i := 0; i < n; i++ {
+		re, im := float64(real(x[i*incX])), float64(imag(x[i*incX]))
+		a.add(re * re)
+		a.add(im * im)
+	}
+	return float32(a.sqrt())
+}
+
+// ZdotuCompensated computes the unconjugated dot product of x and y as
+// Zdotu does, using compensated summation of error-free products.
+func (Blas) ZdotuCompensated(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im sum2
+	for i := 0; i < n; i++ {
+		xr, xi := real(x[kx+i*incX]), imag(x[kx+i*incX])
+		yr, yi := real(y[ky+i*incY]), imag(y[ky+i*incY])
+		re.addProd(xr, yr)
+		re.addProd(-xi, yi)
+		im.addProd(xr, yi)
+		im.addProd(xi, yr)
+	}
+	return complex(re.value(), im.value())
+}
+
+// ZdotcCompensated computes the dot product of the conjugate of x and y
+// as Zdotc does, using compensated summation of error-free products.
+func (Blas) ZdotcCompensated(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im sum2
+	for i := 0; i < n; i++ {
+		xr, xi := real(x[kx+i*incX]), imag(x[kx+i*incX])
+		yr, yi := real(y[ky+i*incY]), imag(y[ky+i*incY])
+		re.addProd(xr, yr)
+		re.addProd(xi, yi)
+		im.addProd(xr, yi)
+		im.addProd(-xi, yr)
+	}
+	return complex(re.value(), im.value())
+}
+
+// ZdotuXP computes the unconjugated dot product of x and y as Zdotu does,
+// accumulating in double-double arithmetic.
+func (Blas) ZdotuXP(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im dd
+	for i := 0; i < n; i++ {
+		xr, xi := real(x[kx+i*incX]), imag(x[kx+i*incX])
+		yr, yi := real(y[ky+i*incY]), imag(y[ky+i*incY])
+		re.addProd(xr, yr)
+		re.addProd(-xi, yi)
+		im.addProd(xr, yi)
+		im.addProd(xi, yr)
+	}
+	return complex(re.value(), im.value())
+}
+
+// ZdotcXP computes the dot product of the conjugate of x and y as Zdotc
+// does, accumulating in double-double arithmetic.
+func (Blas) ZdotcXP(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
+	kx, ky := checkDot(n, incX, len(x), incY, len(y))
+	var re, im dd
+	for i := 0; i < n; i++ {
+		xr, xi := real(x[kx+i*incX]), imag(x[kx+i*incX])
+		yr, yi := real(y[ky+i*incY]), imag(y[ky+i*incY])
+		re.addProd(xr, yr)
+		re.addProd(xi, yi)
+		im.addProd(xr, yi)
+		im.addProd(-xi, yr)
+	}
+	return complex(re.value(), im.value())
+}
+
+// DzasumCompensated computes the sum of the absolute values of the real
+// and imaginary parts of the elements of x as Dzasum does, using
+// compensated summation.
+func (Blas) DzasumCompensated(n int, x []complex128, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var a sum2
+	for i := 0; i < n; i++ {
+		a.add(math.Abs(real(x[i*incX])))
+		a.add(math.Abs(imag(x[i*incX])))
+	}
+	return a.value()
+}
+
+// Dznrm2Compensated computes the Euclidean norm of x as Dznrm2 does,
+// using compensated summation of the squares of the parts of the elements
+// scaled by a power of two.
+func (Blas) Dznrm2Compensated(n int, x []complex128, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	var max float64
+	for i := 0; i < n; i++ {
+		re, im := math.Abs(real(x[i*incX])), math.Abs(imag(x[i*incX]))
+		if math.IsNaN(re) || math.IsNaN(im) {
+			return math.NaN()
+		}
+		max = math.Max(max, math.Max(re, im))
+	}
+	if max == 0 || math.IsInf(max, 1) {
+		return max
+	}
+	e := scaleExp(max)
+	var a sum2
+	for i := 0; i < n; i++ {
+		re, im := math.Ldexp(real(x[i*incX]), -e), math.Ldexp(imag(x[i*incX]), -e)
+		a.addProd(re, re)
+		a.addProd(im, im)
+	}
+	return math.Ldexp(a.sqrt(), e)
+}
@@ -0,0 +1,298 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// accurate lists the accurate Level 1 reductions tested by TestAccurate.
+var accurate = []string{
+	"SdotCompensated", "SdsdotCompensated", "SasumCompensated", "Snrm2Compensated",
+	"DdotCompensated", "DdotXP", "DsdotCompensated", "DasumCompensated", "Dnrm2Compensated",
+	"CdotuCompensated", "CdotcCompensated", "ScasumCompensated", "Scnrm2Compensated",
+	"ZdotuCompensated", "ZdotcCompensated", "ZdotuXP", "ZdotcXP", "DzasumCompensated", "Dznrm2Compensated",
+}
+
+// refPrec is the precision of the big.Float reference computations, which
+// is enough for the sums of the products of the test values to be exact.
+const refPrec = 2048
+
+// TestAccurate tests the compensated and extended precision Level 1
+// reductions, such as DdotCompensated and DdotXP, against exact big.Float
+// computations with vectors whose dot products are very ill-conditioned.
+//
+// The error of a dot product computed by a Compensated routine may be
+// 2uᵣ|r| + (2n+1)²u²·Σ|p|, and by an XP routine 2uᵣ|r| + 4(2n+1)u²·Σ|p|,
+// where r is the exact result, u is the unit roundoff of double precision,
+// uᵣ that of the result type, and Σ|p| is the sum of the magnitudes of the
+// real products forming the real or imaginary part of the result. Sums of
+// magnitudes and norms must be within 2uᵣ of the exact result relative to
+// it. Results must be infinite or NaN as the ordinary sums would be.
+func TestAccurate(t *testing.T) {
+	v := reflect.ValueOf(Blas{})
+	for _, name := range accurate {
+		name := name
+		m := v.MethodByName(name)
+		t.Run(name, func(t *testing.T) {
+			testAccurate(t, name, m)
+		})
+	}
+}
+
+func testAccurate(t *testing.T, name string, m reflect.Value) {
+	rnd := rand.New(rand.NewSource(1))
+	mt := m.Type()
+	xi := 1
+	if strings.HasPrefix(name, "Sdsdot") {
+		xi = 2
+	}
+	elemType := mt.In(xi).Elem()
+	cplx := elemType.Kind() == reflect.Complex64 || elemType.Kind() == reflect.Complex128
+	single := elemType.Kind() == reflect.Float32 || elemType.Kind() == reflect.Complex64
+	dot := mt.NumIn() > xi+2
+	conj := strings.Contains(name, "dotc")
+	xp := strings.HasSuffix(name, "XP")
+
+	// u is the unit roundoff of the accumulation and ur is that of the
+	// result.
+	const u = 0x1p-53
+	ur := u
+	if k := mt.Out(0).Kind(); k == reflect.Float32 || k == reflect.Complex64 {
+		ur = 0x1p-24
+	}
+	// Single precision values are restricted to a range in which their
+	// products are exact.
+	span := 60
+	if single {
+		span = 20
+	}
+
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		for _, inc := range []int{1, 2} {
+			x := make([]complex128, n)
+			y := make([]complex128, n)
+			gen := func() float64 {
+				f := math.Ldexp(rnd.Float64()*2-1, rnd.Intn(2*span+1)-span)
+				if single {
+					f = float64(float32(f))
+				}
+				return f
+			}
+			for i := range x {
+				x[i] = complex(gen(), 0)
+				y[i] = complex(gen(), 0)
+				if cplx {
+					x[i] = complex(real(x[i]), gen())
+					y[i] = complex(real(y[i]), gen())
+				}
+			}
+			if dot {
+				// Make the second half of the products cancel the
+				// first half up to a small perturbation.
+				for i := 0; i < n/2; i++ {
+					x[n-1-i] = x[i]
+					p := complex(gen()*0x1p-30, 0)
+					if cplx {
+						p = complex(real(p), gen()*0x1p-30)
+					}
+					y[n-1-i] = -y[i] + p
+					if single {
+						y[n-1-i] = complex(float64(float32(real(y[n-1-i]))), float64(float32(imag(y[n-1-i]))))
+					}
+				}
+			}
+			alpha := 0.0
+			if xi == 2 {
+				alpha = gen()
+			}
+
+			args := []reflect.Value{reflect.ValueOf(n)}
+			if xi == 2 {
+				args = append(args, reflect.ValueOf(float32(alpha)))
+			}
+			args = append(args, strided(elemType, x, inc), reflect.ValueOf(inc))
+			if dot {
+				args = append(args, strided(elemType, y, inc), reflect.ValueOf(inc))
+			}
+			got := m.Call(args)[0]
+			var res complex128
+			if got.Kind() == reflect.Complex64 || got.Kind() == reflect.Complex128 {
+				res = got.Complex()
+			} else {
+				res = complex(got.Float(), 0)
+			}
+
+			var want, bound complex128
+			switch {
+			case dot:
+				var re, im, absRe, absIm big.Float
+				for _, f := range []*big.Float{&re, &im, &absRe, &absIm} {
+					f.SetPrec(refPrec)
+				}
+				re.SetFloat64(alpha)
+				absRe.SetFloat64(math.Abs(alpha))
+				for i := range x {
+					a := x[i]
+					if conj {
+						a = complex(real(a), -imag(a))
+					}
+					b := y[i]
+					accumulate(&re, &absRe, real(a), real(b))
+					accumulate(&re, &absRe, -imag(a), imag(b))
+					accumulate(&im, &absIm, real(a), imag(b))
+					accumulate(&im, &absIm, imag(a), real(b))
+				}
+				r, _ := re.Float64()
+				i, _ := im.Float64()
+				ar, _ := absRe.Float64()
+				ai, _ := absIm.Float64()
+				want = complex(r, i)
+				k := float64(2*n+1) * u
+				if !xp {
+					k *= float64(2*n+1) * u
+				} else {
+					k *= 4 * u
+				}
+				bound = complex(2*ur*math.Abs(r)+k*ar, 2*ur*math.Abs(i)+k*ai)
+			case strings.Contains(name, "asum"):
+				var s big.Float
+				s.SetPrec(refPrec)
+				for _, v := range x {
+					s.Add(&s, new(big.Float).SetFloat64(math.Abs(real(v))))
+					s.Add(&s, new(big.Float).SetFloat64(math.Abs(imag(v))))
+				}
+				r, _ := s.Float64()
+				want = complex(r, 0)
+				bound = complex(2*ur*r, 0)
+			default:
+				var s big.Float
+				s.SetPrec(refPrec)
+				for _, v := range x {
+					accumulate(&s, nil, real(v), real(v))
+					accumulate(&s, nil, imag(v), imag(v))
+				}
+				r, _ := new(big.Float).SetPrec(refPrec).Sqrt(&s).Float64()
+				want = complex(r, 0)
+				bound = complex(2*ur*r, 0)
+			}
+			if math.Abs(real(res)-real(want)) > real(bound) || math.Abs(imag(res)-imag(want)) > imag(bound) {
+				t.Errorf("n=%d inc=%d: got %v, want %v within %v", n, inc, res, want, bound)
+			}
+		}
+	}
+
+	// Results that overflow or are NaN are not affected by compensation.
+	if dot {
+		return
+	}
+	for _, c := range []struct {
+		v    float64
+		want float64
+	}{
+		{math.Inf(1), math.Inf(1)},
+		{math.Inf(-1), math.Inf(1)},
+		{math.NaN(), math.NaN()},
+	} {
+		x := []complex128{1, complex(c.v, 0), 2}
+		got := m.Call([]reflect.Value{reflect.ValueOf(len(x)), strided(elemType, x, 1), reflect.ValueOf(1)})[0]
+		var g float64
+		if got.Kind() == reflect.Float32 || got.Kind() == reflect.Float64 {
+			g = got.Float()
+		}
+		if g != c.want && !(math.IsNaN(g) && math.IsNaN(c.want)) {
+			t.Errorf("with %v element: got %v, want %v", c.v, g, c.want)
+		}
+	}
+}
+
+// TestAccurateIncrement tests that the accurate Level 1 reductions treat
+// negative and zero increments as the corresponding methods of Blas do.
+func TestAccurateIncrement(t *testing.T) {
+	v := reflect.ValueOf(Blas{})
+	for _, name := range accurate {
+		m := v.MethodByName(name)
+		plain := v.MethodByName(strings.TrimSuffix(strings.TrimSuffix(name, "Compensated"), "XP"))
+		mt := m.Type()
+		xi := 1
+		if strings.HasPrefix(name, "Sdsdot") {
+			xi = 2
+		}
+		elemType := mt.In(xi).Elem()
+		dot := mt.NumIn() > xi+2
+
+		x := []complex128{1 + 2i, -3, 4 - 1i}
+		y := []complex128{2, 1 - 1i, -5 + 3i}
+		for _, inc := range [][2]int{{-2, 1}, {1, -1}, {-1, -3}, {0, 1}, {1, 0}} {
+			if !dot && inc[1] != 1 {
+				continue
+			}
+			args := []reflect.Value{reflect.ValueOf(len(x))}
+			if xi == 2 {
+				args = append(args, reflect.ValueOf(float32(0.5)))
+			}
+			args = append(args, strided(elemType, x, abs(inc[0])+1), reflect.ValueOf(inc[0]))
+			if dot {
+				args = append(args, strided(elemType, y, abs(inc[1])+1), reflect.ValueOf(inc[1]))
+			}
+
+			if inc[0] == 0 || inc[1] == 0 {
+				func() {
+					defer func() {
+						if r := recover(); r != "cblas: zero increment" {
+							t.Errorf("%s inc=%v: unexpected panic: %v", name, inc, r)
+						}
+					}()
+					m.Call(args)
+				}()
+				continue
+			}
+			got := m.Call(args)[0].Interface()
+			want := plain.Call(args)[0].Interface()
+			if got != want {
+				t.Errorf("%s inc=%v: got %v want %v", name, inc, got, want)
+			}
+			if !dot && !reflect.ValueOf(got).IsZero() {
+				t.Errorf("%s inc=%v: got %v want 0", name, inc, got)
+			}
+		}
+	}
+}
+
+// accumulate adds the exact product a·b to sum and its magnitude to abs if
+// abs is not nil.
+func accumulate(sum, abs *big.Float, a, b float64) {
+	p := new(big.Float).SetPrec(refPrec).SetFloat64(a)
+	p.Mul(p, new(big.Float).SetFloat64(b))
+	sum.Add(sum, p)
+	if abs != nil {
+		abs.Add(abs, p.Abs(p))
+	}
+}
+
+// strided returns a slice of element type t holding the values of v with
+// increment inc, with zeros between them.
+func strided(t reflect.Type, v []complex128, inc int) reflect.Value {
+	n := 0
+	if len(v) > 0 {
+		n = (len(v)-1)*inc + 1
+	}
+	s := reflect.MakeSlice(reflect.SliceOf(t), n, n)
+	for i, x := range v {
+		e := s.Index(i * inc)
+		switch t.Kind() {
+		case reflect.Complex64, reflect.Complex128:
+			e.SetComplex(x)
+		default:
+			e.SetFloat(real(x))
+		}
+	}
+	return s
+}
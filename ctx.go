@@ -0,0 +1,315 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include "cblas.h"
+*/
+import "C"
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/gonum/blas"
+)
+
+// The Ctx variants of the Level 3 routines split the operation into tiles
+// that are each computed with a single call to the library, and check the
+// context before each call. Each element of the result is computed by a
+// single call over the whole inner dimension, as without a context,
+// unless the "ctxk" tile is set. A gemm is then also split into panels of
+// the inner dimension, with the partial sums of each element accumulated
+// in C between calls, so it may round differently. A cancelled operation
+// returns the error of the context, leaving its output partially computed
+// as described for each routine. The arguments are checked as for the
+// routine without a context.
+
+// ctxTile is the size of the tiles of the Ctx routines. It is set by
+// SetTile("ctx", n).
+var ctxTile int64 = 512
+
+// ctxKTile is the size of the panels of the inner dimension of a gemm tile
+// of the Ctx routines, or zero for no split. It is set by
+// SetTile("ctxk", n).
+var ctxKTile int64
+
+// tileGemm calls mul for each tile of a gemm with the rows [i0, i0+mb)
+// and columns [j0, j0+nb) of C and the panel [p0, p0+kb) of the inner
+// dimension, checking ctx before each. The tiles of C are visited in
+// row-major order with their panels in order. A tile has a single panel
+// holding the whole inner dimension unless the "ctxk" tile is set. first
+// is true for the first panel of a tile, which must scale C by beta.
+func tileGemm(ctx context.Context, m, n, k int, mul func(i0, j0, p0, mb, nb, kb int, first bool)) error {
+	bs, kbs := tileSize(&ctxTile), tileSize(&ctxKTile)
+	if kbs == 0 {
+		kbs = max(k, 1)
+	}
+	for i0 := 0; i0 < m; i0 += bs {
+		for j0 := 0; j0 < n; j0 += bs {
+			for p0 := 0; p0 == 0 || p0 < k; p0 += kbs {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				mul(i0, j0, p0, min(bs, m-i0), min(bs, n-j0), min(kbs, k-p0), p0 == 0)
+			}
+		}
+	}
+	return nil
+}
+
+// tileSide calls f for each tile of the columns of the m×n matrix B when
+// s is blas.Left, or of its rows when s is blas.Right, which are the
+// independent parts of a triangular multiply or solve, checking ctx before
+// each.
+func tileSide(ctx context.Context, s blas.Side, m, n int, f func(i0, j0, mb, nb int)) error {
+	if m == 0 || n == 0 {
+		return nil
+	}
+	bs := tileSize(&ctxTile)
+	if s == blas.Left {
+		for j0 := 0; j0 < n; j0 += bs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			f(0, j0, m, min(bs, n-j0))
+		}
+		return nil
+	}
+	for i0 := 0; i0 < m; i0 += bs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		f(i0, 0, min(bs, m-i0), n)
+	}
+	return nil
+}
+
+// checkTri checks the arguments of a triangular multiply or solve as
+// Dtrmm and Dtrsm do.
+func checkTri(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n, lenA, lda, lenB, ldb int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
+	}
+	if s != blas.Left && s != blas.Right {
+		panic("cblas: illegal side")
+	}
+	if ul != blas.Upper && ul != blas.Lower {
+		panic("cblas: illegal triangle")
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic("cblas: illegal diagonal")
+	}
+	if m < 0 {
+		panic("cblas: m < 0")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	checkOrdered(o, k, k, lenA, lda)
+	checkOrdered(o, m, n, lenB, ldb)
+}
+
+// gemmOffsets returns the offsets of the first elements of the tiles of
+// A, B and C of a gemm tile.
+func gemmOffsets(o blas.Order, tA, tB blas.Transpose, lda, ldb, ldc, i0, j0, p0 int) (offA, offB, offC int) {
+	rs, cs := strides(o, tA, lda)
+	offA = i0*rs + p0*cs
+	rs, cs = strides(o, tB, ldb)
+	offB = p0*rs + j0*cs
+	rs, cs = strides(o, blas.NoTrans, ldc)
+	return offA, offB, i0*rs + j0*cs
+}
+
+// SgemmCtx performs Sgemm tile by tile, checking ctx before each tile. If
+// ctx is done it returns ctx.Err(), leaving the tiles of C before the
+// current one in row-major order holding their result and the others
+// unchanged, except that if the "ctxk" tile is set the current tile may
+// hold beta * C plus the product over a leading part of the inner
+// dimension.
+func (Blas) SgemmCtx(ctx context.Context, o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) error {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	return tileGemm(ctx, m, n, k, func(i0, j0, p0, mb, nb, kb int, first bool) {
+		offA, offB, offC := gemmOffsets(o, tA, tB, lda, ldb, ldc, i0, j0, p0)
+		var pa, pb *C.float
+		if kb > 0 {
+			pa, pb = f32(a[offA:]), f32(b[offB:])
+		}
+		bc := beta
+		if !first {
+			bc = 1
+		}
+		C.cblas_sgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(mb), C.int(nb), C.int(kb), C.float(alpha), pa, C.int(lda), pb, C.int(ldb), C.float(bc), f32(c[offC:]), C.int(ldc))
+	})
+}
+
+// DgemmCtx performs Dgemm tile by tile, checking ctx before each tile. If
+// ctx is done it returns ctx.Err(), leaving the tiles of C before the
+// current one in row-major order holding their result and the others
+// unchanged, except that if the "ctxk" tile is set the current tile may
+// hold beta * C plus the product over a leading part of the inner
+// dimension.
+func (Blas) DgemmCtx(ctx context.Context, o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) error {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	return tileGemm(ctx, m, n, k, func(i0, j0, p0, mb, nb, kb int, first bool) {
+		offA, offB, offC := gemmOffsets(o, tA, tB, lda, ldb, ldc, i0, j0, p0)
+		var pa, pb *C.double
+		if kb > 0 {
+			pa, pb = f64(a[offA:]), f64(b[offB:])
+		}
+		bc := beta
+		if !first {
+			bc = 1
+		}
+		C.cblas_dgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(mb), C.int(nb), C.int(kb), C.double(alpha), pa, C.int(lda), pb, C.int(ldb), C.double(bc), f64(c[offC:]), C.int(ldc))
+	})
+}
+
+// CgemmCtx performs Cgemm tile by tile, checking ctx before each tile. If
+// ctx is done it returns ctx.Err(), leaving the tiles of C before the
+// current one in row-major order holding their result and the others
+// unchanged, except that if the "ctxk" tile is set the current tile may
+// hold beta * C plus the product over a leading part of the inner
+// dimension.
+func (Blas) CgemmCtx(ctx context.Context, o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) error {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	return tileGemm(ctx, m, n, k, func(i0, j0, p0, mb, nb, kb int, first bool) {
+		offA, offB, offC := gemmOffsets(o, tA, tB, lda, ldb, ldc, i0, j0, p0)
+		var pa, pb unsafe.Pointer
+		if kb > 0 {
+			pa, pb = c64(a[offA:]), c64(b[offB:])
+		}
+		bc := beta
+		if !first {
+			bc = 1
+		}
+		C.cblas_cgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(mb), C.int(nb), C.int(kb), unsafe.Pointer(&alpha), pa, C.int(lda), pb, C.int(ldb), unsafe.Pointer(&bc), c64(c[offC:]), C.int(ldc))
+	})
+}
+
+// ZgemmCtx performs Zgemm tile by tile, checking ctx before each tile. If
+// ctx is done it returns ctx.Err(), leaving the tiles of C before the
+// current one in row-major order holding their result and the others
+// unchanged, except that if the "ctxk" tile is set the current tile may
+// hold beta * C plus the product over a leading part of the inner
+// dimension.
+func (Blas) ZgemmCtx(ctx context.Context, o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) error {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	return tileGemm(ctx, m, n, k, func(i0, j0, p0, mb, nb, kb int, first bool) {
+		offA, offB, offC := gemmOffsets(o, tA, tB, lda, ldb, ldc, i0, j0, p0)
+		var pa, pb unsafe.Pointer
+		if kb > 0 {
+			pa, pb = c128(a[offA:]), c128(b[offB:])
+		}
+		bc := beta
+		if !first {
+			bc = 1
+		}
+		C.cblas_zgemm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_TRANSPOSE(tB), C.int(mb), C.int(nb), C.int(kb), unsafe.Pointer(&alpha), pa, C.int(lda), pb, C.int(ldb), unsafe.Pointer(&bc), c128(c[offC:]), C.int(ldc))
+	})
+}
+
+// StrmmCtx performs Strmm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their result and the others unchanged.
+func (Blas) StrmmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_strmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), C.float(alpha), f32(a), C.int(lda), f32(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// DtrmmCtx performs Dtrmm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their result and the others unchanged.
+func (Blas) DtrmmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_dtrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), C.double(alpha), f64(a), C.int(lda), f64(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// CtrmmCtx performs Ctrmm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their result and the others unchanged.
+func (Blas) CtrmmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_ctrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// ZtrmmCtx performs Ztrmm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their result and the others unchanged.
+func (Blas) ZtrmmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_ztrmm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// StrsmCtx performs Strsm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their solution and the others unchanged.
+func (Blas) StrsmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_strsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), C.float(alpha), f32(a), C.int(lda), f32(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// DtrsmCtx performs Dtrsm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their solution and the others unchanged.
+func (Blas) DtrsmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_dtrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), C.double(alpha), f64(a), C.int(lda), f64(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// CtrsmCtx performs Ctrsm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their solution and the others unchanged.
+func (Blas) CtrsmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_ctrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
+
+// ZtrsmCtx performs Ztrsm on tiles of the columns of B when s is
+// blas.Left or of its rows when s is blas.Right, checking ctx before each
+// tile. If ctx is done it returns ctx.Err(), leaving the tiles before the
+// current one holding their solution and the others unchanged.
+func (Blas) ZtrsmCtx(ctx context.Context, o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) error {
+	checkTri(o, s, ul, tA, d, m, n, len(a), lda, len(b), ldb)
+	rs, cs := strides(o, blas.NoTrans, ldb)
+	return tileSide(ctx, s, m, n, func(i0, j0, mb, nb int) {
+		C.cblas_ztrsm(C.enum_CBLAS_ORDER(o), C.enum_CBLAS_SIDE(s), C.enum_CBLAS_UPLO(ul), C.enum_CBLAS_TRANSPOSE(tA), C.enum_CBLAS_DIAG(d), C.int(mb), C.int(nb), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b[i0*rs+j0*cs:]), C.int(ldb))
+	})
+}
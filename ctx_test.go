@@ -0,0 +1,244 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestCtx tests that the Ctx routines agree with the routines without a
+// context when their operands span several tiles.
+func TestCtx(t *testing.T) {
+	defer SetTile("ctx", SetTile("ctx", 3))
+	for _, kb := range []int{0, 2} {
+		prev := SetTile("ctxk", kb)
+		testCtxGemm(t, kb)
+		testCtxTri(t, kb)
+		SetTile("ctxk", prev)
+	}
+}
+
+func testCtxGemm(t *testing.T, kb int) {
+	rnd := rand.New(rand.NewSource(1))
+	ctx := context.Background()
+	var impl Blas
+	for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float64{0, 1, -0.5} {
+					m, n, k := 7, 5, 8
+					ra, ca := m, k
+					if tA != blas.NoTrans {
+						ra, ca = k, m
+					}
+					rb, cb := k, n
+					if tB != blas.NoTrans {
+						rb, cb = n, k
+					}
+					lda, ldb, ldc := ld(o, ra, ca), ld(o, rb, cb), ld(o, m, n)
+					a := random(rnd, size(o, ra, ca, lda))
+					b := random(rnd, size(o, rb, cb, ldb))
+					c := random(rnd, size(o, m, n, ldc))
+					want := append([]float64(nil), c...)
+					impl.Dgemm(o, tA, tB, m, n, k, 0.5, a, lda, b, ldb, beta, want, ldc)
+					if err := impl.DgemmCtx(ctx, o, tA, tB, m, n, k, 0.5, a, lda, b, ldb, beta, c, ldc); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					desc := fmt.Sprintf("DgemmCtx(%v, %v, %v) ctxk=%d beta=%v", o, tA, tB, kb, beta)
+					// Without a split of the inner dimension each
+					// element is computed by a single call over
+					// all of it, as by Dgemm, so the results must
+					// be identical.
+					depth := k
+					if kb == 0 {
+						depth = 0
+					}
+					same(t, desc, c, want, depth)
+				}
+			}
+		}
+	}
+}
+
+// testCtxTri tests the triangular Ctx routines, whose tiles are
+// independent and computed as without a context whatever the "ctxk"
+// tile kb, so the results must be identical.
+func testCtxTri(t *testing.T, kb int) {
+	rnd := rand.New(rand.NewSource(1))
+	ctx := context.Background()
+	var impl Blas
+	for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+		for _, s := range []blas.Side{blas.Left, blas.Right} {
+			for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					m, n := 7, 8
+					k := n
+					if s == blas.Left {
+						k = m
+					}
+					lda, ldb := k+1, ld(o, m, n)
+					a := random(rnd, lda*k)
+					// A well-conditioned triangle for the solves.
+					for i := 0; i < k; i++ {
+						a[i*lda+i] = 4 + a[i*lda+i]
+					}
+					b := random(rnd, size(o, m, n, ldb))
+
+					want := append([]float64(nil), b...)
+					got := append([]float64(nil), b...)
+					impl.Dtrmm(o, s, ul, tA, blas.NonUnit, m, n, 1.5, a, lda, want, ldb)
+					if err := impl.DtrmmCtx(ctx, o, s, ul, tA, blas.NonUnit, m, n, 1.5, a, lda, got, ldb); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					same(t, fmt.Sprintf("DtrmmCtx(%v, %v, %v, %v) ctxk=%d", o, s, ul, tA, kb), got, want, 0)
+
+					want = append(want[:0], b...)
+					got = append(got[:0], b...)
+					impl.Dtrsm(o, s, ul, tA, blas.NonUnit, m, n, 1.5, a, lda, want, ldb)
+					if err := impl.DtrsmCtx(ctx, o, s, ul, tA, blas.NonUnit, m, n, 1.5, a, lda, got, ldb); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					same(t, fmt.Sprintf("DtrsmCtx(%v, %v, %v, %v) ctxk=%d", o, s, ul, tA, kb), got, want, 0)
+				}
+			}
+		}
+	}
+}
+
+// TestCtxCancel tests that a Ctx routine with a cancelled context returns
+// its error without modifying its output.
+func TestCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := []float64{1, 2, 3, 4}
+	err := Blas{}.DgemmCtx(ctx, blas.RowMajor, blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, []float64{1, 2, 3, 4}, 2, []float64{1, 2, 3, 4}, 2, 0, c, 2)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got %v want %v", err, context.Canceled)
+	}
+	if c[0] != 1 || c[1] != 2 || c[2] != 3 || c[3] != 4 {
+		t.Errorf("output modified: %v", c)
+	}
+}
+
+// cancelAfter is a context that is cancelled after its Err method has
+// been called n times, so after n tiles of a Ctx routine.
+type cancelAfter struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+// TestCtxCancelPartial tests the state of the output of a Ctx routine
+// cancelled after its first tile.
+func TestCtxCancelPartial(t *testing.T) {
+	defer SetTile("ctx", SetTile("ctx", 2))
+	rnd := rand.New(rand.NewSource(1))
+	var impl Blas
+	const m, n, k = 4, 4, 4
+	a, b, c := random(rnd, m*k), random(rnd, k*n), random(rnd, m*n)
+
+	// The tiles of C are 2×2 and visited in row-major order, so only the
+	// top left tile holds its result.
+	for _, kb := range []int{0, 2} {
+		prev := SetTile("ctxk", kb)
+		want := append([]float64(nil), c...)
+		// With the inner dimension split in two panels, the tile holds
+		// beta * C plus the product over the first panel.
+		kk := k
+		if kb != 0 {
+			kk = kb
+		}
+		impl.Dgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, m, n, kk, 0.5, a, k, b, n, -1, want, n)
+		got := append([]float64(nil), c...)
+		err := impl.DgemmCtx(&cancelAfter{Context: context.Background(), n: 1}, blas.RowMajor, blas.NoTrans, blas.NoTrans, m, n, k, 0.5, a, k, b, n, -1, got, n)
+		if err != context.Canceled {
+			t.Errorf("ctxk=%d: unexpected error: got %v want %v", kb, err, context.Canceled)
+		}
+		for i := 0; i < m; i++ {
+			for j := 0; j < n; j++ {
+				w := c[i*n+j]
+				if i < 2 && j < 2 {
+					w = want[i*n+j]
+				}
+				if got[i*n+j] != w {
+					t.Errorf("DgemmCtx ctxk=%d: element (%d, %d): got %v want %v", kb, i, j, got[i*n+j], w)
+				}
+			}
+		}
+		SetTile("ctxk", prev)
+	}
+
+	// The tiles of a left-sided triangular multiply are the columns of B.
+	tri := random(rnd, m*m)
+	want := append([]float64(nil), c...)
+	impl.Dtrmm(blas.ColMajor, blas.Left, blas.Upper, blas.Trans, blas.NonUnit, m, n, 2, tri, m, want, m)
+	got := append([]float64(nil), c...)
+	err := impl.DtrmmCtx(&cancelAfter{Context: context.Background(), n: 1}, blas.ColMajor, blas.Left, blas.Upper, blas.Trans, blas.NonUnit, m, n, 2, tri, m, got, m)
+	if err != context.Canceled {
+		t.Errorf("unexpected error: got %v want %v", err, context.Canceled)
+	}
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			w := c[j*m+i]
+			if j < 2 {
+				w = want[j*m+i]
+			}
+			if got[j*m+i] != w {
+				t.Errorf("DtrmmCtx: element (%d, %d): got %v want %v", i, j, got[j*m+i], w)
+			}
+		}
+	}
+}
+
+// ld returns a leading dimension one larger than needed for an r×c matrix
+// in the order o.
+func ld(o blas.Order, r, c int) int {
+	if o == blas.ColMajor {
+		return r + 1
+	}
+	return c + 1
+}
+
+// size returns the length of storage for an r×c matrix in the order o with
+// leading dimension ld.
+func size(o blas.Order, r, c, ld int) int {
+	if o == blas.ColMajor {
+		r = c
+	}
+	return r * ld
+}
+
+func random(rnd *rand.Rand, n int) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = rnd.Float64()*2 - 1
+	}
+	return v
+}
+
+// same reports the elements of got that differ from want by more than the
+// rounding of a sum of k terms, so a k of zero requires equality.
+func same(t *testing.T, desc string, got, want []float64, k int) {
+	t.Helper()
+	tol := 1e-14 * float64(k)
+	for i, w := range want {
+		if math.Abs(got[i]-w) > tol*(1+math.Abs(w)) {
+			t.Errorf("%s: element %d: got %v want %v", desc, i, got[i], w)
+		}
+	}
+}
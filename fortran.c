@@ -0,0 +1,767 @@
+//go:build fortran
+
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+/*
+ * A CBLAS layer over the Fortran 77 BLAS interface.
+ *
+ * This file is only compiled when the fortran build tag is set. It provides
+ * the cblas_* functions used by blas.go for systems that ship a Fortran BLAS
+ * (sgemm_, zdotc_ ...) but no CBLAS library. The Fortran routines only
+ * understand column-major storage, so row-major calls are mapped onto the
+ * equivalent problem on the transposed matrices. Where that transposition
+ * leaves a conjugated operand that Fortran cannot express, the conjugation is
+ * applied to the vector operands on either side of the call.
+ *
+ * The calling convention can be adjusted with the following macros:
+ *
+ *  F77_INT      the Fortran INTEGER type (default int)
+ *  F77_CHARLEN  the type of the hidden CHARACTER length arguments (default size_t)
+ *  F77_F2C      use the f2c/g77 return convention: REAL functions return
+ *               double and COMPLEX functions return through a hidden first
+ *               argument.
+ */
+
+#include <complex.h>
+#include <stddef.h>
+#include <stdlib.h>
+#include "cblas.h"
+
+#ifndef F77_INT
+#define F77_INT int
+#endif
+
+#ifndef F77_CHARLEN
+#define F77_CHARLEN size_t
+#endif
+
+#ifdef F77_F2C
+typedef double f77_real;
+#else
+typedef float f77_real;
+#endif
+
+typedef const F77_INT f77_int;
+typedef const char f77_char;
+typedef F77_CHARLEN f77_len;
+
+/*
+ * Fortran BLAS prototypes.
+ */
+
+#define REAL_PROTOTYPES(p, T, R) \
+R p##dot_(f77_int *n, const T *x, f77_int *incx, const T *y, f77_int *incy); \
+R p##nrm2_(f77_int *n, const T *x, f77_int *incx); \
+R p##asum_(f77_int *n, const T *x, f77_int *incx); \
+F77_INT i##p##amax_(f77_int *n, const T *x, f77_int *incx); \
+void p##swap_(f77_int *n, T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##copy_(f77_int *n, const T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##axpy_(f77_int *n, const T *alpha, const T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##rotg_(T *a, T *b, T *c, T *s); \
+void p##rotmg_(T *d1, T *d2, T *b1, const T *b2, T *p); \
+void p##rot_(f77_int *n, T *x, f77_int *incx, T *y, f77_int *incy, const T *c, const T *s); \
+void p##rotm_(f77_int *n, T *x, f77_int *incx, T *y, f77_int *incy, const T *p); \
+void p##scal_(f77_int *n, const T *alpha, T *x, f77_int *incx); \
+void p##symv_(f77_char *uplo, f77_int *n, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##sbmv_(f77_char *uplo, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##spmv_(f77_char *uplo, f77_int *n, const T *alpha, const T *ap, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##ger_(f77_int *m, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *a, f77_int *lda); \
+void p##syr_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, T *a, f77_int *lda, f77_len); \
+void p##spr_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, T *ap, f77_len); \
+void p##syr2_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *a, f77_int *lda, f77_len); \
+void p##spr2_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *ap, f77_len);
+
+#define COMMON_PROTOTYPES(p, T) \
+void p##gemv_(f77_char *trans, f77_int *m, f77_int *n, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##gbmv_(f77_char *trans, f77_int *m, f77_int *n, f77_int *kl, f77_int *ku, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##trmv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, const T *a, f77_int *lda, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##tbmv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, f77_int *k, const T *a, f77_int *lda, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##tpmv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, const T *ap, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##trsv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, const T *a, f77_int *lda, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##tbsv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, f77_int *k, const T *a, f77_int *lda, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##tpsv_(f77_char *uplo, f77_char *trans, f77_char *diag, f77_int *n, const T *ap, T *x, f77_int *incx, f77_len, f77_len, f77_len); \
+void p##gemm_(f77_char *transa, f77_char *transb, f77_int *m, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *b, f77_int *ldb, const T *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##symm_(f77_char *side, f77_char *uplo, f77_int *m, f77_int *n, const T *alpha, const T *a, f77_int *lda, const T *b, f77_int *ldb, const T *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##syrk_(f77_char *uplo, f77_char *trans, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##syr2k_(f77_char *uplo, f77_char *trans, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *b, f77_int *ldb, const T *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##trmm_(f77_char *side, f77_char *uplo, f77_char *transa, f77_char *diag, f77_int *m, f77_int *n, const T *alpha, const T *a, f77_int *lda, T *b, f77_int *ldb, f77_len, f77_len, f77_len, f77_len); \
+void p##trsm_(f77_char *side, f77_char *uplo, f77_char *transa, f77_char *diag, f77_int *m, f77_int *n, const T *alpha, const T *a, f77_int *lda, T *b, f77_int *ldb, f77_len, f77_len, f77_len, f77_len);
+
+#ifdef F77_F2C
+#define COMPLEX_DOT_PROTOTYPES(p, T) \
+void p##dotu_(T *ret, f77_int *n, const T *x, f77_int *incx, const T *y, f77_int *incy); \
+void p##dotc_(T *ret, f77_int *n, const T *x, f77_int *incx, const T *y, f77_int *incy);
+#else
+#define COMPLEX_DOT_PROTOTYPES(p, T) \
+T p##dotu_(f77_int *n, const T *x, f77_int *incx, const T *y, f77_int *incy); \
+T p##dotc_(f77_int *n, const T *x, f77_int *incx, const T *y, f77_int *incy);
+#endif
+
+#define COMPLEX_PROTOTYPES(p, q, r, T, S, R) \
+COMPLEX_DOT_PROTOTYPES(p, T) \
+R q##nrm2_(f77_int *n, const T *x, f77_int *incx); \
+R q##asum_(f77_int *n, const T *x, f77_int *incx); \
+F77_INT i##p##amax_(f77_int *n, const T *x, f77_int *incx); \
+void p##swap_(f77_int *n, T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##copy_(f77_int *n, const T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##axpy_(f77_int *n, const T *alpha, const T *x, f77_int *incx, T *y, f77_int *incy); \
+void p##scal_(f77_int *n, const T *alpha, T *x, f77_int *incx); \
+void p##r##scal_(f77_int *n, const S *alpha, T *x, f77_int *incx); \
+void p##hemv_(f77_char *uplo, f77_int *n, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##hbmv_(f77_char *uplo, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##hpmv_(f77_char *uplo, f77_int *n, const T *alpha, const T *ap, const T *x, f77_int *incx, const T *beta, T *y, f77_int *incy, f77_len); \
+void p##geru_(f77_int *m, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *a, f77_int *lda); \
+void p##gerc_(f77_int *m, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *a, f77_int *lda); \
+void p##her_(f77_char *uplo, f77_int *n, const S *alpha, const T *x, f77_int *incx, T *a, f77_int *lda, f77_len); \
+void p##hpr_(f77_char *uplo, f77_int *n, const S *alpha, const T *x, f77_int *incx, T *ap, f77_len); \
+void p##her2_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *a, f77_int *lda, f77_len); \
+void p##hpr2_(f77_char *uplo, f77_int *n, const T *alpha, const T *x, f77_int *incx, const T *y, f77_int *incy, T *ap, f77_len); \
+void p##hemm_(f77_char *side, f77_char *uplo, f77_int *m, f77_int *n, const T *alpha, const T *a, f77_int *lda, const T *b, f77_int *ldb, const T *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##herk_(f77_char *uplo, f77_char *trans, f77_int *n, f77_int *k, const S *alpha, const T *a, f77_int *lda, const S *beta, T *c, f77_int *ldc, f77_len, f77_len); \
+void p##her2k_(f77_char *uplo, f77_char *trans, f77_int *n, f77_int *k, const T *alpha, const T *a, f77_int *lda, const T *b, f77_int *ldb, const S *beta, T *c, f77_int *ldc, f77_len, f77_len);
+
+REAL_PROTOTYPES(s, float, f77_real)
+REAL_PROTOTYPES(d, double, double)
+COMMON_PROTOTYPES(s, float)
+COMMON_PROTOTYPES(d, double)
+COMMON_PROTOTYPES(c, float complex)
+COMMON_PROTOTYPES(z, double complex)
+COMPLEX_PROTOTYPES(c, sc, s, float complex, float, f77_real)
+COMPLEX_PROTOTYPES(z, dz, d, double complex, double, double)
+
+f77_real sdsdot_(f77_int *n, const float *sb, const float *x, f77_int *incx, const float *y, f77_int *incy);
+double dsdot_(f77_int *n, const float *x, f77_int *incx, const float *y, f77_int *incy);
+
+/*
+ * Argument translation.
+ */
+
+static char trans(enum CBLAS_TRANSPOSE t) {
+	switch (t) {
+	case CblasNoTrans:
+		return 'N';
+	case CblasTrans:
+		return 'T';
+	default:
+		return 'C';
+	}
+}
+
+// rowTrans returns the transpose flag for the column-major view of a
+// row-major matrix when the transpose flag can be expressed directly.
+static char rowTrans(enum CBLAS_TRANSPOSE t) {
+	return t == CblasNoTrans ? 'T' : 'N';
+}
+
+// rowConjTrans is rowTrans for routines where the row-major transpose
+// of NoTrans is ConjTrans, such as herk and her2k.
+static char rowConjTrans(enum CBLAS_TRANSPOSE t) {
+	return t == CblasNoTrans ? 'C' : 'N';
+}
+
+static char uplo(enum CBLAS_ORDER o, enum CBLAS_UPLO u) {
+	if ((u == CblasUpper) == (o == CblasColMajor)) {
+		return 'U';
+	}
+	return 'L';
+}
+
+static char diag(enum CBLAS_DIAG d) {
+	return d == CblasUnit ? 'U' : 'N';
+}
+
+static char side(enum CBLAS_ORDER o, enum CBLAS_SIDE s) {
+	if ((s == CblasLeft) == (o == CblasColMajor)) {
+		return 'L';
+	}
+	return 'R';
+}
+
+static int iabs(int i) {
+	return i < 0 ? -i : i;
+}
+
+#define CONJUGATE(p, T, CONJ) \
+static void p##conj(int n, T *x, int incX) { \
+	int i, inc = iabs(incX); \
+	for (i = 0; i < n; i++) { \
+		x[i*inc] = CONJ(x[i*inc]); \
+	} \
+} \
+/* p##conjCopy returns a contiguous conjugated copy of the n elements of x. \
+ * The copy must be used with an increment of sign(incX) and freed by the \
+ * caller. */ \
+static T *p##conjCopy(int n, const T *x, int incX) { \
+	int i, inc = iabs(incX); \
+	T *c = malloc((n > 0 ? n : 1) * sizeof(T)); \
+	if (c == NULL) { \
+		abort(); \
+	} \
+	for (i = 0; i < n; i++) { \
+		c[i] = CONJ(x[i*inc]); \
+	} \
+	return c; \
+}
+
+CONJUGATE(c, float complex, conjf)
+CONJUGATE(z, double complex, conj)
+
+static int sign(int i) {
+	return i < 0 ? -1 : 1;
+}
+
+/*
+ * Level 1
+ */
+
+float cblas_sdsdot(const int N, const float alpha, const float *X, const int incX, const float *Y, const int incY) {
+	F77_INT n = N, incx = incX, incy = incY;
+	return sdsdot_(&n, &alpha, X, &incx, Y, &incy);
+}
+
+double cblas_dsdot(const int N, const float *X, const int incX, const float *Y, const int incY) {
+	F77_INT n = N, incx = incX, incy = incY;
+	return dsdot_(&n, X, &incx, Y, &incy);
+}
+
+#define REAL_LEVEL1(p, T) \
+T cblas_##p##dot(const int N, const T *X, const int incX, const T *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	return p##dot_(&n, X, &incx, Y, &incy); \
+} \
+T cblas_##p##nrm2(const int N, const T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	return p##nrm2_(&n, X, &incx); \
+} \
+T cblas_##p##asum(const int N, const T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	return p##asum_(&n, X, &incx); \
+} \
+CBLAS_INDEX cblas_i##p##amax(const int N, const T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	F77_INT i = i##p##amax_(&n, X, &incx); \
+	return i ? i-1 : 0; \
+} \
+void cblas_##p##swap(const int N, T *X, const int incX, T *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##swap_(&n, X, &incx, Y, &incy); \
+} \
+void cblas_##p##copy(const int N, const T *X, const int incX, T *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##copy_(&n, X, &incx, Y, &incy); \
+} \
+void cblas_##p##axpy(const int N, const T alpha, const T *X, const int incX, T *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##axpy_(&n, &alpha, X, &incx, Y, &incy); \
+} \
+void cblas_##p##rotg(T *a, T *b, T *c, T *s) { \
+	p##rotg_(a, b, c, s); \
+} \
+void cblas_##p##rotmg(T *d1, T *d2, T *b1, const T b2, T *P) { \
+	p##rotmg_(d1, d2, b1, &b2, P); \
+} \
+void cblas_##p##rot(const int N, T *X, const int incX, T *Y, const int incY, const T c, const T s) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##rot_(&n, X, &incx, Y, &incy, &c, &s); \
+} \
+void cblas_##p##rotm(const int N, T *X, const int incX, T *Y, const int incY, const T *P) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##rotm_(&n, X, &incx, Y, &incy, P); \
+} \
+void cblas_##p##scal(const int N, const T alpha, T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	p##scal_(&n, &alpha, X, &incx); \
+}
+
+REAL_LEVEL1(s, float)
+REAL_LEVEL1(d, double)
+
+#ifdef F77_F2C
+#define COMPLEX_DOT(p, T, kind) \
+void cblas_##p##dot##kind##_sub(const int N, const void *X, const int incX, const void *Y, const int incY, void *dot) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##dot##kind##_((T *)dot, &n, X, &incx, Y, &incy); \
+}
+#else
+#define COMPLEX_DOT(p, T, kind) \
+void cblas_##p##dot##kind##_sub(const int N, const void *X, const int incX, const void *Y, const int incY, void *dot) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	*(T *)dot = p##dot##kind##_(&n, X, &incx, Y, &incy); \
+}
+#endif
+
+#define COMPLEX_LEVEL1(p, q, T, S) \
+COMPLEX_DOT(p, T, u) \
+COMPLEX_DOT(p, T, c) \
+S cblas_##q##nrm2(const int N, const void *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	return q##nrm2_(&n, X, &incx); \
+} \
+S cblas_##q##asum(const int N, const void *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	return q##asum_(&n, X, &incx); \
+} \
+CBLAS_INDEX cblas_i##p##amax(const int N, const void *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	F77_INT i = i##p##amax_(&n, X, &incx); \
+	return i ? i-1 : 0; \
+} \
+void cblas_##p##swap(const int N, void *X, const int incX, void *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##swap_(&n, X, &incx, Y, &incy); \
+} \
+void cblas_##p##copy(const int N, const void *X, const int incX, void *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##copy_(&n, X, &incx, Y, &incy); \
+} \
+void cblas_##p##axpy(const int N, const void *alpha, const void *X, const int incX, void *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	p##axpy_(&n, alpha, X, &incx, Y, &incy); \
+} \
+void cblas_##p##scal(const int N, const void *alpha, void *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	p##scal_(&n, alpha, X, &incx); \
+}
+
+COMPLEX_LEVEL1(c, sc, float complex, float)
+COMPLEX_LEVEL1(z, dz, double complex, double)
+
+void cblas_csscal(const int N, const float alpha, void *X, const int incX) {
+	F77_INT n = N, incx = incX;
+	csscal_(&n, &alpha, X, &incx);
+}
+
+void cblas_zdscal(const int N, const double alpha, void *X, const int incX) {
+	F77_INT n = N, incx = incX;
+	zdscal_(&n, &alpha, X, &incx);
+}
+
+/*
+ * Level 2
+ */
+
+#define REAL_LEVEL2(p, T) \
+void cblas_##p##gemv(const enum CBLAS_ORDER Order, const enum CBLAS_TRANSPOSE TransA, const int M, const int N, const T alpha, const T *A, const int lda, const T *X, const int incX, const T beta, T *Y, const int incY) { \
+	F77_INT m = M, n = N, ld = lda, incx = incX, incy = incY; \
+	char t; \
+	if (Order == CblasColMajor) { \
+		t = TransA == CblasNoTrans ? 'N' : 'T'; \
+		p##gemv_(&t, &m, &n, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+		return; \
+	} \
+	t = rowTrans(TransA); \
+	p##gemv_(&t, &n, &m, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+} \
+void cblas_##p##gbmv(const enum CBLAS_ORDER Order, const enum CBLAS_TRANSPOSE TransA, const int M, const int N, const int KL, const int KU, const T alpha, const T *A, const int lda, const T *X, const int incX, const T beta, T *Y, const int incY) { \
+	F77_INT m = M, n = N, kl = KL, ku = KU, ld = lda, incx = incX, incy = incY; \
+	char t; \
+	if (Order == CblasColMajor) { \
+		t = TransA == CblasNoTrans ? 'N' : 'T'; \
+		p##gbmv_(&t, &m, &n, &kl, &ku, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+		return; \
+	} \
+	t = rowTrans(TransA); \
+	p##gbmv_(&t, &n, &m, &ku, &kl, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+} \
+void cblas_##p##trmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const T *A, const int lda, T *X, const int incX) { \
+	F77_INT n = N, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##trmv_(&u, &t, &d, &n, A, &ld, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##tbmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const int K, const T *A, const int lda, T *X, const int incX) { \
+	F77_INT n = N, k = K, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##tbmv_(&u, &t, &d, &n, &k, A, &ld, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##tpmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const T *Ap, T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##tpmv_(&u, &t, &d, &n, Ap, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##trsv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const T *A, const int lda, T *X, const int incX) { \
+	F77_INT n = N, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##trsv_(&u, &t, &d, &n, A, &ld, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##tbsv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const int K, const T *A, const int lda, T *X, const int incX) { \
+	F77_INT n = N, k = K, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##tbsv_(&u, &t, &d, &n, &k, A, &ld, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##tpsv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const T *Ap, T *X, const int incX) { \
+	F77_INT n = N, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag); \
+	char t = Order == CblasColMajor ? (TransA == CblasNoTrans ? 'N' : 'T') : rowTrans(TransA); \
+	p##tpsv_(&u, &t, &d, &n, Ap, X, &incx, 1, 1, 1); \
+} \
+void cblas_##p##symv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *A, const int lda, const T *X, const int incX, const T beta, T *Y, const int incY) { \
+	F77_INT n = N, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	p##symv_(&u, &n, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+} \
+void cblas_##p##sbmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const int K, const T alpha, const T *A, const int lda, const T *X, const int incX, const T beta, T *Y, const int incY) { \
+	F77_INT n = N, k = K, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	p##sbmv_(&u, &n, &k, &alpha, A, &ld, X, &incx, &beta, Y, &incy, 1); \
+} \
+void cblas_##p##spmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *Ap, const T *X, const int incX, const T beta, T *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	p##spmv_(&u, &n, &alpha, Ap, X, &incx, &beta, Y, &incy, 1); \
+} \
+void cblas_##p##ger(const enum CBLAS_ORDER Order, const int M, const int N, const T alpha, const T *X, const int incX, const T *Y, const int incY, T *A, const int lda) { \
+	F77_INT m = M, n = N, ld = lda, incx = incX, incy = incY; \
+	if (Order == CblasColMajor) { \
+		p##ger_(&m, &n, &alpha, X, &incx, Y, &incy, A, &ld); \
+		return; \
+	} \
+	p##ger_(&n, &m, &alpha, Y, &incy, X, &incx, A, &ld); \
+} \
+void cblas_##p##syr(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *X, const int incX, T *A, const int lda) { \
+	F77_INT n = N, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo); \
+	p##syr_(&u, &n, &alpha, X, &incx, A, &ld, 1); \
+} \
+void cblas_##p##spr(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *X, const int incX, T *Ap) { \
+	F77_INT n = N, incx = incX; \
+	char u = uplo(Order, Uplo); \
+	p##spr_(&u, &n, &alpha, X, &incx, Ap, 1); \
+} \
+void cblas_##p##syr2(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *X, const int incX, const T *Y, const int incY, T *A, const int lda) { \
+	F77_INT n = N, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	p##syr2_(&u, &n, &alpha, X, &incx, Y, &incy, A, &ld, 1); \
+} \
+void cblas_##p##spr2(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const T alpha, const T *X, const int incX, const T *Y, const int incY, T *A) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	p##spr2_(&u, &n, &alpha, X, &incx, Y, &incy, A, 1); \
+}
+
+REAL_LEVEL2(s, float)
+REAL_LEVEL2(d, double)
+
+// For complex types a row-major ConjTrans operation is a conjugated
+// NoTrans operation on the column-major view, which Fortran cannot
+// express. In those cases the vector operands are conjugated so that
+// conj(y) = conj(alpha)*A_c*conj(x) + conj(beta)*conj(y) is computed
+// instead.
+
+#define COMPLEX_MV(p, T, CONJ, name, decl, args) \
+void cblas_##p##name decl { \
+	F77_INT n = N, incx = incX; \
+	char u = uplo(Order, Uplo), d = diag(Diag), t; \
+	if (Order == CblasColMajor) { \
+		t = trans(TransA); \
+		p##name##_ args; \
+		return; \
+	} \
+	if (TransA != CblasConjTrans) { \
+		t = rowTrans(TransA); \
+		p##name##_ args; \
+		return; \
+	} \
+	t = 'N'; \
+	p##conj(N, X, incX); \
+	p##name##_ args; \
+	p##conj(N, X, incX); \
+}
+
+#define COMPLEX_LEVEL2(p, T, S, CONJ) \
+static void p##gemvRowConj(const int M, const int N, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY, int band, const int KL, const int KU) { \
+	F77_INT m = M, n = N, kl = KL, ku = KU, ld = lda, incx = sign(incX), incy = incY; \
+	T a = CONJ(*(const T *)alpha), b = CONJ(*(const T *)beta); \
+	T *x = p##conjCopy(M, X, incX); \
+	char t = 'N'; \
+	p##conj(N, Y, incY); \
+	if (band) { \
+		p##gbmv_(&t, &n, &m, &ku, &kl, &a, A, &ld, x, &incx, &b, Y, &incy, 1); \
+	} else { \
+		p##gemv_(&t, &n, &m, &a, A, &ld, x, &incx, &b, Y, &incy, 1); \
+	} \
+	p##conj(N, Y, incY); \
+	free(x); \
+} \
+void cblas_##p##gemv(const enum CBLAS_ORDER Order, const enum CBLAS_TRANSPOSE TransA, const int M, const int N, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT m = M, n = N, ld = lda, incx = incX, incy = incY; \
+	char t; \
+	if (Order == CblasColMajor) { \
+		t = trans(TransA); \
+		p##gemv_(&t, &m, &n, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	if (TransA != CblasConjTrans) { \
+		t = rowTrans(TransA); \
+		p##gemv_(&t, &n, &m, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	p##gemvRowConj(M, N, alpha, A, lda, X, incX, beta, Y, incY, 0, 0, 0); \
+} \
+void cblas_##p##gbmv(const enum CBLAS_ORDER Order, const enum CBLAS_TRANSPOSE TransA, const int M, const int N, const int KL, const int KU, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT m = M, n = N, kl = KL, ku = KU, ld = lda, incx = incX, incy = incY; \
+	char t; \
+	if (Order == CblasColMajor) { \
+		t = trans(TransA); \
+		p##gbmv_(&t, &m, &n, &kl, &ku, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	if (TransA != CblasConjTrans) { \
+		t = rowTrans(TransA); \
+		p##gbmv_(&t, &n, &m, &ku, &kl, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	p##gemvRowConj(M, N, alpha, A, lda, X, incX, beta, Y, incY, 1, KL, KU); \
+} \
+COMPLEX_MV(p, T, CONJ, trmv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const void *A, const int lda, void *X, const int incX), \
+	(&u, &t, &d, &n, A, &(F77_INT){lda}, X, &incx, 1, 1, 1)) \
+COMPLEX_MV(p, T, CONJ, tbmv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const int K, const void *A, const int lda, void *X, const int incX), \
+	(&u, &t, &d, &n, &(F77_INT){K}, A, &(F77_INT){lda}, X, &incx, 1, 1, 1)) \
+COMPLEX_MV(p, T, CONJ, tpmv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const void *Ap, void *X, const int incX), \
+	(&u, &t, &d, &n, Ap, X, &incx, 1, 1, 1)) \
+COMPLEX_MV(p, T, CONJ, trsv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const void *A, const int lda, void *X, const int incX), \
+	(&u, &t, &d, &n, A, &(F77_INT){lda}, X, &incx, 1, 1, 1)) \
+COMPLEX_MV(p, T, CONJ, tbsv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const int K, const void *A, const int lda, void *X, const int incX), \
+	(&u, &t, &d, &n, &(F77_INT){K}, A, &(F77_INT){lda}, X, &incx, 1, 1, 1)) \
+COMPLEX_MV(p, T, CONJ, tpsv, \
+	(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int N, const void *Ap, void *X, const int incX), \
+	(&u, &t, &d, &n, Ap, X, &incx, 1, 1, 1)) \
+/* A row-major Hermitian matrix is the conjugate of its column-major view. */ \
+static void p##hemvRow(int kind, const enum CBLAS_UPLO Uplo, const int N, const int K, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT n = N, k = K, ld = lda, incx = sign(incX), incy = incY; \
+	T a = CONJ(*(const T *)alpha), b = CONJ(*(const T *)beta); \
+	T *x = p##conjCopy(N, X, incX); \
+	char u = uplo(CblasRowMajor, Uplo); \
+	p##conj(N, Y, incY); \
+	switch (kind) { \
+	case 0: \
+		p##hemv_(&u, &n, &a, A, &ld, x, &incx, &b, Y, &incy, 1); \
+		break; \
+	case 1: \
+		p##hbmv_(&u, &n, &k, &a, A, &ld, x, &incx, &b, Y, &incy, 1); \
+		break; \
+	default: \
+		p##hpmv_(&u, &n, &a, A, x, &incx, &b, Y, &incy, 1); \
+	} \
+	p##conj(N, Y, incY); \
+	free(x); \
+} \
+void cblas_##p##hemv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT n = N, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	if (Order == CblasColMajor) { \
+		p##hemv_(&u, &n, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	p##hemvRow(0, Uplo, N, 0, alpha, A, lda, X, incX, beta, Y, incY); \
+} \
+void cblas_##p##hbmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const int K, const void *alpha, const void *A, const int lda, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT n = N, k = K, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	if (Order == CblasColMajor) { \
+		p##hbmv_(&u, &n, &k, alpha, A, &ld, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	p##hemvRow(1, Uplo, N, K, alpha, A, lda, X, incX, beta, Y, incY); \
+} \
+void cblas_##p##hpmv(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const void *alpha, const void *Ap, const void *X, const int incX, const void *beta, void *Y, const int incY) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	if (Order == CblasColMajor) { \
+		p##hpmv_(&u, &n, alpha, Ap, X, &incx, beta, Y, &incy, 1); \
+		return; \
+	} \
+	p##hemvRow(2, Uplo, N, 0, alpha, Ap, 1, X, incX, beta, Y, incY); \
+} \
+void cblas_##p##geru(const enum CBLAS_ORDER Order, const int M, const int N, const void *alpha, const void *X, const int incX, const void *Y, const int incY, void *A, const int lda) { \
+	F77_INT m = M, n = N, ld = lda, incx = incX, incy = incY; \
+	if (Order == CblasColMajor) { \
+		p##geru_(&m, &n, alpha, X, &incx, Y, &incy, A, &ld); \
+		return; \
+	} \
+	p##geru_(&n, &m, alpha, Y, &incy, X, &incx, A, &ld); \
+} \
+void cblas_##p##gerc(const enum CBLAS_ORDER Order, const int M, const int N, const void *alpha, const void *X, const int incX, const void *Y, const int incY, void *A, const int lda) { \
+	F77_INT m = M, n = N, ld = lda, incx = incX, incy = sign(incY); \
+	T *y; \
+	if (Order == CblasColMajor) { \
+		incy = incY; \
+		p##gerc_(&m, &n, alpha, X, &incx, Y, &incy, A, &ld); \
+		return; \
+	} \
+	/* A^T += alpha * conj(y) * x^T */ \
+	y = p##conjCopy(N, Y, incY); \
+	p##geru_(&n, &m, alpha, y, &incy, X, &incx, A, &ld); \
+	free(y); \
+} \
+void cblas_##p##her(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const S alpha, const void *X, const int incX, void *A, const int lda) { \
+	F77_INT n = N, ld = lda, incx = incX; \
+	char u = uplo(Order, Uplo); \
+	T *x; \
+	if (Order == CblasColMajor) { \
+		p##her_(&u, &n, &alpha, X, &incx, A, &ld, 1); \
+		return; \
+	} \
+	x = p##conjCopy(N, X, incX); \
+	incx = sign(incX); \
+	p##her_(&u, &n, &alpha, x, &incx, A, &ld, 1); \
+	free(x); \
+} \
+void cblas_##p##hpr(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const S alpha, const void *X, const int incX, void *A) { \
+	F77_INT n = N, incx = incX; \
+	char u = uplo(Order, Uplo); \
+	T *x; \
+	if (Order == CblasColMajor) { \
+		p##hpr_(&u, &n, &alpha, X, &incx, A, 1); \
+		return; \
+	} \
+	x = p##conjCopy(N, X, incX); \
+	incx = sign(incX); \
+	p##hpr_(&u, &n, &alpha, x, &incx, A, 1); \
+	free(x); \
+} \
+void cblas_##p##her2(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const void *alpha, const void *X, const int incX, const void *Y, const int incY, void *A, const int lda) { \
+	F77_INT n = N, ld = lda, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	T *x, *y; \
+	if (Order == CblasColMajor) { \
+		p##her2_(&u, &n, alpha, X, &incx, Y, &incy, A, &ld, 1); \
+		return; \
+	} \
+	/* conj(A) += alpha*conj(y)*conj(x)^H + conj(alpha)*conj(x)*conj(y)^H */ \
+	x = p##conjCopy(N, X, incX); \
+	y = p##conjCopy(N, Y, incY); \
+	incx = sign(incX); \
+	incy = sign(incY); \
+	p##her2_(&u, &n, alpha, y, &incy, x, &incx, A, &ld, 1); \
+	free(x); \
+	free(y); \
+} \
+void cblas_##p##hpr2(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const int N, const void *alpha, const void *X, const int incX, const void *Y, const int incY, void *Ap) { \
+	F77_INT n = N, incx = incX, incy = incY; \
+	char u = uplo(Order, Uplo); \
+	T *x, *y; \
+	if (Order == CblasColMajor) { \
+		p##hpr2_(&u, &n, alpha, X, &incx, Y, &incy, Ap, 1); \
+		return; \
+	} \
+	x = p##conjCopy(N, X, incX); \
+	y = p##conjCopy(N, Y, incY); \
+	incx = sign(incX); \
+	incy = sign(incY); \
+	p##hpr2_(&u, &n, alpha, y, &incy, x, &incx, Ap, 1); \
+	free(x); \
+	free(y); \
+}
+
+COMPLEX_LEVEL2(c, float complex, float, conjf)
+COMPLEX_LEVEL2(z, double complex, double, conj)
+
+/*
+ * Level 3
+ *
+ * A row-major matrix is the transpose of its column-major view, so
+ * C = op(A)*op(B) is computed as C^T = op(B)^T*op(A)^T, and side and
+ * triangle arguments are reflected.
+ */
+
+// S is the type of the alpha and beta parameters, V is the element type of
+// the matrix parameters and ALPHA and BETA are pointers to the scalars.
+#define LEVEL3(p, S, V, ALPHA, BETA) \
+void cblas_##p##gemm(const enum CBLAS_ORDER Order, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_TRANSPOSE TransB, const int M, const int N, const int K, S alpha, const V *A, const int lda, const V *B, const int ldb, S beta, V *C, const int ldc) { \
+	F77_INT m = M, n = N, k = K, lda_ = lda, ldb_ = ldb, ldc_ = ldc; \
+	char ta = trans(TransA), tb = trans(TransB); \
+	if (Order == CblasColMajor) { \
+		p##gemm_(&ta, &tb, &m, &n, &k, ALPHA, A, &lda_, B, &ldb_, BETA, C, &ldc_, 1, 1); \
+		return; \
+	} \
+	p##gemm_(&tb, &ta, &n, &m, &k, ALPHA, B, &ldb_, A, &lda_, BETA, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##symm(const enum CBLAS_ORDER Order, const enum CBLAS_SIDE Side, const enum CBLAS_UPLO Uplo, const int M, const int N, S alpha, const V *A, const int lda, const V *B, const int ldb, S beta, V *C, const int ldc) { \
+	F77_INT m = M, n = N, lda_ = lda, ldb_ = ldb, ldc_ = ldc; \
+	char s = side(Order, Side), u = uplo(Order, Uplo); \
+	if (Order == CblasColMajor) { \
+		p##symm_(&s, &u, &m, &n, ALPHA, A, &lda_, B, &ldb_, BETA, C, &ldc_, 1, 1); \
+		return; \
+	} \
+	p##symm_(&s, &u, &n, &m, ALPHA, A, &lda_, B, &ldb_, BETA, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##syrk(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE Trans, const int N, const int K, S alpha, const V *A, const int lda, S beta, V *C, const int ldc) { \
+	F77_INT n = N, k = K, lda_ = lda, ldc_ = ldc; \
+	char u = uplo(Order, Uplo); \
+	char t = Order == CblasColMajor ? (Trans == CblasNoTrans ? 'N' : 'T') : rowTrans(Trans); \
+	p##syrk_(&u, &t, &n, &k, ALPHA, A, &lda_, BETA, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##syr2k(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE Trans, const int N, const int K, S alpha, const V *A, const int lda, const V *B, const int ldb, S beta, V *C, const int ldc) { \
+	F77_INT n = N, k = K, lda_ = lda, ldb_ = ldb, ldc_ = ldc; \
+	char u = uplo(Order, Uplo); \
+	char t = Order == CblasColMajor ? (Trans == CblasNoTrans ? 'N' : 'T') : rowTrans(Trans); \
+	p##syr2k_(&u, &t, &n, &k, ALPHA, A, &lda_, B, &ldb_, BETA, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##trmm(const enum CBLAS_ORDER Order, const enum CBLAS_SIDE Side, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int M, const int N, S alpha, const V *A, const int lda, V *B, const int ldb) { \
+	F77_INT m = M, n = N, lda_ = lda, ldb_ = ldb; \
+	char s = side(Order, Side), u = uplo(Order, Uplo), t = trans(TransA), d = diag(Diag); \
+	if (Order == CblasColMajor) { \
+		p##trmm_(&s, &u, &t, &d, &m, &n, ALPHA, A, &lda_, B, &ldb_, 1, 1, 1, 1); \
+		return; \
+	} \
+	p##trmm_(&s, &u, &t, &d, &n, &m, ALPHA, A, &lda_, B, &ldb_, 1, 1, 1, 1); \
+} \
+void cblas_##p##trsm(const enum CBLAS_ORDER Order, const enum CBLAS_SIDE Side, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE TransA, const enum CBLAS_DIAG Diag, const int M, const int N, S alpha, const V *A, const int lda, V *B, const int ldb) { \
+	F77_INT m = M, n = N, lda_ = lda, ldb_ = ldb; \
+	char s = side(Order, Side), u = uplo(Order, Uplo), t = trans(TransA), d = diag(Diag); \
+	if (Order == CblasColMajor) { \
+		p##trsm_(&s, &u, &t, &d, &m, &n, ALPHA, A, &lda_, B, &ldb_, 1, 1, 1, 1); \
+		return; \
+	} \
+	p##trsm_(&s, &u, &t, &d, &n, &m, ALPHA, A, &lda_, B, &ldb_, 1, 1, 1, 1); \
+}
+
+LEVEL3(s, const float, float, &alpha, &beta)
+LEVEL3(d, const double, double, &alpha, &beta)
+LEVEL3(c, const void *, void, alpha, beta)
+LEVEL3(z, const void *, void, alpha, beta)
+
+#define HERMITIAN_LEVEL3(p, T, S, CONJ) \
+void cblas_##p##hemm(const enum CBLAS_ORDER Order, const enum CBLAS_SIDE Side, const enum CBLAS_UPLO Uplo, const int M, const int N, const void *alpha, const void *A, const int lda, const void *B, const int ldb, const void *beta, void *C, const int ldc) { \
+	F77_INT m = M, n = N, lda_ = lda, ldb_ = ldb, ldc_ = ldc; \
+	char s = side(Order, Side), u = uplo(Order, Uplo); \
+	if (Order == CblasColMajor) { \
+		p##hemm_(&s, &u, &m, &n, alpha, A, &lda_, B, &ldb_, beta, C, &ldc_, 1, 1); \
+		return; \
+	} \
+	p##hemm_(&s, &u, &n, &m, alpha, A, &lda_, B, &ldb_, beta, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##herk(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE Trans, const int N, const int K, const S alpha, const void *A, const int lda, const S beta, void *C, const int ldc) { \
+	F77_INT n = N, k = K, lda_ = lda, ldc_ = ldc; \
+	char u = uplo(Order, Uplo); \
+	char t = Order == CblasColMajor ? (Trans == CblasNoTrans ? 'N' : 'C') : rowConjTrans(Trans); \
+	p##herk_(&u, &t, &n, &k, &alpha, A, &lda_, &beta, C, &ldc_, 1, 1); \
+} \
+void cblas_##p##her2k(const enum CBLAS_ORDER Order, const enum CBLAS_UPLO Uplo, const enum CBLAS_TRANSPOSE Trans, const int N, const int K, const void *alpha, const void *A, const int lda, const void *B, const int ldb, const S beta, void *C, const int ldc) { \
+	F77_INT n = N, k = K, lda_ = lda, ldb_ = ldb, ldc_ = ldc; \
+	char u = uplo(Order, Uplo), t; \
+	T a; \
+	if (Order == CblasColMajor) { \
+		t = Trans == CblasNoTrans ? 'N' : 'C'; \
+		p##her2k_(&u, &t, &n, &k, alpha, A, &lda_, B, &ldb_, &beta, C, &ldc_, 1, 1); \
+		return; \
+	} \
+	t = rowConjTrans(Trans); \
+	a = CONJ(*(const T *)alpha); \
+	p##her2k_(&u, &t, &n, &k, &a, A, &lda_, B, &ldb_, &beta, C, &ldc_, 1, 1); \
+}
+
+HERMITIAN_LEVEL3(c, float complex, float, conjf)
+HERMITIAN_LEVEL3(z, double complex, double, conj)
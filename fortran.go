@@ -0,0 +1,27 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build fortran
+
+package cblas
+
+// Building with the fortran tag compiles fortran.c, a CBLAS layer over the
+// Fortran 77 BLAS symbols, for systems that provide only the Fortran
+// interface. The f2c tag selects the f2c/g77 calling convention for REAL and
+// COMPLEX functions, and the ilp64 tag selects 64-bit Fortran integers.
+
+/*
+#cgo f2c CFLAGS: -DF77_F2C
+#cgo ilp64 CFLAGS: -DF77_INT=long
+
+#ifndef F77_INT
+#define F77_INT int
+#endif
+static const int f77_int_size = sizeof(F77_INT);
+*/
+import "C"
+
+func init() {
+	fortranInt = 8 * int(C.f77_int_size)
+}
@@ -0,0 +1,16 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"testing"
+
+	"github.com/gonum/blas/cblas/blastest"
+)
+
+func FuzzFloat32(f *testing.F)    { blastest.FuzzFloat32(f, Blas{}) }
+func FuzzFloat64(f *testing.F)    { blastest.FuzzFloat64(f, Blas{}) }
+func FuzzComplex64(f *testing.F)  { blastest.FuzzComplex64(f, Blas{}) }
+func FuzzComplex128(f *testing.F) { blastest.FuzzComplex128(f, Blas{}) }
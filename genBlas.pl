@@ -7,10 +7,9 @@ use strict;
 use warnings;
 
 my $cblasHeader = "cblas.h";
-my $LIB = "/usr/lib/";
 
 my $excludeComplex = 0;
-my $excludeAtlas = 1;
+my $excludeAtlas = 1; # The ATLAS extensions are not generated.
 
 
 open(my $cblas, "<", $cblasHeader) or die;
@@ -31,12 +30,30 @@ my %done = ("cblas_errprn"     => 1,
 	        "cblas_zdotc_sub"  => 1,
 	        );
 
-my $atlas = "";
+# The nrm2 routines fall back to the portable implementations in nrm2.go
+# when the library's are unsafe.
+my %safe = ("cblas_snrm2"  => "snrm2",
+	        "cblas_dnrm2"  => "dnrm2",
+	        "cblas_scnrm2" => "scnrm2",
+	        "cblas_dznrm2" => "dznrm2",
+	        );
+
+# Calls to these routines below the threshold given by the variable in
+# small.go run the Go kernel named by the lowercased routine, with the
+# problem size given by the expression.
+my %small = ("cblas_saxpy" => ["smallSaxpy", "n"],
+	         "cblas_daxpy" => ["smallDaxpy", "n"],
+	         "cblas_sdot"  => ["smallSdot", "n"],
+	         "cblas_ddot"  => ["smallDdot", "n"],
+	         "cblas_sscal" => ["smallSscal", "n"],
+	         "cblas_dscal" => ["smallDscal", "n"],
+	         "cblas_sgemv" => ["smallSgemv", "m*n"],
+	         "cblas_dgemv" => ["smallDgemv", "m*n"],
+	         );
+
 if ($excludeAtlas) {
 	$done{'cblas_csrot'} = 1;
 	$done{'cblas_zdrot'} = 1;
-} else {
-	$atlas = " -latlas";
 }
 printf $goblas <<EOH;
 // Do not manually edit this file. It was created by the genBlas.pl script from ${cblasHeader}.
@@ -50,7 +67,6 @@ package cblas
 
 /*
 #cgo CFLAGS: -g -O2 -fPIC -m64 -pthread
-#cgo LDFLAGS: -L${LIB} -lblas${atlas}
 #include "${cblasHeader}"
 */
 import "C"
@@ -75,6 +91,24 @@ func max(a, b int) int {
 	return b
 }
 
+// checkOrdered checks an r×c matrix in the order o with leading dimension
+// ld held in a slice of length l.
+func checkOrdered(o blas.Order, r, c, l, ld int) {
+	if o == blas.ColMajor {
+		r, c = c, r
+	}
+	checkMatrix(r, c, l, ld)
+}
+
+// Blas implements the blas interfaces by calling the CBLAS library.
+//
+// The arguments of each method are checked before the library is called,
+// and illegal arguments cause a panic with a "cblas: " message rather than
+// a call to the error handler of the library. A negative increment steps
+// backwards through a vector, starting from its last element, as defined
+// by BLAS; earlier versions of this package panicked instead. As in the
+// reference BLAS, the nrm2 and asum methods return zero, the scal methods
+// do nothing and the i?amax methods return -1 for a negative increment.
 type Blas struct{}
 
 // Special cases...
@@ -89,16 +123,9 @@ func (Blas) Srotmg(d1 float32, d2 float32, b1 float32, b2 float32) (p *blas.Srot
 	return p, d1, d2, b1
 }
 func (Blas) Srotm(n int, x []float32, incX int, y []float32, incY int, p *blas.SrotmParams) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_srotm(C.int(n), (*C.float)(&x[0]), C.int(incX), (*C.float)(&y[0]), C.int(incY), (*C.float)(unsafe.Pointer(p)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_srotm(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY), (*C.float)(unsafe.Pointer(p)))
 }
 func (Blas) Drotg(a float64, b float64) (c float64, s float64, r float64, z float64) {
 	C.cblas_drotg((*C.double)(&a), (*C.double)(&b), (*C.double)(&c), (*C.double)(&s))
@@ -110,67 +137,32 @@ func (Blas) Drotmg(d1 float64, d2 float64, b1 float64, b2 float64) (p *blas.Drot
 	return p, d1, d2, b1
 }
 func (Blas) Drotm(n int, x []float64, incX int, y []float64, incY int, p *blas.DrotmParams) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_drotm(C.int(n), (*C.double)(&x[0]), C.int(incX), (*C.double)(&y[0]), C.int(incY), (*C.double)(unsafe.Pointer(p)))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_drotm(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY), (*C.double)(unsafe.Pointer(p)))
 }
 func (Blas) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cdotu_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotu))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotu_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotu))
 	return dotu
 }
 func (Blas) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_cdotc_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotc))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotc_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotc))
 	return dotc
 }
 func (Blas) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zdotu_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotu))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotu_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotu))
 	return dotu
 }
 func (Blas) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
-	if n < 0 {
-		panic("cblas: n < 0")
-	}
-	if incX <= 0 || n*incX > len(x) {
-		panic("cblas: index out of range")
-	}
-	if incY <= 0 || n*incY > len(y) {
-		panic("cblas: index out of range")
-	}
-	C.cblas_zdotc_sub(C.int(n), unsafe.Pointer(&x[0]), C.int(incX), unsafe.Pointer(&y[0]), C.int(incY), unsafe.Pointer(&dotc))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotc_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotc))
 	return dotc
 }
 EOH
@@ -223,6 +215,41 @@ foreach my $line (@lines) {
 }
 
 close($goblas);
+
+# The CBLAS functions neither keep the pointers they are passed nor call
+# back into Go, so each function called by blas.go is marked noescape and
+# nocallback. The directives need go1.24, so they are written to a file
+# of their own.
+open($goblas, "<", "blas.go") or die;
+my %bound;
+while (<$goblas>) {
+	$bound{$1} = 1 while m/\bC\.(cblas_\w+)\(/g;
+}
+close($goblas);
+open(my $noescape, ">", "noescape.go") or die;
+print $noescape <<EOH;
+// Do not manually edit this file. It was created by the genBlas.pl script from ${cblasHeader}.
+
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.24
+
+package cblas
+
+// The CBLAS functions neither keep the pointers they are passed nor call
+// back into Go. Marking them so lets the slices passed to the Blas methods
+// stay on the stack and avoids preparing for callbacks on each call.
+
+/*
+EOH
+foreach my $func (sort keys %bound) {
+	print $noescape "#cgo noescape $func\n#cgo nocallback $func\n";
+}
+print $noescape "*/\nimport \"C\"\n";
+close($noescape);
+
 `go fmt .`;
 
 sub process {
@@ -244,8 +271,24 @@ sub processProto {
 	my $GoRet = $retConv{$ret};
 	my $complexType = $func;
 	$complexType =~ s/.*_[isd]?([zc]).*/$1/;
-	print $goblas "func (Blas) ".Gofunc($func)."(".processParamToGo($func, $paramList, $complexType).") ".$GoRet."{\n";
+	my $GoParams = processParamToGo($func, $paramList, $complexType);
+	print $goblas "func (Blas) ".Gofunc($func)."(".$GoParams.") ".$GoRet."{\n";
 	print $goblas processParamToChecks($func, $paramList);
+	if ($safe{$func}) {
+		print $goblas "\tif safeNrm2() {\n\t\treturn $safe{$func}(n, x, incX)\n\t}\n";
+	}
+	if ($small{$func}) {
+		my ($threshold, $size) = @{$small{$func}};
+		my $args = join ", ", map { (split ' ')[0] } split /, /, $GoParams;
+		my $kernel = lcfirst Gofunc($func);
+		print $goblas "\tif below(&$threshold, $size) {\n";
+		if ($ret ne 'void') {
+			print $goblas "\t\treturn $kernel($args)\n";
+		} else {
+			print $goblas "\t\t$kernel($args)\n\t\treturn\n";
+		}
+		print $goblas "\t}\n";
+	}
 	print $goblas "\t";
 	if ($ret ne 'void') {
 		chop($GoRet);
@@ -373,7 +416,7 @@ sub processParamToChecks {
 			};
 			$var =~ /trans/ && do {
 				$var =~ s/trans([AB]?)/t$1/;
-				$scalarArgs{$var} = 1; next;
+				$scalarArgs{$var} = 1;
 				if ($func =~ m/cblas_[cz]h/) {
 					push @processed, "if $var != blas.NoTrans && $var != blas.ConjTrans { panic(\"cblas: illegal transpose\") }"; next;
 				} elsif ($func =~ m/cblas_[cz]s/) {
@@ -396,88 +439,79 @@ sub processParamToChecks {
 	}
 
 	# shape checks
+	# When the vector checks follow directly, they check n.
+	my $vectorChecksN = $scalarArgs{'incX'} && !($scalarArgs{'m'} || $scalarArgs{'k'} || $scalarArgs{'kL'} || $scalarArgs{'kU'});
 	foreach my $ref ('m', 'n', 'k', 'kL', 'kU') {
+		next if $ref eq 'n' && $vectorChecksN;
 		push @processed, "if $ref < 0 { panic(\"cblas: $ref < 0\") }" if $scalarArgs{$ref};
 	}
 
-	if ($arrayArgs{'ap'}) {
-		push @processed, "if n*(n + 1)/2 > len(ap) { panic(\"cblas: index out of range\") }"
-	}
-
-	if ($func =~ m/cblas_[sdcz]g[eb]mv/) {
-		push @processed, "if incX <= 0 || incY <= 0 { panic(\"cblas: index out of range\") }";
+	# vector checks
+	if ($func =~ m/amax$/) {
+		# There is no largest element of a vector with a negative increment.
+		push @processed, "if !checkReduction(n, len(x), incX) { return -1 }";
+	} elsif ($func =~ m/(?:nrm2|asum)$/) {
+		# The reductions of a vector with a negative increment are zero.
+		push @processed, "if !checkReduction(n, len(x), incX) { return 0 }";
+	} elsif ($func =~ m/scal$/) {
+		push @processed, "if !checkReduction(n, len(x), incX) { return }";
+	} elsif ($func =~ m/cblas_[sdcz]g[eb]mv/) {
 		push @processed, "var lenX, lenY int";
 		push @processed, "if tA == blas.NoTrans { lenX, lenY = n, m } else { lenX, lenY = m, n }";
-		push @processed, "if (lenX-1)*incX >= len(x) { panic(\"cblas: index out of range\") }";
-		push @processed, "if (lenY-1)*incY >= len(y) { panic(\"cblas: index out of range\") }";
+		push @processed, "checkVector(lenX, len(x), incX)";
+		push @processed, "checkVector(lenY, len(y), incY)";
 	} elsif ($scalarArgs{'m'}) {
-		push @processed, "if incX <= 0 || (m-1)*incX >= len(x) { panic(\"cblas: index out of range\") }" if $scalarArgs{'incX'};
-		push @processed, "if incY <= 0 || (n-1)*incY >= len(y) { panic(\"cblas: index out of range\") }" if $scalarArgs{'incY'};
+		push @processed, "checkVector(m, len(x), incX)" if $scalarArgs{'incX'};
+		push @processed, "checkVector(n, len(y), incY)" if $scalarArgs{'incY'};
 	} else {
-		push @processed, "if incX <= 0 || (n-1)*incX >= len(x) { panic(\"cblas: index out of range\") }" if $scalarArgs{'incX'};
-		push @processed, "if incY <= 0 || (n-1)*incY >= len(y) { panic(\"cblas: index out of range\") }" if $scalarArgs{'incY'};
+		push @processed, "checkVector(n, len(x), incX)" if $scalarArgs{'incX'};
+		push @processed, "checkVector(n, len(y), incY)" if $scalarArgs{'incY'};
 	}
 
-	if (not $func =~ m/(?:mm|r2?k)$/) {
+	# matrix checks
+	if ($arrayArgs{'ap'}) {
+		push @processed, "checkPacked(n, len(ap))";
+	}
+	if (not $func =~ m/(?:mm|sm|r2?k)$/) {
 		if ($arrayArgs{'a'}) {
 			if ($scalarArgs{'kL'} && $scalarArgs{'kU'}) {
-				push @processed, "if lda*n > len(a) || lda < kL+kU+1 { panic(\"cblas: index out of range\") }";
+				push @processed, "if o == blas.RowMajor { checkBand(m, n, kL, kU, len(a), lda) } else { checkBand(n, m, kU, kL, len(a), lda) }";
 			} elsif ($scalarArgs{'k'}) {
-				push @processed, "if lda*n > len(a) || lda < k+1 { panic(\"cblas: index out of range\") }";
+				push @processed, "checkBand(n, n, k, 0, len(a), lda)";
 			} elsif ($scalarArgs{'m'}) {
-				push @processed, "if lda*n > len(a) || lda < max(1, m) { panic(\"cblas: index out of range\") }";
+				push @processed, "checkOrdered(o, m, n, len(a), lda)";
 			} else {
-				push @processed, "if lda*n > len(a) || lda < max(1, n) { panic(\"cblas: index out of range\") }";
+				push @processed, "checkMatrix(n, n, len(a), lda)";
 			}
 		}
 	} else {
 		if ($scalarArgs{'s'}) {
 			push @processed, "var k int";
 			push @processed, "if s == blas.Left { k = m } else { k = n }";
-			push @processed, "if o == blas.RowMajor {";
-			push @processed, "if lda*n > len(a) || lda < max(1, m) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldb*k > len(b) || ldb < max(1, k) { panic(\"cblas: index out of range\") }";
-			push @processed, "} else {";
-			push @processed, "if lda*k > len(a) || lda < max(1, k) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldb*n > len(b) || ldb < max(1, m) { panic(\"cblas: index out of range\") }";
-			push @processed, "}";
+			push @processed, "checkMatrix(k, k, len(a), lda)";
+			push @processed, "checkOrdered(o, m, n, len(b), ldb)";
 		}
 		if ($scalarArgs{'t'}) {
 			push @processed, "var row, col int";
 			push @processed, "if t == blas.NoTrans { row, col = n, k } else { row, col = k, n }";
-			push @processed, "if o == blas.RowMajor {";
-			foreach my $ref ('a', 'b') {
-				if ($arrayArgs{$ref}) {
-					push @processed, "if ld${ref}*col > len(${ref}) || ld${ref} < max(1, row) { panic(\"cblas: index out of range\") }";
-				}
-			}
-			push @processed, "} else {";
 			foreach my $ref ('a', 'b') {
 				if ($arrayArgs{$ref}) {
-					push @processed, "if ld${ref}*row > len(${ref}) || ld${ref} < max(1, col) { panic(\"cblas: index out of range\") }";
+					push @processed, "checkOrdered(o, row, col, len(${ref}), ld${ref})";
 				}
 			}
-			push @processed, "}";
 		}
 		if ($scalarArgs{'tA'} && $scalarArgs{'tB'}) {
 			push @processed, "var rowA, colA, rowB, colB int";
 			push @processed, "if tA == blas.NoTrans { rowA, colA = m, k } else { rowA, colA = k, m }";
 			push @processed, "if tB == blas.NoTrans { rowB, colB = k, n } else { rowB, colB = n, k }";
-			push @processed, "if o == blas.RowMajor {";
-			push @processed, "if lda*rowA > len(a) || lda < max(1, colA) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldb*rowB > len(b) || ldb < max(1, colB) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldc*m > len(c) || ldc < max(1, n) { panic(\"cblas: index out of range\") }";
-			push @processed, "} else {";
-			push @processed, "if lda*colA > len(a) || lda < max(1, rowA) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldb*colB > len(b) || ldb < max(1, rowB) { panic(\"cblas: index out of range\") }";
-			push @processed, "if ldc*n > len(c) || ldc < max(1, m) { panic(\"cblas: index out of range\") }";
-			push @processed, "}";
+			push @processed, "checkOrdered(o, rowA, colA, len(a), lda)";
+			push @processed, "checkOrdered(o, rowB, colB, len(b), ldb)";
 		}
-		if ($arrayArgs{'c'} and !($scalarArgs{'tA'} && $scalarArgs{'tB'})) {
+		if ($arrayArgs{'c'}) {
 			if ($scalarArgs{'m'}) {
-				push @processed, "if ldc*n > len(c) || ldc < max(1, m) { panic(\"cblas: index out of range\") }"
+				push @processed, "checkOrdered(o, m, n, len(c), ldc)";
 			} else {
-				push @processed, "if ldc*n > len(c) || ldc < max(1, n) { panic(\"cblas: index out of range\") }"
+				push @processed, "checkMatrix(n, n, len(c), ldc)";
 			}
 		}
 	}
@@ -499,22 +533,24 @@ sub processParamToC {
 			push @processed, "(*C.int)(&".$var.")"; next;
 		};
 		$param =~ m/^(?:const )?void \*[a-zA-Z]/ && do {
-			my $type;
 			if ($var eq "alpha" || $var eq "beta") {
-				$type = "";
-			} else {
-				$type = "[0]";
+				push @processed, "unsafe.Pointer(&".$var.")"; next;
+			}
+			my $complexType = $func;
+			$complexType =~ s/.*_[isd]?([zc]).*/$1/;
+			if ($complexType eq 'c') {
+				push @processed, "c64(".$var.")"; next;
 			}
-			push @processed, "unsafe.Pointer(&".$var.$type.")"; next;
+			push @processed, "c128(".$var.")"; next;
 		};
 		$param =~ m/^(?:const )?char \*[a-zA-Z]/ && do {
 			push @processed, "(*C.char)(&".$var.")"; next;
 		};
 		$param =~ m/^(?:const )?float \*[a-zA-Z]/ && do {
-			push @processed, "(*C.float)(&".$var."[0])"; next;
+			push @processed, "f32(".$var.")"; next;
 		};
 		$param =~ m/^(?:const )?double \*[a-zA-Z]/ && do {
-			push @processed, "(*C.double)(&".$var."[0])"; next;
+			push @processed, "f64(".$var.")"; next;
 		};
 		$param =~ m/^(?:const )?int [a-zA-Z]/ && do {
 			push @processed, "C.int(".$var.")"; next;
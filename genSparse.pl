@@ -0,0 +1,308 @@
+#!/usr/bin/env perl
+# Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+# genSparse.pl writes sparseblas.go, the sparse matrix types and routines
+# for each precision, from the template below. In the template Scalar is
+# the element type and P the routine prefix. Lines ending in "// conj"
+# are only kept for complex types, where CONJ(v) is the conjugate of v.
+# Sections following a "@real" or "@complex" line are only written for
+# real or complex types, and sections following "@all" for every type.
+
+use strict;
+use warnings;
+
+my @types = (["S", "float32",    "single precision",         0],
+             ["D", "float64",    "double precision",         0],
+             ["C", "complex64",  "single precision complex", 1],
+             ["Z", "complex128", "double precision complex", 1]);
+
+my @sections = split(/^@(all|real|complex)\n/m, do { local $/; <DATA> });
+shift(@sections);
+
+open(my $out, ">", "sparseblas.go") or die;
+print $out <<EOH;
+// Do not manually edit this file. It was created by the genSparse.pl script.
+
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import "github.com/gonum/blas"
+EOH
+
+foreach my $t (@types) {
+	my ($p, $scalar, $desc, $complex) = @$t;
+	my $code = "";
+	for (my $i = 0; $i < @sections; $i += 2) {
+		my $kind = $sections[$i];
+		if ($kind eq "all" || ($kind eq "complex") == ($complex != 0)) {
+			$code .= $sections[$i+1];
+		}
+	}
+	if ($complex) {
+		$code =~ s/ *\/\/ conj$//mg;
+		$code =~ s/CONJ\((\w+)\)/complex(real($1), -imag($1))/g;
+	} else {
+		$code =~ s/^.*\/\/ conj\n//mg;
+	}
+	$code =~ s/\bScalar\b/$scalar/g;
+	$code =~ s/\bP(cs|gthr|sctr|axpyi|doti|dotci|dotui|roti)/${p}$1/g;
+	$code =~ s/\bP(CSR|CSC)/${p}$1/g;
+	$code =~ s/\bP(gather|scatter|spmm)/\l${p}$1/g;
+	$code =~ s/DESC/$desc/g;
+	print $out $code;
+}
+close($out);
+`gofmt -w sparseblas.go`;
+
+__DATA__
+@all
+
+// PCSR is a DESC sparse matrix in compressed sparse row format. The
+// column indices and values of the elements of row i are held in
+// ColIdx[RowPtr[i]:RowPtr[i+1]] and Val[RowPtr[i]:RowPtr[i+1]].
+type PCSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Val        []Scalar
+}
+
+// PCSC is a DESC sparse matrix in compressed sparse column format. The
+// row indices and values of the elements of column j are held in
+// RowIdx[ColPtr[j]:ColPtr[j+1]] and Val[ColPtr[j]:ColPtr[j+1]].
+type PCSC struct {
+	Rows, Cols int
+	ColPtr     []int
+	RowIdx     []int
+	Val        []Scalar
+}
+
+// Pcsrmv performs y = alpha * op(A) * x + beta * y for a CSR matrix A.
+func (Blas) Pcsrmv(tA blas.Transpose, alpha Scalar, a *PCSR, x []Scalar, incX int, beta Scalar, y []Scalar, incY int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		Pgather(a.Rows, alpha, a.RowPtr, a.ColIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		Pscatter(a.Rows, a.Cols, alpha, a.RowPtr, a.ColIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Pcscmv performs y = alpha * op(A) * x + beta * y for a CSC matrix A.
+func (Blas) Pcscmv(tA blas.Transpose, alpha Scalar, a *PCSC, x []Scalar, incX int, beta Scalar, y []Scalar, incY int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		Pscatter(a.Cols, a.Rows, alpha, a.ColPtr, a.RowIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		Pgather(a.Cols, alpha, a.ColPtr, a.RowIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Pcsrmm performs C = alpha * op(A) * B + beta * C for a CSR matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Pcsrmm(o blas.Order, tA blas.Transpose, n int, alpha Scalar, a *PCSR, b []Scalar, ldb int, beta Scalar, c []Scalar, ldc int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	Pspmm(o, tA == blas.NoTrans, tA == blas.ConjTrans, a.Rows, m, n, alpha, a.RowPtr, a.ColIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Pcscmm performs C = alpha * op(A) * B + beta * C for a CSC matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Pcscmm(o blas.Order, tA blas.Transpose, n int, alpha Scalar, a *PCSC, b []Scalar, ldb int, beta Scalar, c []Scalar, ldc int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	Pspmm(o, tA != blas.NoTrans, tA == blas.ConjTrans, a.Cols, m, n, alpha, a.ColPtr, a.RowIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Pgather computes y[i] = alpha * Σ val[p]*x[idx[p]] + beta * y[i] for
+// each of the major lines i of a compressed matrix.
+func Pgather(major int, alpha Scalar, ptr, idx []int, val []Scalar, conj bool, x []Scalar, incX int, beta Scalar, y []Scalar, incY int) {
+	for i := 0; i < major; i++ {
+		var s Scalar
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj { // conj
+				v = CONJ(v) // conj
+			} // conj
+			s += v * x[idx[p]*incX]
+		}
+		if beta == 0 {
+			y[i*incY] = alpha * s
+		} else {
+			y[i*incY] = alpha*s + beta*y[i*incY]
+		}
+	}
+}
+
+// Pscatter computes y = beta * y and adds alpha * val[p] * x[i] to
+// y[idx[p]] for each element p of the major lines i of a compressed
+// matrix with minor lines.
+func Pscatter(major, minor int, alpha Scalar, ptr, idx []int, val []Scalar, conj bool, x []Scalar, incX int, beta Scalar, y []Scalar, incY int) {
+	for i := 0; i < minor; i++ {
+		if beta == 0 {
+			y[i*incY] = 0
+		} else {
+			y[i*incY] *= beta
+		}
+	}
+	for i := 0; i < major; i++ {
+		t := alpha * x[i*incX]
+		if t == 0 {
+			continue
+		}
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj { // conj
+				v = CONJ(v) // conj
+			} // conj
+			y[idx[p]*incY] += v * t
+		}
+	}
+}
+
+// Pspmm computes C = alpha * op(A) * B + beta * C for a compressed matrix
+// A. If gather is true the major lines of A are the rows of op(A),
+// otherwise they are its columns.
+func Pspmm(o blas.Order, gather, conj bool, major, m, n int, alpha Scalar, ptr, idx []int, val []Scalar, b []Scalar, ldb int, beta Scalar, c []Scalar, ldc int) {
+	brs, bcs := strides(o, blas.NoTrans, ldb)
+	crs, ccs := strides(o, blas.NoTrans, ldc)
+	scale := func(i int) {
+		for j := 0; j < n; j++ {
+			if beta == 0 {
+				c[i*crs+j*ccs] = 0
+			} else {
+				c[i*crs+j*ccs] *= beta
+			}
+		}
+	}
+	if !gather {
+		for i := 0; i < m; i++ {
+			scale(i)
+		}
+	}
+	for q := 0; q < major; q++ {
+		if gather {
+			scale(q)
+		}
+		for p := ptr[q]; p < ptr[q+1]; p++ {
+			v := val[p]
+			if conj { // conj
+				v = CONJ(v) // conj
+			} // conj
+			t := alpha * v
+			// The element is at row q and column idx[p] of op(A)
+			// when gathering, and at row idx[p] and column q
+			// otherwise.
+			ci, bi := q, idx[p]
+			if !gather {
+				ci, bi = idx[p], q
+			}
+			for j := 0; j < n; j++ {
+				c[ci*crs+j*ccs] += t * b[bi*brs+j*bcs]
+			}
+		}
+	}
+}
+
+// Pgthr gathers the elements of y at the indices in indx into x,
+// x[i] = y[indx[i]] for i < n.
+func (Blas) Pgthr(n int, y []Scalar, x []Scalar, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+	}
+}
+
+// Pgthrz gathers the elements of y at the indices in indx into x and sets
+// them to zero in y.
+func (Blas) Pgthrz(n int, y []Scalar, x []Scalar, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+		y[j] = 0
+	}
+}
+
+// Psctr scatters the elements of x into y at the indices in indx,
+// y[indx[i]] = x[i] for i < n.
+func (Blas) Psctr(n int, x []Scalar, indx []int, y []Scalar) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		y[j] = x[i]
+	}
+}
+
+// Paxpyi adds alpha times the sparse vector x to y,
+// y[indx[i]] += alpha * x[i] for i < n.
+func (Blas) Paxpyi(n int, alpha Scalar, x []Scalar, indx []int, y []Scalar) {
+	checkSparseVec(n, len(x), indx, len(y))
+	if alpha == 0 {
+		return
+	}
+	for i, j := range indx[:n] {
+		y[j] += alpha * x[i]
+	}
+}
+@real
+
+// Pdoti returns the dot product of the sparse vector x and y,
+// the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Pdoti(n int, x []Scalar, indx []int, y []Scalar) Scalar {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s Scalar
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Proti applies the plane rotation (c, s) to the sparse vector x and the
+// elements of y at the indices in indx.
+func (Blas) Proti(n int, x []Scalar, indx []int, y []Scalar, c, s Scalar) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i], y[j] = c*x[i]+s*y[j], c*y[j]-s*x[i]
+	}
+}
+@complex
+
+// Pdotui returns the unconjugated dot product of the sparse vector x and
+// y, the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Pdotui(n int, x []Scalar, indx []int, y []Scalar) Scalar {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s Scalar
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Pdotci returns the dot product of the conjugate of the sparse vector x
+// and y, the sum of conj(x[i]) * y[indx[i]] for i < n.
+func (Blas) Pdotci(n int, x []Scalar, indx []int, y []Scalar) Scalar {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s Scalar
+	for i, j := range indx[:n] {
+		v := x[i]
+		s += CONJ(v) * y[j]
+	}
+	return s
+}
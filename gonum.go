@@ -0,0 +1,1480 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include "cblas.h"
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	gblas "gonum.org/v1/gonum/blas"
+)
+
+// Type check assertions:
+var (
+	_ gblas.Float32    = Implementation{}
+	_ gblas.Float64    = Implementation{}
+	_ gblas.Complex64  = Implementation{}
+	_ gblas.Complex128 = Implementation{}
+)
+
+// Implementation implements the gonum.org/v1/gonum/blas interfaces with
+// the same CBLAS library as Blas. Those interfaces take row-major matrices
+// only, allow negative vector increments and accept empty slices for empty
+// operands. An Implementation may be registered as the BLAS used by
+// gonum.org/v1/gonum/mat and the other gonum packages with
+//
+//	blas32.Use(cblas.Implementation{})
+//	blas64.Use(cblas.Implementation{})
+//	cblas64.Use(cblas.Implementation{})
+//	cblas128.Use(cblas.Implementation{})
+//
+// As in the gonum BLAS, the scal methods set x to zero when alpha is zero
+// rather than multiplying it, so NaN and infinite elements do not
+// propagate.
+type Implementation struct{}
+
+// The pointers passed to the CBLAS library for empty slices are nil.
+// They are not dereferenced since the corresponding operand is empty.
+
+func f32(x []float32) *C.float {
+	if len(x) == 0 {
+		return nil
+	}
+	return (*C.float)(&x[0])
+}
+
+func f64(x []float64) *C.double {
+	if len(x) == 0 {
+		return nil
+	}
+	return (*C.double)(&x[0])
+}
+
+func c64(x []complex64) unsafe.Pointer {
+	if len(x) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&x[0])
+}
+
+func c128(x []complex128) unsafe.Pointer {
+	if len(x) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&x[0])
+}
+
+func transpose(t gblas.Transpose) C.enum_CBLAS_TRANSPOSE {
+	switch t {
+	case gblas.NoTrans:
+		return C.CblasNoTrans
+	case gblas.Trans:
+		return C.CblasTrans
+	case gblas.ConjTrans:
+		return C.CblasConjTrans
+	}
+	panic("cblas: illegal transpose")
+}
+
+// symTranspose converts the transpose argument of a complex symmetric
+// rank k update, which may not be ConjTrans.
+func symTranspose(t gblas.Transpose) C.enum_CBLAS_TRANSPOSE {
+	if t == gblas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	return transpose(t)
+}
+
+// hermTranspose converts the transpose argument of a hermitian
+// rank k update, which may not be Trans.
+func hermTranspose(t gblas.Transpose) C.enum_CBLAS_TRANSPOSE {
+	if t == gblas.Trans {
+		panic("cblas: illegal transpose")
+	}
+	return transpose(t)
+}
+
+func uplo(ul gblas.Uplo) C.enum_CBLAS_UPLO {
+	switch ul {
+	case gblas.Upper:
+		return C.CblasUpper
+	case gblas.Lower:
+		return C.CblasLower
+	}
+	panic("cblas: illegal triangle")
+}
+
+func diag(d gblas.Diag) C.enum_CBLAS_DIAG {
+	switch d {
+	case gblas.NonUnit:
+		return C.CblasNonUnit
+	case gblas.Unit:
+		return C.CblasUnit
+	}
+	panic("cblas: illegal diagonal")
+}
+
+func side(s gblas.Side) C.enum_CBLAS_SIDE {
+	switch s {
+	case gblas.Left:
+		return C.CblasLeft
+	case gblas.Right:
+		return C.CblasRight
+	}
+	panic("cblas: illegal side")
+}
+
+func checkMN(m, n int) {
+	if m < 0 {
+		panic("cblas: m < 0")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+}
+
+func checkN(n int) {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+}
+
+func checkK(k int) {
+	if k < 0 {
+		panic("cblas: k < 0")
+	}
+}
+
+// checkVector checks a vector of n elements with increment inc
+// held in a slice of length l.
+func checkVector(n, l, inc int) {
+	checkN(n)
+	if inc == 0 {
+		panic("cblas: zero increment")
+	}
+	if inc < 0 {
+		inc = -inc
+	}
+	if n > 0 && (n-1)*inc >= l {
+		panic("cblas: index out of range")
+	}
+}
+
+// checkReduction checks the vector of a routine that does nothing
+// for a negative increment, and returns whether inc is positive.
+func checkReduction(n, l, inc int) bool {
+	checkN(n)
+	if inc < 0 {
+		return false
+	}
+	checkVector(n, l, inc)
+	return true
+}
+
+// checkMatrix checks an r×c row-major matrix with leading dimension ld
+// held in a slice of length l.
+func checkMatrix(r, c, l, ld int) {
+	if ld < max(1, c) {
+		panic("cblas: index out of range")
+	}
+	if r > 0 && c > 0 && (r-1)*ld+c > l {
+		panic("cblas: index out of range")
+	}
+}
+
+// checkBand checks an m×n row-major band matrix with kL sub-diagonals,
+// kU super-diagonals and leading dimension ld held in a slice of length l.
+func checkBand(m, n, kL, kU, l, ld int) {
+	if kL < 0 {
+		panic("cblas: kL < 0")
+	}
+	if kU < 0 {
+		panic("cblas: kU < 0")
+	}
+	if ld < kL+kU+1 {
+		panic("cblas: index out of range")
+	}
+	if m > 0 && n > 0 && ld*(min(m, n+kL)-1)+kL+kU+1 > l {
+		panic("cblas: index out of range")
+	}
+}
+
+// checkPacked checks a packed n×n triangle held in a slice of length l.
+func checkPacked(n, l int) {
+	if n*(n+1)/2 > l {
+		panic("cblas: index out of range")
+	}
+}
+
+func checkFlag(f gblas.Flag) {
+	if f < gblas.Identity || f > gblas.Diagonal {
+		panic("cblas: illegal flag")
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Float32 routines.
+
+func (Implementation) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float32(C.cblas_sdsdot(C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY)))
+}
+func (Implementation) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float64(C.cblas_dsdot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY)))
+}
+func (Implementation) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float32(C.cblas_sdot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY)))
+}
+func (Implementation) Snrm2(n int, x []float32, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	if safeNrm2() {
+		return snrm2(n, x, incX)
+	}
+	return float32(C.cblas_snrm2(C.int(n), f32(x), C.int(incX)))
+}
+func (Implementation) Sasum(n int, x []float32, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	return float32(C.cblas_sasum(C.int(n), f32(x), C.int(incX)))
+}
+func (Implementation) Isamax(n int, x []float32, incX int) int {
+	if !checkReduction(n, len(x), incX) || n == 0 {
+		return -1
+	}
+	return int(C.cblas_isamax(C.int(n), f32(x), C.int(incX)))
+}
+func (Implementation) Sswap(n int, x []float32, incX int, y []float32, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_sswap(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY))
+}
+func (Implementation) Scopy(n int, x []float32, incX int, y []float32, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_scopy(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY))
+}
+func (Implementation) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_saxpy(C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY))
+}
+func (Implementation) Srotg(a, b float32) (c, s, r, z float32) {
+	C.cblas_srotg((*C.float)(&a), (*C.float)(&b), (*C.float)(&c), (*C.float)(&s))
+	return c, s, a, b
+}
+func (Implementation) Srotmg(d1, d2, b1, b2 float32) (p gblas.SrotmParams, rd1, rd2, rb1 float32) {
+	var param [5]float32
+	C.cblas_srotmg((*C.float)(&d1), (*C.float)(&d2), (*C.float)(&b1), C.float(b2), (*C.float)(&param[0]))
+	p.Flag = gblas.Flag(param[0])
+	copy(p.H[:], param[1:])
+	return p, d1, d2, b1
+}
+func (Implementation) Srot(n int, x []float32, incX int, y []float32, incY int, c, s float32) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_srot(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY), C.float(c), C.float(s))
+}
+func (Implementation) Srotm(n int, x []float32, incX int, y []float32, incY int, p gblas.SrotmParams) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkFlag(p.Flag)
+	param := [5]float32{float32(p.Flag), p.H[0], p.H[1], p.H[2], p.H[3]}
+	C.cblas_srotm(C.int(n), f32(x), C.int(incX), f32(y), C.int(incY), (*C.float)(&param[0]))
+}
+func (Implementation) Sscal(n int, alpha float32, x []float32, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_sscal(C.int(n), C.float(alpha), f32(x), C.int(incX))
+}
+
+func (Implementation) Sgemv(tA gblas.Transpose, m, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkMatrix(m, n, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_sgemv(C.CblasRowMajor, t, C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
+}
+func (Implementation) Sgbmv(tA gblas.Transpose, m, n, kL, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkBand(m, n, kL, kU, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_sgbmv(C.CblasRowMajor, t, C.int(m), C.int(n), C.int(kL), C.int(kU), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
+}
+
+func (Implementation) Ssymv(ul gblas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_ssymv(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
+}
+func (Implementation) Ssbmv(ul gblas.Uplo, n, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_ssbmv(C.CblasRowMajor, u, C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
+}
+func (Implementation) Sspmv(ul gblas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_sspmv(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(ap), f32(x), C.int(incX), C.float(beta), f32(y), C.int(incY))
+}
+
+func (Implementation) Sger(m, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_sger(C.CblasRowMajor, C.int(m), C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(a), C.int(lda))
+}
+func (Implementation) Ssyr(ul gblas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ssyr(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(x), C.int(incX), f32(a), C.int(lda))
+}
+func (Implementation) Sspr(ul gblas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_sspr(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(x), C.int(incX), f32(ap))
+}
+func (Implementation) Ssyr2(ul gblas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_ssyr2(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(a), C.int(lda))
+}
+func (Implementation) Sspr2(ul gblas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, ap []float32) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_sspr2(C.CblasRowMajor, u, C.int(n), C.float(alpha), f32(x), C.int(incX), f32(y), C.int(incY), f32(ap))
+}
+
+func (Implementation) Strmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_strmv(C.CblasRowMajor, u, t, dg, C.int(n), f32(a), C.int(lda), f32(x), C.int(incX))
+}
+func (Implementation) Stbmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []float32, lda int, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_stbmv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), f32(a), C.int(lda), f32(x), C.int(incX))
+}
+func (Implementation) Stpmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []float32, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_stpmv(C.CblasRowMajor, u, t, dg, C.int(n), f32(ap), f32(x), C.int(incX))
+}
+func (Implementation) Strsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_strsv(C.CblasRowMajor, u, t, dg, C.int(n), f32(a), C.int(lda), f32(x), C.int(incX))
+}
+func (Implementation) Stbsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []float32, lda int, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_stbsv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), f32(a), C.int(lda), f32(x), C.int(incX))
+}
+func (Implementation) Stpsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []float32, x []float32, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_stpsv(C.CblasRowMajor, u, t, dg, C.int(n), f32(ap), f32(x), C.int(incX))
+}
+
+func (Implementation) Sgemm(tA, tB gblas.Transpose, m, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	ta, tb := transpose(tA), transpose(tB)
+	checkMN(m, n)
+	checkK(k)
+	rowA, colA := m, k
+	if tA != gblas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != gblas.NoTrans {
+		rowB, colB = n, k
+	}
+	checkMatrix(rowA, colA, len(a), lda)
+	checkMatrix(rowB, colB, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_sgemm(C.CblasRowMajor, ta, tb, C.int(m), C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
+}
+
+func (Implementation) Ssymm(s gblas.Side, ul gblas.Uplo, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_ssymm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
+}
+func (Implementation) Ssyrk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+	u, tr := uplo(ul), transpose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_ssyrk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), C.float(beta), f32(c), C.int(ldc))
+}
+func (Implementation) Ssyr2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	u, tr := uplo(ul), transpose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_ssyr2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb), C.float(beta), f32(c), C.int(ldc))
+}
+func (Implementation) Strmm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_strmm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb))
+}
+func (Implementation) Strsm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_strsm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), C.float(alpha), f32(a), C.int(lda), f32(b), C.int(ldb))
+}
+
+// Float64 routines.
+
+func (Implementation) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	return float64(C.cblas_ddot(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY)))
+}
+func (Implementation) Dnrm2(n int, x []float64, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	if safeNrm2() {
+		return dnrm2(n, x, incX)
+	}
+	return float64(C.cblas_dnrm2(C.int(n), f64(x), C.int(incX)))
+}
+func (Implementation) Dasum(n int, x []float64, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	return float64(C.cblas_dasum(C.int(n), f64(x), C.int(incX)))
+}
+func (Implementation) Idamax(n int, x []float64, incX int) int {
+	if !checkReduction(n, len(x), incX) || n == 0 {
+		return -1
+	}
+	return int(C.cblas_idamax(C.int(n), f64(x), C.int(incX)))
+}
+func (Implementation) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dswap(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY))
+}
+func (Implementation) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dcopy(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY))
+}
+func (Implementation) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_daxpy(C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY))
+}
+func (Implementation) Drotg(a, b float64) (c, s, r, z float64) {
+	C.cblas_drotg((*C.double)(&a), (*C.double)(&b), (*C.double)(&c), (*C.double)(&s))
+	return c, s, a, b
+}
+func (Implementation) Drotmg(d1, d2, b1, b2 float64) (p gblas.DrotmParams, rd1, rd2, rb1 float64) {
+	var param [5]float64
+	C.cblas_drotmg((*C.double)(&d1), (*C.double)(&d2), (*C.double)(&b1), C.double(b2), (*C.double)(&param[0]))
+	p.Flag = gblas.Flag(param[0])
+	copy(p.H[:], param[1:])
+	return p, d1, d2, b1
+}
+func (Implementation) Drot(n int, x []float64, incX int, y []float64, incY int, c, s float64) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_drot(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY), C.double(c), C.double(s))
+}
+func (Implementation) Drotm(n int, x []float64, incX int, y []float64, incY int, p gblas.DrotmParams) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkFlag(p.Flag)
+	param := [5]float64{float64(p.Flag), p.H[0], p.H[1], p.H[2], p.H[3]}
+	C.cblas_drotm(C.int(n), f64(x), C.int(incX), f64(y), C.int(incY), (*C.double)(&param[0]))
+}
+func (Implementation) Dscal(n int, alpha float64, x []float64, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_dscal(C.int(n), C.double(alpha), f64(x), C.int(incX))
+}
+
+func (Implementation) Dgemv(tA gblas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkMatrix(m, n, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_dgemv(C.CblasRowMajor, t, C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
+}
+func (Implementation) Dgbmv(tA gblas.Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkBand(m, n, kL, kU, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_dgbmv(C.CblasRowMajor, t, C.int(m), C.int(n), C.int(kL), C.int(kU), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
+}
+
+func (Implementation) Dsymv(ul gblas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dsymv(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
+}
+func (Implementation) Dsbmv(ul gblas.Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dsbmv(C.CblasRowMajor, u, C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
+}
+func (Implementation) Dspmv(ul gblas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_dspmv(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(ap), f64(x), C.int(incX), C.double(beta), f64(y), C.int(incY))
+}
+
+func (Implementation) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_dger(C.CblasRowMajor, C.int(m), C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(a), C.int(lda))
+}
+func (Implementation) Dsyr(ul gblas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dsyr(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(x), C.int(incX), f64(a), C.int(lda))
+}
+func (Implementation) Dspr(ul gblas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_dspr(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(x), C.int(incX), f64(ap))
+}
+func (Implementation) Dsyr2(ul gblas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_dsyr2(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(a), C.int(lda))
+}
+func (Implementation) Dspr2(ul gblas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, ap []float64) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_dspr2(C.CblasRowMajor, u, C.int(n), C.double(alpha), f64(x), C.int(incX), f64(y), C.int(incY), f64(ap))
+}
+
+func (Implementation) Dtrmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_dtrmv(C.CblasRowMajor, u, t, dg, C.int(n), f64(a), C.int(lda), f64(x), C.int(incX))
+}
+func (Implementation) Dtbmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_dtbmv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), f64(a), C.int(lda), f64(x), C.int(incX))
+}
+func (Implementation) Dtpmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []float64, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_dtpmv(C.CblasRowMajor, u, t, dg, C.int(n), f64(ap), f64(x), C.int(incX))
+}
+func (Implementation) Dtrsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_dtrsv(C.CblasRowMajor, u, t, dg, C.int(n), f64(a), C.int(lda), f64(x), C.int(incX))
+}
+func (Implementation) Dtbsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_dtbsv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), f64(a), C.int(lda), f64(x), C.int(incX))
+}
+func (Implementation) Dtpsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []float64, x []float64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_dtpsv(C.CblasRowMajor, u, t, dg, C.int(n), f64(ap), f64(x), C.int(incX))
+}
+
+func (Implementation) Dgemm(tA, tB gblas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	ta, tb := transpose(tA), transpose(tB)
+	checkMN(m, n)
+	checkK(k)
+	rowA, colA := m, k
+	if tA != gblas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != gblas.NoTrans {
+		rowB, colB = n, k
+	}
+	checkMatrix(rowA, colA, len(a), lda)
+	checkMatrix(rowB, colB, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_dgemm(C.CblasRowMajor, ta, tb, C.int(m), C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
+}
+
+func (Implementation) Dsymm(s gblas.Side, ul gblas.Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_dsymm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
+}
+func (Implementation) Dsyrk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	u, tr := uplo(ul), transpose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_dsyrk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), C.double(beta), f64(c), C.int(ldc))
+}
+func (Implementation) Dsyr2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	u, tr := uplo(ul), transpose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_dsyr2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb), C.double(beta), f64(c), C.int(ldc))
+}
+func (Implementation) Dtrmm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_dtrmm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb))
+}
+func (Implementation) Dtrsm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_dtrsm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), C.double(alpha), f64(a), C.int(lda), f64(b), C.int(ldb))
+}
+
+// Complex64 routines.
+
+func (Implementation) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotu_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotu))
+	return dotu
+}
+func (Implementation) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cdotc_sub(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY), unsafe.Pointer(&dotc))
+	return dotc
+}
+func (Implementation) Scnrm2(n int, x []complex64, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	if safeNrm2() {
+		return scnrm2(n, x, incX)
+	}
+	return float32(C.cblas_scnrm2(C.int(n), c64(x), C.int(incX)))
+}
+func (Implementation) Scasum(n int, x []complex64, incX int) float32 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	return float32(C.cblas_scasum(C.int(n), c64(x), C.int(incX)))
+}
+func (Implementation) Icamax(n int, x []complex64, incX int) int {
+	if !checkReduction(n, len(x), incX) || n == 0 {
+		return -1
+	}
+	return int(C.cblas_icamax(C.int(n), c64(x), C.int(incX)))
+}
+func (Implementation) Cswap(n int, x []complex64, incX int, y []complex64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_cswap(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY))
+}
+func (Implementation) Ccopy(n int, x []complex64, incX int, y []complex64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_ccopy(C.int(n), c64(x), C.int(incX), c64(y), C.int(incY))
+}
+func (Implementation) Caxpy(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_caxpy(C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY))
+}
+func (Implementation) Cscal(n int, alpha complex64, x []complex64, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_cscal(C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX))
+}
+func (Implementation) Csscal(n int, alpha float32, x []complex64, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_csscal(C.int(n), C.float(alpha), c64(x), C.int(incX))
+}
+
+func (Implementation) Cgemv(tA gblas.Transpose, m, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkMatrix(m, n, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_cgemv(C.CblasRowMajor, t, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
+}
+func (Implementation) Cgbmv(tA gblas.Transpose, m, n, kL, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkBand(m, n, kL, kU, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_cgbmv(C.CblasRowMajor, t, C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
+}
+func (Implementation) Chemv(ul gblas.Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_chemv(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
+}
+func (Implementation) Chbmv(ul gblas.Uplo, n, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_chbmv(C.CblasRowMajor, u, C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
+}
+func (Implementation) Chpmv(ul gblas.Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_chpmv(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c64(ap), c64(x), C.int(incX), unsafe.Pointer(&beta), c64(y), C.int(incY))
+}
+func (Implementation) Cgeru(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_cgeru(C.CblasRowMajor, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
+}
+func (Implementation) Cgerc(m, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_cgerc(C.CblasRowMajor, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
+}
+func (Implementation) Cher(ul gblas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_cher(C.CblasRowMajor, u, C.int(n), C.float(alpha), c64(x), C.int(incX), c64(a), C.int(lda))
+}
+func (Implementation) Chpr(ul gblas.Uplo, n int, alpha float32, x []complex64, incX int, ap []complex64) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_chpr(C.CblasRowMajor, u, C.int(n), C.float(alpha), c64(x), C.int(incX), c64(ap))
+}
+func (Implementation) Cher2(ul gblas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_cher2(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(a), C.int(lda))
+}
+func (Implementation) Chpr2(ul gblas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_chpr2(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c64(x), C.int(incX), c64(y), C.int(incY), c64(ap))
+}
+
+func (Implementation) Ctrmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ctrmv(C.CblasRowMajor, u, t, dg, C.int(n), c64(a), C.int(lda), c64(x), C.int(incX))
+}
+func (Implementation) Ctbmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []complex64, lda int, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ctbmv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), c64(a), C.int(lda), c64(x), C.int(incX))
+}
+func (Implementation) Ctpmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_ctpmv(C.CblasRowMajor, u, t, dg, C.int(n), c64(ap), c64(x), C.int(incX))
+}
+func (Implementation) Ctrsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ctrsv(C.CblasRowMajor, u, t, dg, C.int(n), c64(a), C.int(lda), c64(x), C.int(incX))
+}
+func (Implementation) Ctbsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []complex64, lda int, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ctbsv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), c64(a), C.int(lda), c64(x), C.int(incX))
+}
+func (Implementation) Ctpsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_ctpsv(C.CblasRowMajor, u, t, dg, C.int(n), c64(ap), c64(x), C.int(incX))
+}
+
+func (Implementation) Cgemm(tA, tB gblas.Transpose, m, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	ta, tb := transpose(tA), transpose(tB)
+	checkMN(m, n)
+	checkK(k)
+	rowA, colA := m, k
+	if tA != gblas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != gblas.NoTrans {
+		rowB, colB = n, k
+	}
+	checkMatrix(rowA, colA, len(a), lda)
+	checkMatrix(rowB, colB, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_cgemm(C.CblasRowMajor, ta, tb, C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
+}
+
+func (Implementation) Csymm(s gblas.Side, ul gblas.Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_csymm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
+}
+
+func (Implementation) Chemm(s gblas.Side, ul gblas.Uplo, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_chemm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
+}
+func (Implementation) Csyrk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) {
+	u, tr := uplo(ul), symTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_csyrk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), unsafe.Pointer(&beta), c64(c), C.int(ldc))
+}
+func (Implementation) Csyr2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	u, tr := uplo(ul), symTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_csyr2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), unsafe.Pointer(&beta), c64(c), C.int(ldc))
+}
+func (Implementation) Ctrmm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_ctrmm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb))
+}
+func (Implementation) Ctrsm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_ctrsm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb))
+}
+
+func (Implementation) Cherk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) {
+	u, tr := uplo(ul), hermTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_cherk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.float(alpha), c64(a), C.int(lda), C.float(beta), c64(c), C.int(ldc))
+}
+func (Implementation) Cher2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
+	u, tr := uplo(ul), hermTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_cher2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c64(a), C.int(lda), c64(b), C.int(ldb), C.float(beta), c64(c), C.int(ldc))
+}
+
+// Complex128 routines.
+
+func (Implementation) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotu_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotu))
+	return dotu
+}
+func (Implementation) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zdotc_sub(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY), unsafe.Pointer(&dotc))
+	return dotc
+}
+func (Implementation) Dznrm2(n int, x []complex128, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	if safeNrm2() {
+		return dznrm2(n, x, incX)
+	}
+	return float64(C.cblas_dznrm2(C.int(n), c128(x), C.int(incX)))
+}
+func (Implementation) Dzasum(n int, x []complex128, incX int) float64 {
+	if !checkReduction(n, len(x), incX) {
+		return 0
+	}
+	return float64(C.cblas_dzasum(C.int(n), c128(x), C.int(incX)))
+}
+func (Implementation) Izamax(n int, x []complex128, incX int) int {
+	if !checkReduction(n, len(x), incX) || n == 0 {
+		return -1
+	}
+	return int(C.cblas_izamax(C.int(n), c128(x), C.int(incX)))
+}
+func (Implementation) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zswap(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY))
+}
+func (Implementation) Zcopy(n int, x []complex128, incX int, y []complex128, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zcopy(C.int(n), c128(x), C.int(incX), c128(y), C.int(incY))
+}
+func (Implementation) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zaxpy(C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY))
+}
+func (Implementation) Zscal(n int, alpha complex128, x []complex128, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_zscal(C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX))
+}
+func (Implementation) Zdscal(n int, alpha float64, x []complex128, incX int) {
+	if !checkReduction(n, len(x), incX) {
+		return
+	}
+	if alpha == 0 {
+		for i := 0; i < n; i++ {
+			x[i*incX] = 0
+		}
+		return
+	}
+	C.cblas_zdscal(C.int(n), C.double(alpha), c128(x), C.int(incX))
+}
+
+func (Implementation) Zgemv(tA gblas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkMatrix(m, n, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_zgemv(C.CblasRowMajor, t, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
+}
+func (Implementation) Zgbmv(tA gblas.Transpose, m, n, kL, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	t := transpose(tA)
+	checkMN(m, n)
+	checkBand(m, n, kL, kU, len(a), lda)
+	lenX, lenY := n, m
+	if tA != gblas.NoTrans {
+		lenX, lenY = m, n
+	}
+	checkVector(lenX, len(x), incX)
+	checkVector(lenY, len(y), incY)
+	C.cblas_zgbmv(C.CblasRowMajor, t, C.int(m), C.int(n), C.int(kL), C.int(kU), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
+}
+func (Implementation) Zhemv(ul gblas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zhemv(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
+}
+func (Implementation) Zhbmv(ul gblas.Uplo, n, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zhbmv(C.CblasRowMajor, u, C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
+}
+func (Implementation) Zhpmv(ul gblas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	u := uplo(ul)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	C.cblas_zhpmv(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c128(ap), c128(x), C.int(incX), unsafe.Pointer(&beta), c128(y), C.int(incY))
+}
+func (Implementation) Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_zgeru(C.CblasRowMajor, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
+}
+func (Implementation) Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	checkMN(m, n)
+	checkVector(m, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(m, n, len(a), lda)
+	C.cblas_zgerc(C.CblasRowMajor, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
+}
+func (Implementation) Zher(ul gblas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_zher(C.CblasRowMajor, u, C.int(n), C.double(alpha), c128(x), C.int(incX), c128(a), C.int(lda))
+}
+func (Implementation) Zhpr(ul gblas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkPacked(n, len(ap))
+	C.cblas_zhpr(C.CblasRowMajor, u, C.int(n), C.double(alpha), c128(x), C.int(incX), c128(ap))
+}
+func (Implementation) Zher2(ul gblas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkMatrix(n, n, len(a), lda)
+	C.cblas_zher2(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(a), C.int(lda))
+}
+func (Implementation) Zhpr2(ul gblas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	u := uplo(ul)
+	checkN(n)
+	checkVector(n, len(x), incX)
+	checkVector(n, len(y), incY)
+	checkPacked(n, len(ap))
+	C.cblas_zhpr2(C.CblasRowMajor, u, C.int(n), unsafe.Pointer(&alpha), c128(x), C.int(incX), c128(y), C.int(incY), c128(ap))
+}
+
+func (Implementation) Ztrmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ztrmv(C.CblasRowMajor, u, t, dg, C.int(n), c128(a), C.int(lda), c128(x), C.int(incX))
+}
+func (Implementation) Ztbmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ztbmv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), c128(a), C.int(lda), c128(x), C.int(incX))
+}
+func (Implementation) Ztpmv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_ztpmv(C.CblasRowMajor, u, t, dg, C.int(n), c128(ap), c128(x), C.int(incX))
+}
+func (Implementation) Ztrsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkMatrix(n, n, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ztrsv(C.CblasRowMajor, u, t, dg, C.int(n), c128(a), C.int(lda), c128(x), C.int(incX))
+}
+func (Implementation) Ztbsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n, k int, a []complex128, lda int, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkK(k)
+	checkBand(n, n, 0, k, len(a), lda)
+	checkVector(n, len(x), incX)
+	C.cblas_ztbsv(C.CblasRowMajor, u, t, dg, C.int(n), C.int(k), c128(a), C.int(lda), c128(x), C.int(incX))
+}
+func (Implementation) Ztpsv(ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	u, t, dg := uplo(ul), transpose(tA), diag(d)
+	checkN(n)
+	checkPacked(n, len(ap))
+	checkVector(n, len(x), incX)
+	C.cblas_ztpsv(C.CblasRowMajor, u, t, dg, C.int(n), c128(ap), c128(x), C.int(incX))
+}
+
+func (Implementation) Zgemm(tA, tB gblas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	ta, tb := transpose(tA), transpose(tB)
+	checkMN(m, n)
+	checkK(k)
+	rowA, colA := m, k
+	if tA != gblas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != gblas.NoTrans {
+		rowB, colB = n, k
+	}
+	checkMatrix(rowA, colA, len(a), lda)
+	checkMatrix(rowB, colB, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_zgemm(C.CblasRowMajor, ta, tb, C.int(m), C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
+}
+
+func (Implementation) Zsymm(s gblas.Side, ul gblas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_zsymm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
+}
+
+func (Implementation) Zhemm(s gblas.Side, ul gblas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sd, u := side(s), uplo(ul)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	checkMatrix(m, n, len(c), ldc)
+	C.cblas_zhemm(C.CblasRowMajor, sd, u, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
+}
+func (Implementation) Zsyrk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	u, tr := uplo(ul), symTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zsyrk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), unsafe.Pointer(&beta), c128(c), C.int(ldc))
+}
+func (Implementation) Zsyr2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	u, tr := uplo(ul), symTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zsyr2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), unsafe.Pointer(&beta), c128(c), C.int(ldc))
+}
+func (Implementation) Ztrmm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_ztrmm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb))
+}
+func (Implementation) Ztrsm(s gblas.Side, ul gblas.Uplo, tA gblas.Transpose, d gblas.Diag, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	sd, u, t, dg := side(s), uplo(ul), transpose(tA), diag(d)
+	checkMN(m, n)
+	k := n
+	if s == gblas.Left {
+		k = m
+	}
+	checkMatrix(k, k, len(a), lda)
+	checkMatrix(m, n, len(b), ldb)
+	C.cblas_ztrsm(C.CblasRowMajor, sd, u, t, dg, C.int(m), C.int(n), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb))
+}
+
+func (Implementation) Zherk(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	u, tr := uplo(ul), hermTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zherk(C.CblasRowMajor, u, tr, C.int(n), C.int(k), C.double(alpha), c128(a), C.int(lda), C.double(beta), c128(c), C.int(ldc))
+}
+func (Implementation) Zher2k(ul gblas.Uplo, t gblas.Transpose, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	u, tr := uplo(ul), hermTranspose(t)
+	checkN(n)
+	checkK(k)
+	row, col := n, k
+	if t != gblas.NoTrans {
+		row, col = k, n
+	}
+	checkMatrix(row, col, len(a), lda)
+	checkMatrix(row, col, len(b), ldb)
+	checkMatrix(n, n, len(c), ldc)
+	C.cblas_zher2k(C.CblasRowMajor, u, tr, C.int(n), C.int(k), unsafe.Pointer(&alpha), c128(a), C.int(lda), c128(b), C.int(ldb), C.double(beta), c128(c), C.int(ldc))
+}
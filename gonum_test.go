@@ -0,0 +1,183 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"math"
+	"testing"
+
+	gblas "gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/gonum"
+	"gonum.org/v1/gonum/blas/testblas"
+	"gonum.org/v1/gonum/mat"
+)
+
+// TestImplementation runs the tests of the gonum BLAS on Implementation.
+func TestImplementation(t *testing.T) {
+	var impl Implementation
+	for _, test := range []struct {
+		name string
+		test func(*testing.T)
+	}{
+		{"Dasum", func(t *testing.T) { testblas.DasumTest(t, impl) }},
+		{"Daxpy", func(t *testing.T) { testblas.DaxpyTest(t, impl) }},
+		{"Ddot", func(t *testing.T) { testblas.DdotTest(t, impl) }},
+		{"Dnrm2", func(t *testing.T) { testblas.Dnrm2Test(t, impl) }},
+		{"Idamax", func(t *testing.T) { testblas.IdamaxTest(t, impl) }},
+		{"Dswap", func(t *testing.T) { testblas.DswapTest(t, impl) }},
+		{"Dcopy", func(t *testing.T) { testblas.DcopyTest(t, impl) }},
+		{"Drotg", func(t *testing.T) { testblas.DrotgTest(t, impl, false) }},
+		{"Drotmg", func(t *testing.T) { testblas.DrotmgTest(t, impl) }},
+		{"Drot", func(t *testing.T) { testblas.DrotTest(t, impl) }},
+		{"Drotm", func(t *testing.T) { testblas.DrotmTest(t, impl) }},
+		{"Dscal", func(t *testing.T) { testblas.DscalTest(t, impl) }},
+
+		{"Dgemv", func(t *testing.T) { testblas.DgemvTest(t, impl) }},
+		{"Dger", func(t *testing.T) { testblas.DgerTest(t, impl) }},
+		{"Dtxmv", func(t *testing.T) { testblas.DtxmvTest(t, impl) }},
+		{"Dgbmv", func(t *testing.T) { testblas.DgbmvTest(t, impl) }},
+		{"Dtbsv", func(t *testing.T) { testblas.DtbsvTest(t, impl) }},
+		{"Dsbmv", func(t *testing.T) { testblas.DsbmvTest(t, impl) }},
+		{"Dtbmv", func(t *testing.T) { testblas.DtbmvTest(t, impl) }},
+		{"Dtrsv", func(t *testing.T) { testblas.DtrsvTest(t, impl) }},
+		{"Dtrmv", func(t *testing.T) { testblas.DtrmvTest(t, impl) }},
+		{"Dsymv", func(t *testing.T) { testblas.DsymvTest(t, impl) }},
+		{"Dsyr", func(t *testing.T) { testblas.DsyrTest(t, impl) }},
+		{"Dsyr2", func(t *testing.T) { testblas.Dsyr2Test(t, impl) }},
+		{"Dspr2", func(t *testing.T) { testblas.Dspr2Test(t, impl) }},
+		{"Dspr", func(t *testing.T) { testblas.DsprTest(t, impl) }},
+		{"Dspmv", func(t *testing.T) { testblas.DspmvTest(t, impl) }},
+		{"Dtpsv", func(t *testing.T) { testblas.DtpsvTest(t, impl) }},
+		{"Dtpmv", func(t *testing.T) { testblas.DtpmvTest(t, impl) }},
+
+		{"Dgemm", func(t *testing.T) { testblas.TestDgemm(t, impl) }},
+		{"Dsymm", func(t *testing.T) { testblas.DsymmTest(t, impl) }},
+		{"Dtrsm", func(t *testing.T) { testblas.DtrsmTest(t, impl) }},
+		{"Dsyrk", func(t *testing.T) { testblas.DsyrkTest(t, impl) }},
+		{"Dsyr2k", func(t *testing.T) { testblas.Dsyr2kTest(t, impl) }},
+		{"Dtrmm", func(t *testing.T) { testblas.DtrmmTest(t, impl) }},
+
+		{"Dzasum", func(t *testing.T) { testblas.DzasumTest(t, impl) }},
+		{"Dznrm2", func(t *testing.T) { testblas.Dznrm2Test(t, impl) }},
+		{"Izamax", func(t *testing.T) { testblas.IzamaxTest(t, impl) }},
+		{"Zaxpy", func(t *testing.T) { testblas.ZaxpyTest(t, impl) }},
+		{"Zcopy", func(t *testing.T) { testblas.ZcopyTest(t, impl) }},
+		{"Zdotc", func(t *testing.T) { testblas.ZdotcTest(t, impl) }},
+		{"Zdotu", func(t *testing.T) { testblas.ZdotuTest(t, impl) }},
+		{"Zdscal", func(t *testing.T) { testblas.ZdscalTest(t, impl) }},
+		{"Zscal", func(t *testing.T) { testblas.ZscalTest(t, impl) }},
+		{"Zswap", func(t *testing.T) { testblas.ZswapTest(t, impl) }},
+
+		{"Zgbmv", func(t *testing.T) { testblas.ZgbmvTest(t, impl) }},
+		{"Zgemv", func(t *testing.T) { testblas.ZgemvTest(t, impl) }},
+		{"Zgerc", func(t *testing.T) { testblas.ZgercTest(t, impl) }},
+		{"Zgeru", func(t *testing.T) { testblas.ZgeruTest(t, impl) }},
+		{"Zhbmv", func(t *testing.T) { testblas.ZhbmvTest(t, impl) }},
+		{"Zhemv", func(t *testing.T) { testblas.ZhemvTest(t, impl) }},
+		{"Zher", func(t *testing.T) { testblas.ZherTest(t, impl) }},
+		{"Zher2", func(t *testing.T) { testblas.Zher2Test(t, impl) }},
+		{"Zhpmv", func(t *testing.T) { testblas.ZhpmvTest(t, impl) }},
+		{"Zhpr", func(t *testing.T) { testblas.ZhprTest(t, impl) }},
+		{"Zhpr2", func(t *testing.T) { testblas.Zhpr2Test(t, impl) }},
+		{"Ztbmv", func(t *testing.T) { testblas.ZtbmvTest(t, impl) }},
+		{"Ztbsv", func(t *testing.T) { testblas.ZtbsvTest(t, impl) }},
+		{"Ztpmv", func(t *testing.T) { testblas.ZtpmvTest(t, impl) }},
+		{"Ztpsv", func(t *testing.T) { testblas.ZtpsvTest(t, impl) }},
+		{"Ztrmv", func(t *testing.T) { testblas.ZtrmvTest(t, impl) }},
+		{"Ztrsv", func(t *testing.T) { testblas.ZtrsvTest(t, impl) }},
+
+		{"Zgemm", func(t *testing.T) { testblas.ZgemmTest(t, impl) }},
+		{"Zhemm", func(t *testing.T) { testblas.ZhemmTest(t, impl) }},
+		{"Zherk", func(t *testing.T) { testblas.ZherkTest(t, impl) }},
+		{"Zher2k", func(t *testing.T) { testblas.Zher2kTest(t, impl) }},
+		{"Zsymm", func(t *testing.T) { testblas.ZsymmTest(t, impl) }},
+		{"Zsyrk", func(t *testing.T) { testblas.ZsyrkTest(t, impl) }},
+		{"Zsyr2k", func(t *testing.T) { testblas.Zsyr2kTest(t, impl) }},
+		{"Ztrmm", func(t *testing.T) { testblas.ZtrmmTest(t, impl) }},
+		{"Ztrsm", func(t *testing.T) { testblas.ZtrsmTest(t, impl) }},
+	} {
+		t.Run(test.name, test.test)
+	}
+}
+
+// TestImplementationRemap tests the results that Implementation maps
+// from the CBLAS conventions to those of the gonum BLAS for the single
+// precision routines, which the gonum tests do not cover.
+func TestImplementationRemap(t *testing.T) {
+	var impl Implementation
+	var ref gonum.Implementation
+	for _, v := range [][4]float32{
+		{1, 1, 1, 0}, {1, 1, 0.5, 2}, {2, 1, 3, 1}, {1, 2, 1, 3}, {-1, 1, 1, 1}, {4096, 1e-8, 1, 1},
+	} {
+		p, d1, d2, b1 := impl.Srotmg(v[0], v[1], v[2], v[3])
+		wp, wd1, wd2, wb1 := ref.Srotmg(v[0], v[1], v[2], v[3])
+		if p.Flag != wp.Flag || !sameFloat32s(p.H[:], wp.H[:]) || !sameFloat32s([]float32{d1, d2, b1}, []float32{wd1, wd2, wb1}) {
+			t.Errorf("Srotmg%v: got %v %v %v %v want %v %v %v %v", v, p, d1, d2, b1, wp, wd1, wd2, wb1)
+		}
+	}
+
+	x := []float32{1, -3, 2}
+	for _, test := range []struct {
+		n, inc, want int
+	}{
+		{3, 1, 1},
+		{2, 2, 1},
+		{0, 1, -1},
+		{3, -1, -1},
+	} {
+		if got := impl.Isamax(test.n, x, test.inc); got != test.want {
+			t.Errorf("Isamax(%d, x, %d): got %d want %d", test.n, test.inc, got, test.want)
+		}
+	}
+
+	p := gblas.SrotmParams{Flag: gblas.Rescaling, H: [4]float32{1, 2, 3, 4}}
+	y := []float32{1, 1}
+	x = []float32{1, 2}
+	impl.Srotm(2, x, 1, y, 1, p)
+	if want := []float32{4, 5}; !sameFloat32s(x, want) {
+		t.Errorf("Srotm x: got %v want %v", x, want)
+	}
+	if want := []float32{6, 8}; !sameFloat32s(y, want) {
+		t.Errorf("Srotm y: got %v want %v", y, want)
+	}
+}
+
+// TestImplementationMat tests Implementation as the BLAS of the gonum
+// matrix package against the gonum BLAS.
+func TestImplementationMat(t *testing.T) {
+	a := mat.NewDense(3, 4, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	b := mat.NewDense(4, 2, []float64{1, -1, 2, 0, 0, 3, -2, 1})
+	x := mat.NewVecDense(3, []float64{1, 0, -1})
+	compute := func() (c *mat.Dense, v *mat.VecDense, norm float64) {
+		c, v = &mat.Dense{}, &mat.VecDense{}
+		c.Mul(a, b)
+		v.MulVec(a.T(), x)
+		return c, v, mat.Norm(a, 2)
+	}
+	wantC, wantV, wantNorm := compute()
+
+	blas64.Use(Implementation{})
+	defer blas64.Use(gonum.Implementation{})
+	c, v, norm := compute()
+	if !mat.EqualApprox(c, wantC, 1e-14) {
+		t.Errorf("unexpected product: got %v want %v", mat.Formatted(c), mat.Formatted(wantC))
+	}
+	if !mat.EqualApprox(v, wantV, 1e-14) {
+		t.Errorf("unexpected vector: got %v want %v", mat.Formatted(v), mat.Formatted(wantV))
+	}
+	if math.Abs(norm-wantNorm) > 1e-12*wantNorm {
+		t.Errorf("unexpected norm: got %v want %v", norm, wantNorm)
+	}
+}
+
+func sameFloat32s(a, b []float32) bool {
+	for i := range a {
+		if a[i] != b[i] && !(math.IsNaN(float64(a[i])) && math.IsNaN(float64(b[i]))) {
+			return false
+		}
+	}
+	return true
+}
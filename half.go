@@ -0,0 +1,286 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include <stdint.h>
+
+// The half precision GEMM extensions of OpenBLAS, with 32 and 64-bit
+// integers.
+typedef void (*hgemm32)(int, int, int, int32_t, int32_t, int32_t, float, const uint16_t *, int32_t, const uint16_t *, int32_t, float, float *, int32_t);
+typedef void (*hgemm64)(int, int, int, int64_t, int64_t, int64_t, float, const uint16_t *, int64_t, const uint16_t *, int64_t, float, float *, int64_t);
+
+static void call_hgemm(void *f, int ilp64, int o, int tA, int tB, int64_t m, int64_t n, int64_t k, float alpha, const uint16_t *a, int64_t lda, const uint16_t *b, int64_t ldb, float beta, float *c, int64_t ldc) {
+	if (ilp64) {
+		((hgemm64)f)(o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc);
+	} else {
+		((hgemm32)f)(o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc);
+	}
+}
+*/
+import "C"
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+
+	"github.com/gonum/blas"
+)
+
+// Float16 is an IEEE 754 binary16 half precision floating point number.
+type Float16 uint16
+
+// BFloat16 is a bfloat16 floating point number, the upper half of an IEEE
+// 754 binary32 number.
+type BFloat16 uint16
+
+// Float16FromFloat32 returns f rounded to the nearest Float16, with ties
+// rounded to even. Values too large for a Float16 become infinite.
+func Float16FromFloat32(f float32) Float16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int(b >> 23 & 0xff)
+	mant := b & 0x7fffff
+	if exp == 0xff {
+		if mant != 0 {
+			// Quiet the NaN and keep the top of its payload.
+			return Float16(sign | 0x7e00 | uint16(mant>>13))
+		}
+		return Float16(sign | 0x7c00)
+	}
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f:
+		return Float16(sign | 0x7c00)
+	case e < -10:
+		return Float16(sign)
+	case e <= 0:
+		// The result is subnormal, in units of 2^-24.
+		m := mant | 0x800000
+		shift := uint(14 - e)
+		h := m >> shift
+		rem, half := m&(1<<shift-1), uint32(1)<<(shift-1)
+		if rem > half || rem == half && h&1 == 1 {
+			h++
+		}
+		return Float16(sign | uint16(h))
+	}
+	h := uint32(e)<<10 | mant>>13
+	rem := mant & 0x1fff
+	if rem > 0x1000 || rem == 0x1000 && h&1 == 1 {
+		// A carry into the exponent gives the next power of two,
+		// or infinity.
+		h++
+	}
+	return Float16(sign | uint16(h))
+}
+
+// Float32 returns h as a float32, which is exact.
+func (h Float16) Float32() float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h) & 0x3ff
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0:
+		f := float32(mant) * 0x1p-24
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// BFloat16FromFloat32 returns f rounded to the nearest BFloat16, with ties
+// rounded to even.
+func BFloat16FromFloat32(f float32) BFloat16 {
+	b := math.Float32bits(f)
+	if b&0x7fffffff > 0x7f800000 {
+		return BFloat16(b>>16 | 0x40)
+	}
+	b += 0x7fff + (b >> 16 & 1)
+	return BFloat16(b >> 16)
+}
+
+// Float32 returns h as a float32, which is exact.
+func (h BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(h) << 16)
+}
+
+// ToFloat16 rounds the elements of src to dst, which must have the same
+// length.
+func ToFloat16(dst []Float16, src []float32) {
+	if len(dst) != len(src) {
+		panic("cblas: length mismatch")
+	}
+	for i, f := range src {
+		dst[i] = Float16FromFloat32(f)
+	}
+}
+
+// FromFloat16 converts the elements of src to dst, which must have the
+// same length.
+func FromFloat16(dst []float32, src []Float16) {
+	if len(dst) != len(src) {
+		panic("cblas: length mismatch")
+	}
+	for i, h := range src {
+		dst[i] = h.Float32()
+	}
+}
+
+// ToBFloat16 rounds the elements of src to dst, which must have the same
+// length.
+func ToBFloat16(dst []BFloat16, src []float32) {
+	if len(dst) != len(src) {
+		panic("cblas: length mismatch")
+	}
+	for i, f := range src {
+		dst[i] = BFloat16FromFloat32(f)
+	}
+}
+
+// FromBFloat16 converts the elements of src to dst, which must have the
+// same length.
+func FromBFloat16(dst []float32, src []BFloat16) {
+	if len(dst) != len(src) {
+		panic("cblas: length mismatch")
+	}
+	for i, h := range src {
+		dst[i] = h.Float32()
+	}
+}
+
+var (
+	hgemmOnce sync.Once
+	sbgemm    unsafe.Pointer // The cblas_sbgemm function of the library, or nil.
+	shgemm    unsafe.Pointer // The cblas_shgemm function of the library, or nil.
+	hgemmInt  C.int          // Whether the library uses 64-bit integers.
+)
+
+func findHgemm() {
+	sbgemm = symbol("cblas_sbgemm")
+	shgemm = symbol("cblas_shgemm")
+	if sbgemm != nil || shgemm != nil {
+		if Info().IntSize == 64 {
+			hgemmInt = 1
+		}
+	}
+}
+
+// SHgemm performs
+//
+//	C = alpha * op(A) * op(B) + beta * C
+//
+// where A and B are half precision and C is single precision. It calls the
+// cblas_shgemm extension of the library if it is available, and otherwise
+// converts tiles of A and B to single precision and multiplies them with
+// Sgemm. The arguments are checked as for Sgemm.
+func (impl Blas) SHgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []Float16, lda int, b []Float16, ldb int, beta float32, c []float32, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	hgemmOnce.Do(findHgemm)
+	if shgemm != nil && m != 0 && n != 0 && k != 0 {
+		C.call_hgemm(shgemm, hgemmInt, C.int(o), C.int(tA), C.int(tB), C.int64_t(m), C.int64_t(n), C.int64_t(k),
+			C.float(alpha), (*C.uint16_t)(&a[0]), C.int64_t(lda), (*C.uint16_t)(&b[0]), C.int64_t(ldb),
+			C.float(beta), (*C.float)(&c[0]), C.int64_t(ldc))
+		return
+	}
+	halfGemm(impl, o, m, n, k, alpha, beta, c, ldc,
+		halfPack(o, tA, lda, func(i int) float32 { return a[i].Float32() }),
+		halfPack(o, tB, ldb, func(i int) float32 { return b[i].Float32() }),
+	)
+}
+
+// SBgemm performs
+//
+//	C = alpha * op(A) * op(B) + beta * C
+//
+// where A and B are bfloat16 and C is single precision. It calls the
+// cblas_sbgemm extension of the library if it is available, and otherwise
+// converts tiles of A and B to single precision and multiplies them with
+// Sgemm. The arguments are checked as for Sgemm.
+func (impl Blas) SBgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []BFloat16, lda int, b []BFloat16, ldb int, beta float32, c []float32, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	hgemmOnce.Do(findHgemm)
+	if sbgemm != nil && m != 0 && n != 0 && k != 0 {
+		C.call_hgemm(sbgemm, hgemmInt, C.int(o), C.int(tA), C.int(tB), C.int64_t(m), C.int64_t(n), C.int64_t(k),
+			C.float(alpha), (*C.uint16_t)(&a[0]), C.int64_t(lda), (*C.uint16_t)(&b[0]), C.int64_t(ldb),
+			C.float(beta), (*C.float)(&c[0]), C.int64_t(ldc))
+		return
+	}
+	halfGemm(impl, o, m, n, k, alpha, beta, c, ldc,
+		halfPack(o, tA, lda, func(i int) float32 { return a[i].Float32() }),
+		halfPack(o, tB, ldb, func(i int) float32 { return b[i].Float32() }),
+	)
+}
+
+// halfPack returns a function packing a tile of op(X), for the matrix X
+// in the order o with leading dimension ld whose elements are converted
+// to single precision by elem, into a row-major buffer for halfGemm.
+func halfPack(o blas.Order, t blas.Transpose, ld int, elem func(i int) float32) func(dst []float32, r0, c0, rows, cols int) {
+	rs, cs := strides(o, t, ld)
+	return func(dst []float32, r0, c0, rows, cols int) {
+		for i := 0; i < rows; i++ {
+			d := dst[i*cols : (i+1)*cols]
+			off := (r0+i)*rs + c0*cs
+			for j := range d {
+				d[j] = elem(off + j*cs)
+			}
+		}
+	}
+}
+
+// halfGemm multiplies tiles of op(A) and op(B), converted to row-major
+// single precision by packA and packB, with Sgemm.
+func halfGemm(impl Blas, o blas.Order, m, n, k int, alpha, beta float32, c []float32, ldc int,
+	packA, packB func(dst []float32, r0, c0, rows, cols int)) {
+	if m == 0 || n == 0 {
+		return
+	}
+	bs := tileSize(&gemmTile)
+	ta := make([]float32, bs*bs)
+	tb := make([]float32, bs*bs)
+	tc := make([]float32, bs*bs)
+	rs, cs := strides(o, blas.NoTrans, ldc)
+	for i0 := 0; i0 < m; i0 += bs {
+		mb := min(bs, m-i0)
+		for j0 := 0; j0 < n; j0 += bs {
+			nb := min(bs, n-j0)
+			t := tc[:mb*nb]
+			for i := 0; i < mb; i++ {
+				off := (i0+i)*rs + j0*cs
+				for j := 0; j < nb; j++ {
+					switch {
+					case k != 0:
+						t[i*nb+j] = c[off+j*cs]
+					case beta == 0:
+						t[i*nb+j] = 0
+					default:
+						t[i*nb+j] = beta * c[off+j*cs]
+					}
+				}
+			}
+			for p0 := 0; p0 < k; p0 += bs {
+				kb := min(bs, k-p0)
+				packA(ta[:mb*kb], i0, p0, mb, kb)
+				packB(tb[:kb*nb], p0, j0, kb, nb)
+				bc := float32(1)
+				if p0 == 0 {
+					bc = beta
+				}
+				impl.Sgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, mb, nb, kb, alpha, ta, kb, tb, nb, bc, t, nb)
+			}
+			for i := 0; i < mb; i++ {
+				off := (i0+i)*rs + j0*cs
+				for j := 0; j < nb; j++ {
+					c[off+j*cs] = t[i*nb+j]
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestFloat16RoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		h := Float16(i)
+		f := h.Float32()
+		got := Float16FromFloat32(f)
+		if h&0x7c00 == 0x7c00 && h&0x3ff != 0 {
+			// NaNs are quieted.
+			if !math.IsNaN(float64(f)) || got != h|0x200 {
+				t.Errorf("%#04x: got %#04x from %v want %#04x", i, got, f, h|0x200)
+			}
+			continue
+		}
+		if got != h {
+			t.Errorf("%#04x: got %#04x from %v", i, got, f)
+		}
+	}
+}
+
+func TestBFloat16RoundTrip(t *testing.T) {
+	for i := 0; i < 1<<16; i++ {
+		h := BFloat16(i)
+		f := h.Float32()
+		got := BFloat16FromFloat32(f)
+		if h&0x7f80 == 0x7f80 && h&0x7f != 0 {
+			if !math.IsNaN(float64(f)) || got != h|0x40 {
+				t.Errorf("%#04x: got %#04x from %v want %#04x", i, got, f, h|0x40)
+			}
+			continue
+		}
+		if got != h {
+			t.Errorf("%#04x: got %#04x from %v", i, got, f)
+		}
+	}
+}
+
+func TestFloat16FromFloat32(t *testing.T) {
+	for _, test := range []struct {
+		bits uint32
+		want Float16
+	}{
+		// Ties at 0x1000 in the discarded bits round to even.
+		{bits: 0x3f801000, want: 0x3c00},
+		{bits: 0x3f801001, want: 0x3c01},
+		{bits: 0x3f800fff, want: 0x3c00},
+		{bits: 0x3f803000, want: 0x3c02},
+		{bits: 0xbf803000, want: 0xbc02},
+
+		// 2^-25 is half the smallest subnormal, 2^-24.
+		{bits: 0x33000000, want: 0x0000},
+		{bits: 0x33000001, want: 0x0001},
+		{bits: 0xb3000001, want: 0x8001},
+		{bits: 0x32ffffff, want: 0x0000},
+		{bits: 0x33400000, want: 0x0001},
+		{bits: 0x33800000, want: 0x0001},
+		{bits: 0xb2800000, want: 0x8000},
+		// Subnormal ties and the carry into the normal range.
+		{bits: 0x33c00000, want: 0x0002},
+		{bits: 0x387fe000, want: 0x0400},
+
+		// Overflow to infinity, with 65520 a tie above the largest Float16.
+		{bits: 0x477fe000, want: 0x7bff},
+		{bits: 0x477fefff, want: 0x7bff},
+		{bits: 0x477ff000, want: 0x7c00},
+		{bits: 0x47800000, want: 0x7c00},
+		{bits: 0x7f7fffff, want: 0x7c00},
+		{bits: 0xff7fffff, want: 0xfc00},
+		{bits: 0x7f800000, want: 0x7c00},
+		{bits: 0xff800000, want: 0xfc00},
+
+		// NaNs stay NaN with the top of their payload.
+		{bits: 0x7fc00000, want: 0x7e00},
+		{bits: 0x7f800001, want: 0x7e00},
+		{bits: 0xffa00000, want: 0xff00},
+		{bits: 0x7fd56000, want: 0x7eab},
+	} {
+		f := math.Float32frombits(test.bits)
+		if got := Float16FromFloat32(f); got != test.want {
+			t.Errorf("%#08x (%v): got %#04x want %#04x", test.bits, f, got, test.want)
+		}
+	}
+}
+
+func TestBFloat16FromFloat32(t *testing.T) {
+	for _, test := range []struct {
+		bits uint32
+		want BFloat16
+	}{
+		{bits: 0x3f808000, want: 0x3f80},
+		{bits: 0x3f808001, want: 0x3f81},
+		{bits: 0x3f818000, want: 0x3f82},
+		{bits: 0xbf818000, want: 0xbf82},
+		{bits: 0x00008000, want: 0x0000},
+		{bits: 0x00018000, want: 0x0002},
+		{bits: 0x7f7f7fff, want: 0x7f7f},
+		{bits: 0x7f7f8000, want: 0x7f80},
+		{bits: 0x7f7fffff, want: 0x7f80},
+		{bits: 0xff7fffff, want: 0xff80},
+		{bits: 0x7f800000, want: 0x7f80},
+		{bits: 0x7f800001, want: 0x7fc0},
+		{bits: 0xffffffff, want: 0xffff},
+	} {
+		f := math.Float32frombits(test.bits)
+		if got := BFloat16FromFloat32(f); got != test.want {
+			t.Errorf("%#08x (%v): got %#04x want %#04x", test.bits, f, got, test.want)
+		}
+	}
+}
+
+// TestHalfGemm tests SHgemm and SBgemm against Sgemm of the operands
+// converted to single precision when they span several tiles.
+func TestHalfGemm(t *testing.T) {
+	defer SetTile("gemm", SetTile("gemm", 3))
+	rnd := rand.New(rand.NewSource(1))
+	var impl Blas
+	for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float32{0, 1, -0.5} {
+					m, n, k := 7, 5, 8
+					ra, ca := m, k
+					if tA != blas.NoTrans {
+						ra, ca = k, m
+					}
+					rb, cb := k, n
+					if tB != blas.NoTrans {
+						rb, cb = n, k
+					}
+					lda, ldb, ldc := ld(o, ra, ca), ld(o, rb, cb), ld(o, m, n)
+					a := single(random(rnd, size(o, ra, ca, lda)))
+					b := single(random(rnd, size(o, rb, cb, ldb)))
+					c := single(random(rnd, size(o, m, n, ldc)))
+					desc := fmt.Sprintf("(%v, %v, %v) beta=%v", o, tA, tB, beta)
+
+					ha, hb := make([]Float16, len(a)), make([]Float16, len(b))
+					ToFloat16(ha, a)
+					ToFloat16(hb, b)
+					fa, fb := make([]float32, len(a)), make([]float32, len(b))
+					FromFloat16(fa, ha)
+					FromFloat16(fb, hb)
+					want := append([]float32(nil), c...)
+					impl.Sgemm(o, tA, tB, m, n, k, 0.5, fa, lda, fb, ldb, beta, want, ldc)
+					got := append([]float32(nil), c...)
+					impl.SHgemm(o, tA, tB, m, n, k, 0.5, ha, lda, hb, ldb, beta, got, ldc)
+					sameFloat32(t, "SHgemm"+desc, got, want, k)
+
+					ba, bb := make([]BFloat16, len(a)), make([]BFloat16, len(b))
+					ToBFloat16(ba, a)
+					ToBFloat16(bb, b)
+					FromBFloat16(fa, ba)
+					FromBFloat16(fb, bb)
+					want = append(want[:0], c...)
+					impl.Sgemm(o, tA, tB, m, n, k, 0.5, fa, lda, fb, ldb, beta, want, ldc)
+					got = append(got[:0], c...)
+					impl.SBgemm(o, tA, tB, m, n, k, 0.5, ba, lda, bb, ldb, beta, got, ldc)
+					sameFloat32(t, "SBgemm"+desc, got, want, k)
+				}
+			}
+		}
+	}
+}
+
+// sameFloat32 reports the elements of got that differ from want by more
+// than the single precision rounding of a sum of k terms.
+func sameFloat32(t *testing.T, desc string, got, want []float32, k int) {
+	t.Helper()
+	tol := 1e-6 * float64(k)
+	for i, w := range want {
+		if math.Abs(float64(got[i]-w)) > tol*(1+math.Abs(float64(w))) {
+			t.Errorf("%s: element %d: got %v want %v", desc, i, got[i], w)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include <stdint.h>
+
+// cblas_gemm_s8u8s32 of MKL with 32-bit integers. The offset argument
+// 173 is CblasFixOffset.
+typedef void (*gemm_s8u8s32)(int, int, int, int, int32_t, int32_t, int32_t, float, const void *, int32_t, int8_t, const void *, int32_t, int8_t, float, int32_t *, int32_t, const int32_t *);
+
+static void call_gemm_s8u8s32(void *f, int o, int tA, int tB, int32_t m, int32_t n, int32_t k, const void *a, int32_t lda, int8_t ao, const void *b, int32_t ldb, int8_t bo, float beta, int32_t *c, int32_t ldc) {
+	int32_t co = 0;
+	((gemm_s8u8s32)f)(o, tA, tB, 173, m, n, k, 1, a, lda, ao, b, ldb, bo, beta, c, ldc, &co);
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/gonum/blas"
+)
+
+// The quantized matrix multiplications compute the products of int8
+// matrices with zero points, accumulating in int32:
+//
+//	P = (op(A) - za) * (op(B) - zb)
+//
+// where za holds a zero point for each row of op(A) and zb a zero point
+// for each column of op(B). A nil zero point slice is taken to be all
+// zeros. The accumulation wraps on overflow, which cannot happen when
+// k·(128+|za|)·(128+|zb|) < 2^31, for example for k < 32768 when the zero
+// points are within the range of int8. The other arguments follow the
+// Dgemm calling convention and are checked as for Dgemm.
+//
+// The products are computed by a blocked Go kernel, or by the
+// cblas_gemm_s8u8s32 extension of MKL when it is available and the zero
+// points are uniform and within its range.
+
+// igemmTile and igemmDepth are the size of the tiles of C and the length
+// of the panels of A and B packed by the Go kernel.
+const (
+	igemmTile  = 64
+	igemmDepth = 512
+)
+
+// Igemm performs
+//
+//	C = P + beta * C
+//
+// where P is the quantized product of A and B with zero points za and zb.
+func (Blas) Igemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, a []int8, lda int, za []int32, b []int8, ldb int, zb []int32, beta int32, c []int32, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	checkZero(m, za, n, zb)
+	if m == 0 || n == 0 {
+		return
+	}
+	if igemmLibrary(o, tA, tB, m, n, k, a, lda, za, b, ldb, zb, beta, c, ldc) {
+		return
+	}
+	rs, cs := strides(o, blas.NoTrans, ldc)
+	igemm(o, tA, tB, m, n, k, a, lda, za, b, ldb, zb, func(i0, j0, mb, nb int, p []int32) {
+		for i := 0; i < mb; i++ {
+			off := (i0+i)*rs + j0*cs
+			for j, v := range p[i*nb : (i+1)*nb] {
+				if beta == 0 {
+					c[off+j*cs] = v
+				} else {
+					c[off+j*cs] = v + beta*c[off+j*cs]
+				}
+			}
+		}
+	})
+}
+
+// SIgemm performs
+//
+//	C[i,j] = alpha * sa[i] * sb[j] * P[i,j] + beta * C[i,j]
+//
+// where P is the quantized product of A and B with zero points za and zb,
+// sa holds a scale for each row of op(A) and sb a scale for each column of
+// op(B). A nil scale slice is taken to be all ones.
+func (Blas) SIgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []int8, lda int, za []int32, sa []float32, b []int8, ldb int, zb []int32, sb []float32, beta float32, c []float32, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	checkZero(m, za, n, zb)
+	if sa != nil && len(sa) != m {
+		panic("cblas: len(sa) != m")
+	}
+	if sb != nil && len(sb) != n {
+		panic("cblas: len(sb) != n")
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	rs, cs := strides(o, blas.NoTrans, ldc)
+	igemm(o, tA, tB, m, n, k, a, lda, za, b, ldb, zb, func(i0, j0, mb, nb int, p []int32) {
+		for i := 0; i < mb; i++ {
+			s := alpha
+			if sa != nil {
+				s *= sa[i0+i]
+			}
+			off := (i0+i)*rs + j0*cs
+			for j, v := range p[i*nb : (i+1)*nb] {
+				f := s * float32(v)
+				if sb != nil {
+					f *= sb[j0+j]
+				}
+				if beta == 0 {
+					c[off+j*cs] = f
+				} else {
+					c[off+j*cs] = f + beta*c[off+j*cs]
+				}
+			}
+		}
+	})
+}
+
+func checkZero(m int, za []int32, n int, zb []int32) {
+	if za != nil && len(za) != m {
+		panic("cblas: len(za) != m")
+	}
+	if zb != nil && len(zb) != n {
+		panic("cblas: len(zb) != n")
+	}
+}
+
+// igemm computes the quantized product tile by tile, calling emit with
+// the row-major mb×nb tile p of the product whose top left element is
+// at row i0 and column j0.
+func igemm(o blas.Order, tA, tB blas.Transpose, m, n, k int, a []int8, lda int, za []int32, b []int8, ldb int, zb []int32,
+	emit func(i0, j0, mb, nb int, p []int32)) {
+	kd := min(k, igemmDepth)
+	pa := make([]int8, igemmTile*kd)
+	pb := make([]int8, igemmTile*kd)
+	acc := make([]int32, igemmTile*igemmTile)
+	var rowSum, colSum [igemmTile]int32
+	ars, acs := strides(o, tA, lda)
+	brs, bcs := strides(o, tB, ldb)
+	for i0 := 0; i0 < m; i0 += igemmTile {
+		mb := min(igemmTile, m-i0)
+		for j0 := 0; j0 < n; j0 += igemmTile {
+			nb := min(igemmTile, n-j0)
+			p := acc[:mb*nb]
+			for i := range p {
+				p[i] = 0
+			}
+			rs, cs := rowSum[:mb], colSum[:nb]
+			for i := range rs {
+				rs[i] = 0
+			}
+			for j := range cs {
+				cs[j] = 0
+			}
+			for p0 := 0; p0 < k; p0 += igemmDepth {
+				kb := min(igemmDepth, k-p0)
+				// Pack the rows of op(A) and the columns of op(B)
+				// so that each is contiguous.
+				for i := 0; i < mb; i++ {
+					r := pa[i*kb : (i+1)*kb]
+					off := (i0+i)*ars + p0*acs
+					var s int32
+					for q := range r {
+						r[q] = a[off+q*acs]
+						s += int32(r[q])
+					}
+					rs[i] += s
+				}
+				for j := 0; j < nb; j++ {
+					r := pb[j*kb : (j+1)*kb]
+					off := p0*brs + (j0+j)*bcs
+					var s int32
+					for q := range r {
+						r[q] = b[off+q*brs]
+						s += int32(r[q])
+					}
+					cs[j] += s
+				}
+				idotKernel(p, nb, pa[:mb*kb], pb[:nb*kb], kb)
+			}
+			// Apply the zero points:
+			//  Σ(a-za)(b-zb) = Σab - zb·Σa - za·Σb + k·za·zb
+			if za != nil || zb != nil {
+				for i := 0; i < mb; i++ {
+					var zai int32
+					if za != nil {
+						zai = za[i0+i]
+					}
+					for j := 0; j < nb; j++ {
+						var zbj int32
+						if zb != nil {
+							zbj = zb[j0+j]
+						}
+						p[i*nb+j] += -zbj*rs[i] - zai*cs[j] + int32(k)*zai*zbj
+					}
+				}
+			}
+			emit(i0, j0, mb, nb, p)
+		}
+	}
+}
+
+// idotKernel adds to the row-major tile p with nb columns the dot products
+// of the packed rows pa and columns pb of length kb.
+func idotKernel(p []int32, nb int, pa, pb []int8, kb int) {
+	mb := len(pa) / kb
+	for i := 0; i < mb; i++ {
+		ar := pa[i*kb : (i+1)*kb]
+		pr := p[i*nb : (i+1)*nb]
+		j := 0
+		for ; j+4 <= nb; j += 4 {
+			b0 := pb[j*kb : (j+1)*kb][:len(ar)]
+			b1 := pb[(j+1)*kb : (j+2)*kb][:len(ar)]
+			b2 := pb[(j+2)*kb : (j+3)*kb][:len(ar)]
+			b3 := pb[(j+3)*kb : (j+4)*kb][:len(ar)]
+			var s0, s1, s2, s3 int32
+			for q, v := range ar {
+				x := int32(v)
+				s0 += x * int32(b0[q])
+				s1 += x * int32(b1[q])
+				s2 += x * int32(b2[q])
+				s3 += x * int32(b3[q])
+			}
+			pr[j] += s0
+			pr[j+1] += s1
+			pr[j+2] += s2
+			pr[j+3] += s3
+		}
+		for ; j < nb; j++ {
+			bj := pb[j*kb : (j+1)*kb][:len(ar)]
+			var s int32
+			for q, v := range ar {
+				s += int32(v) * int32(bj[q])
+			}
+			pr[j] += s
+		}
+	}
+}
+
+var (
+	s8u8s32Once sync.Once
+	s8u8s32     unsafe.Pointer // The cblas_gemm_s8u8s32 function of the library, or nil.
+)
+
+// igemmLibrary computes C = P + beta*C with cblas_gemm_s8u8s32 and returns
+// true if the library provides it and it can express the product. Its B
+// operand is unsigned, so B is offset by 128 into a copy, and its zero
+// points are scalar int8 values.
+func igemmLibrary(o blas.Order, tA, tB blas.Transpose, m, n, k int, a []int8, lda int, za []int32, b []int8, ldb int, zb []int32, beta int32, c []int32, ldc int) bool {
+	s8u8s32Once.Do(func() {
+		// Only the LP64 interface is supported.
+		if l := Info(); l.Vendor == "MKL" && l.IntSize != 64 {
+			s8u8s32 = symbol("cblas_gemm_s8u8s32")
+		}
+	})
+	if s8u8s32 == nil || k == 0 || (beta != 0 && beta != 1) {
+		return false
+	}
+	uniform := func(z []int32) (int32, bool) {
+		if len(z) == 0 {
+			return 0, true
+		}
+		for _, v := range z[1:] {
+			if v != z[0] {
+				return 0, false
+			}
+		}
+		return z[0], true
+	}
+	zav, ok := uniform(za)
+	if !ok || -zav < -128 || -zav > 127 {
+		return false
+	}
+	zbv, ok := uniform(zb)
+	// op(B) - zb = (op(B) + 128) + (-128 - zb).
+	bo := -128 - zbv
+	if !ok || bo < -128 || bo > 127 {
+		return false
+	}
+	ub := make([]uint8, len(b))
+	for i, v := range b {
+		ub[i] = uint8(int32(v) + 128)
+	}
+	C.call_gemm_s8u8s32(s8u8s32, C.int(o), C.int(tA), C.int(tB), C.int32_t(m), C.int32_t(n), C.int32_t(k),
+		unsafe.Pointer(&a[0]), C.int32_t(lda), C.int8_t(-zav), unsafe.Pointer(&ub[0]), C.int32_t(ldb), C.int8_t(bo),
+		C.float(beta), (*C.int32_t)(&c[0]), C.int32_t(ldc))
+	return true
+}
@@ -0,0 +1,144 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestIgemm tests Igemm and SIgemm against a naive quantized product with
+// non-uniform zero points and scales when the operands span several tiles
+// and panels of the Go kernel.
+func TestIgemm(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	int8s := func(n int) []int8 {
+		v := make([]int8, n)
+		for i := range v {
+			v[i] = int8(rnd.Intn(256) - 128)
+		}
+		return v
+	}
+	zeros := func(n int) []int32 {
+		z := make([]int32, n)
+		for i := range z {
+			z[i] = int32(rnd.Intn(256) - 128)
+		}
+		return z
+	}
+	scales := func(n int) []float32 {
+		s := make([]float32, n)
+		for i := range s {
+			s[i] = float32(rnd.Float64() + 0.5)
+		}
+		return s
+	}
+
+	m, n, k := igemmTile+6, igemmTile+3, igemmDepth+8
+	var impl Blas
+	for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				ra, ca := m, k
+				if tA != blas.NoTrans {
+					ra, ca = k, m
+				}
+				rb, cb := k, n
+				if tB != blas.NoTrans {
+					rb, cb = n, k
+				}
+				lda, ldb, ldc := ld(o, ra, ca), ld(o, rb, cb), ld(o, m, n)
+				a := int8s(size(o, ra, ca, lda))
+				b := int8s(size(o, rb, cb, ldb))
+				za, zb := zeros(m), zeros(n)
+				sa, sb := scales(m), scales(n)
+
+				for _, zero := range []bool{false, true} {
+					za, zb := za, zb
+					if !zero {
+						za, zb = nil, nil
+					}
+					p := make([]int32, m*n)
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							var zai, zbj int32
+							if zero {
+								zai, zbj = za[i], zb[j]
+							}
+							var s int32
+							for q := 0; q < k; q++ {
+								s += (int32(elem(o, tA, a, lda, i, q)) - zai) * (int32(elem(o, tB, b, ldb, q, j)) - zbj)
+							}
+							p[i*n+j] = s
+						}
+					}
+					desc := fmt.Sprintf("(%v, %v, %v) zero=%t", o, tA, tB, zero)
+
+					for _, beta := range []int32{0, 1, -3} {
+						c := make([]int32, size(o, m, n, ldc))
+						for i := range c {
+							c[i] = int32(rnd.Intn(1000) - 500)
+						}
+						want := append([]int32(nil), c...)
+						for i := 0; i < m; i++ {
+							for j := 0; j < n; j++ {
+								w := &want[index(o, ldc, i, j)]
+								*w = p[i*n+j] + beta**w
+							}
+						}
+						impl.Igemm(o, tA, tB, m, n, k, a, lda, za, b, ldb, zb, beta, c, ldc)
+						for i := range c {
+							if c[i] != want[i] {
+								t.Errorf("Igemm%s beta=%d: element %d: got %d want %d", desc, beta, i, c[i], want[i])
+								break
+							}
+						}
+					}
+
+					const alpha, beta = 0.25, -0.5
+					c := make([]float32, size(o, m, n, ldc))
+					for i := range c {
+						c[i] = float32(rnd.Float64())
+					}
+					want := append([]float32(nil), c...)
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							w := &want[index(o, ldc, i, j)]
+							*w = alpha*sa[i]*float32(p[i*n+j])*sb[j] + beta**w
+						}
+					}
+					impl.SIgemm(o, tA, tB, m, n, k, alpha, a, lda, za, sa, b, ldb, zb, sb, beta, c, ldc)
+					for i := range c {
+						if c[i] != want[i] {
+							t.Errorf("SIgemm%s: element %d: got %v want %v", desc, i, c[i], want[i])
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// elem returns the element at row i and column j of op(X), where X is
+// held in x in the order o with leading dimension ld.
+func elem(o blas.Order, tX blas.Transpose, x []int8, ld, i, j int) int8 {
+	if tX != blas.NoTrans {
+		i, j = j, i
+	}
+	return x[index(o, ld, i, j)]
+}
+
+// index returns the index of the element at row i and column j of a
+// matrix in the order o with leading dimension ld.
+func index(o blas.Order, ld, i, j int) int {
+	if o == blas.ColMajor {
+		return j*ld + i
+	}
+	return i*ld + j
+}
@@ -0,0 +1,205 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#define _GNU_SOURCE
+#include <dlfcn.h>
+#include <stdlib.h>
+
+static void *lookup(const char *name) { return dlsym(RTLD_DEFAULT, name); }
+
+static const char *call_str(void *f) { return ((const char *(*)(void))f)(); }
+static int call_int(void *f) { return ((int (*)(void))f)(); }
+static long long call_long(void *f) { return ((long long (*)(void))f)(); }
+static const char *call_str_long(void *f, long long v) { return ((const char *(*)(long long))f)(v); }
+static void call_buf(void *f, char *buf, int len) { ((void (*)(char *, int))f)(buf, len); }
+*/
+import "C"
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// fortranInt is the width in bits of the integers of the Fortran BLAS
+// when built with the fortran tag, and zero otherwise.
+var fortranInt int
+
+// Library describes the BLAS library used by the package.
+//
+// The library is identified by looking up symbols specific to each vendor
+// in the running process, so a statically linked library may be reported
+// as unknown.
+type Library struct {
+	// Vendor is "OpenBLAS", "BLIS", "MKL", "ATLAS", "Netlib" or "unknown".
+	Vendor string
+
+	// Version is the version reported by the library, if any.
+	Version string
+
+	// Config is the build configuration reported by the library, if any.
+	Config string
+
+	// Core is the processor architecture the library selected, if reported.
+	Core string
+
+	// Parallel is the threading model of the library: "sequential",
+	// "pthreads" or "openmp", or empty if it is not reported.
+	Parallel string
+
+	// IntSize is the width in bits of the integers of the library
+	// interface, or zero if it is not reported. The CBLAS interface used by
+	// the package passes C int values.
+	IntSize int
+
+	// Link is the build tag selecting the linked library, or empty for
+	// the system libblas.
+	Link string
+
+	// Fortran is whether the CBLAS layer of fortran.c is used.
+	Fortran bool
+
+	// Symbols is the list of optional CBLAS symbols provided by the library.
+	Symbols []string
+}
+
+// optional is the list of CBLAS extensions looked for by Info.
+var optional = []string{
+	"cblas_csrot", "cblas_zdrot",
+	"cblas_crotg", "cblas_zrotg",
+	"cblas_scabs1", "cblas_dcabs1",
+	"cblas_isamin", "cblas_idamin", "cblas_icamin", "cblas_izamin",
+	"cblas_saxpby", "cblas_daxpby", "cblas_caxpby", "cblas_zaxpby",
+	"cblas_somatcopy", "cblas_domatcopy", "cblas_comatcopy", "cblas_zomatcopy",
+	"cblas_simatcopy", "cblas_dimatcopy", "cblas_cimatcopy", "cblas_zimatcopy",
+	"cblas_sgemmt", "cblas_dgemmt", "cblas_cgemmt", "cblas_zgemmt",
+	"cblas_cgemm3m", "cblas_zgemm3m",
+	"cblas_sgemm_batch", "cblas_dgemm_batch", "cblas_cgemm_batch", "cblas_zgemm_batch",
+	"cblas_hgemm", "cblas_sbgemm", "cblas_sbgemv", "cblas_sbdot",
+	"cblas_gemm_bf16bf16f32", "cblas_gemm_s8u8s32", "cblas_gemm_s16s16s32",
+	"catlas_saxpby", "catlas_daxpby", "catlas_caxpby", "catlas_zaxpby",
+	"catlas_sset", "catlas_dset", "catlas_cset", "catlas_zset",
+}
+
+// symbol returns the address of the named symbol in the running process,
+// or nil if it is not found.
+func symbol(name string) unsafe.Pointer {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return C.lookup(cname)
+}
+
+// Info returns a description of the BLAS library used by the package.
+func Info() Library {
+	l := Library{
+		Vendor:  "unknown",
+		Link:    linked,
+		Fortran: fortranInt != 0,
+		IntSize: fortranInt,
+	}
+	if f := symbol("openblas_get_config"); f != nil {
+		l.Vendor = "OpenBLAS"
+		l.Config = strings.TrimSpace(C.GoString(C.call_str(f)))
+		if fields := strings.Fields(l.Config); len(fields) > 1 && fields[0] == "OpenBLAS" {
+			l.Version = fields[1]
+		}
+		if f := symbol("openblas_get_corename"); f != nil {
+			l.Core = C.GoString(C.call_str(f))
+		}
+		if f := symbol("openblas_get_parallel"); f != nil {
+			switch C.call_int(f) {
+			case 0:
+				l.Parallel = "sequential"
+			case 1:
+				l.Parallel = "pthreads"
+			case 2:
+				l.Parallel = "openmp"
+			}
+		}
+		if l.IntSize == 0 {
+			l.IntSize = 32
+			if strings.Contains(l.Config, "USE64BITINT") {
+				l.IntSize = 64
+			}
+		}
+	} else if f := symbol("bli_info_get_version_str"); f != nil {
+		l.Vendor = "BLIS"
+		l.Version = C.GoString(C.call_str(f))
+		// The BLIS gint_t may be 32 or 64 bits wide; the values
+		// returned below fit in the low 32 bits.
+		gint := func(name string) int {
+			if f := symbol(name); f != nil {
+				return int(uint32(C.call_long(f)))
+			}
+			return 0
+		}
+		if f, g := symbol("bli_arch_query_id"), symbol("bli_arch_string"); f != nil && g != nil {
+			l.Core = C.GoString(C.call_str_long(g, C.longlong(uint32(C.call_long(f)))))
+		}
+		switch {
+		case gint("bli_info_get_enable_openmp") != 0:
+			l.Parallel = "openmp"
+		case gint("bli_info_get_enable_pthreads") != 0:
+			l.Parallel = "pthreads"
+		case symbol("bli_info_get_enable_openmp") != nil:
+			l.Parallel = "sequential"
+		}
+		if l.IntSize == 0 {
+			l.IntSize = gint("bli_info_get_blas_int_type_size")
+		}
+	} else if f := symbol("MKL_Get_Version_String"); f != nil {
+		l.Vendor = "MKL"
+		var buf [256]C.char
+		C.call_buf(f, &buf[0], C.int(len(buf)))
+		l.Config = strings.TrimSpace(C.GoString(&buf[0]))
+		// The version follows the product name, as in "Intel(R) oneAPI
+		// Math Kernel Library Version 2023.2-Product Build ...".
+		if i := strings.Index(l.Config, "Version "); i >= 0 {
+			if fields := strings.Fields(l.Config[i+len("Version "):]); len(fields) > 0 {
+				l.Version = strings.TrimSuffix(fields[0], "-Product")
+			}
+		}
+	} else if symbol("ATL_buildinfo") != nil || symbol("ATL_xerbla") != nil {
+		l.Vendor = "ATLAS"
+	} else if symbol("CBLAS_CallFromC") != nil {
+		l.Vendor = "Netlib"
+	}
+	for _, name := range optional {
+		if symbol(name) != nil {
+			l.Symbols = append(l.Symbols, name)
+		}
+	}
+	return l
+}
+
+// String returns a one line summary of l suitable for logging.
+func (l Library) String() string {
+	s := l.Vendor
+	if l.Version != "" {
+		s += " " + l.Version
+	}
+	var attr []string
+	if l.Core != "" {
+		attr = append(attr, "core="+l.Core)
+	}
+	if l.Parallel != "" {
+		attr = append(attr, "parallel="+l.Parallel)
+	}
+	if l.IntSize != 0 {
+		attr = append(attr, fmt.Sprintf("int%d", l.IntSize))
+	}
+	if l.Link != "" {
+		attr = append(attr, "tag="+l.Link)
+	}
+	if l.Fortran {
+		attr = append(attr, "fortran")
+	}
+	attr = append(attr, fmt.Sprintf("%d optional symbols", len(l.Symbols)))
+	return s + " (" + strings.Join(attr, ", ") + ")"
+}
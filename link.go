@@ -0,0 +1,25 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !openblas && !blis && !atlas && !netlib && !mkl && !cblas_custom
+
+package cblas
+
+// The BLAS library linked is selected with build tags. Without a tag the
+// system libblas is linked. The openblas, blis, atlas, netlib and mkl tags
+// link OpenBLAS, BLIS, ATLAS, the Netlib reference CBLAS or the sequential
+// LP64 Intel MKL, using the flags given by pkg-config. The cblas_custom tag
+// adds no link flags, leaving the library to be given by CGO_LDFLAGS:
+//
+//	CGO_LDFLAGS="-L/opt/blas/lib -lmyblas" go build -tags cblas_custom
+//
+// Only one of the tags should be given.
+
+/*
+#cgo LDFLAGS: -L/usr/lib/ -lblas
+*/
+import "C"
+
+// linked is the build tag selecting the linked library.
+const linked = ""
@@ -0,0 +1,9 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build cblas_custom
+
+package cblas
+
+const linked = "cblas_custom"
@@ -0,0 +1,12 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mkl && !cblas_custom
+
+package cblas
+
+// #cgo pkg-config: mkl-dynamic-lp64-seq
+import "C"
+
+const linked = "mkl"
@@ -0,0 +1,12 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build netlib && !cblas_custom
+
+package cblas
+
+// #cgo pkg-config: cblas
+import "C"
+
+const linked = "netlib"
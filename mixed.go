@@ -0,0 +1,191 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import "github.com/gonum/blas"
+
+// The mixed precision matrix multiplications take single precision
+// operands and accumulate in double precision. They convert square tiles
+// of the operands to double precision and multiply them with Dgemm, so
+// they use the optimized double precision kernels of the library at the
+// cost of converting each tile of A and B once for every tile of C that
+// depends on it. The arguments are checked as for Dgemm.
+
+// gemmTile is the size of the tiles converted by the mixed and half
+// precision routines. It is set by SetTile("gemm", n).
+var gemmTile int64 = 256
+
+// Dsgemm performs
+//
+//	C = alpha * op(A) * op(B) + beta * C
+//
+// where A and B are single precision and C is double precision, with the
+// products accumulated in double precision.
+func (impl Blas) Dsgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float32, lda int, b []float32, ldb int, beta float64, c []float64, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	mixedGemm(impl, o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta,
+		func(dst []float64, i0, j0, rows, cols int) {
+			pack64(dst, c, ldc, o, blas.NoTrans, i0, j0, rows, cols)
+		},
+		func(src []float64, i0, j0, rows, cols int) {
+			unpack64(c, ldc, o, src, i0, j0, rows, cols)
+		},
+	)
+}
+
+// Sdsgemm performs
+//
+//	C = alpha * op(A) * op(B) + beta * C
+//
+// where A, B and C are single precision, with the products accumulated in
+// double precision and the result rounded to single precision.
+func (impl Blas) Sdsgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	checkGemm(o, tA, tB, m, n, k, len(a), lda, len(b), ldb, len(c), ldc)
+	mixedGemm(impl, o, tA, tB, m, n, k, float64(alpha), a, lda, b, ldb, float64(beta),
+		func(dst []float64, i0, j0, rows, cols int) {
+			pack32(dst, c, ldc, o, blas.NoTrans, i0, j0, rows, cols)
+		},
+		func(src []float64, i0, j0, rows, cols int) {
+			unpack32(c, ldc, o, src, i0, j0, rows, cols)
+		},
+	)
+}
+
+// checkGemm checks the arguments of a matrix multiplication as Dgemm does.
+func checkGemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m, n, k, lenA, lda, lenB, ldb, lenC, ldc int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if m < 0 {
+		panic("cblas: m < 0")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	if k < 0 {
+		panic("cblas: k < 0")
+	}
+	var rowA, colA, rowB, colB int
+	if tA == blas.NoTrans {
+		rowA, colA = m, k
+	} else {
+		rowA, colA = k, m
+	}
+	if tB == blas.NoTrans {
+		rowB, colB = k, n
+	} else {
+		rowB, colB = n, k
+	}
+	checkOrdered(o, rowA, colA, lenA, lda)
+	checkOrdered(o, rowB, colB, lenB, ldb)
+	checkOrdered(o, m, n, lenC, ldc)
+}
+
+// mixedGemm multiplies single precision matrices tile by tile with Dgemm.
+// The tiles of C are loaded into and stored from a row-major double
+// precision buffer by load and store.
+func mixedGemm(impl Blas, o blas.Order, tA, tB blas.Transpose, m, n, k int, alpha float64, a []float32, lda int, b []float32, ldb int, beta float64,
+	load, store func(buf []float64, i0, j0, rows, cols int)) {
+	if m == 0 || n == 0 {
+		return
+	}
+	bs := tileSize(&gemmTile)
+	ta := make([]float64, bs*bs)
+	tb := make([]float64, bs*bs)
+	tc := make([]float64, bs*bs)
+	for i0 := 0; i0 < m; i0 += bs {
+		mb := min(bs, m-i0)
+		for j0 := 0; j0 < n; j0 += bs {
+			nb := min(bs, n-j0)
+			c := tc[:mb*nb]
+			load(c, i0, j0, mb, nb)
+			if k == 0 {
+				for i := range c {
+					if beta == 0 {
+						c[i] = 0
+					} else {
+						c[i] *= beta
+					}
+				}
+			}
+			for p0 := 0; p0 < k; p0 += bs {
+				kb := min(bs, k-p0)
+				pack32(ta[:mb*kb], a, lda, o, tA, i0, p0, mb, kb)
+				pack32(tb[:kb*nb], b, ldb, o, tB, p0, j0, kb, nb)
+				bc := 1.0
+				if p0 == 0 {
+					bc = beta
+				}
+				impl.Dgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, mb, nb, kb, alpha, ta, kb, tb, nb, bc, c, nb)
+			}
+			store(c, i0, j0, mb, nb)
+		}
+	}
+}
+
+// strides returns the offsets in storage with leading dimension ld and
+// order o between the rows and between the columns of op(X).
+func strides(o blas.Order, t blas.Transpose, ld int) (row, col int) {
+	if (o == blas.ColMajor) != (t != blas.NoTrans) {
+		return 1, ld
+	}
+	return ld, 1
+}
+
+// pack32 converts the block of op(X) with rows [r0, r0+rows) and columns
+// [c0, c0+cols) to row-major double precision in dst.
+func pack32(dst []float64, x []float32, ld int, o blas.Order, t blas.Transpose, r0, c0, rows, cols int) {
+	rs, cs := strides(o, t, ld)
+	for i := 0; i < rows; i++ {
+		d := dst[i*cols : (i+1)*cols]
+		off := (r0+i)*rs + c0*cs
+		for j := range d {
+			d[j] = float64(x[off+j*cs])
+		}
+	}
+}
+
+// pack64 is pack32 for double precision X.
+func pack64(dst []float64, x []float64, ld int, o blas.Order, t blas.Transpose, r0, c0, rows, cols int) {
+	rs, cs := strides(o, t, ld)
+	for i := 0; i < rows; i++ {
+		d := dst[i*cols : (i+1)*cols]
+		off := (r0+i)*rs + c0*cs
+		for j := range d {
+			d[j] = x[off+j*cs]
+		}
+	}
+}
+
+// unpack32 rounds the row-major block src to single precision and stores
+// it in the rows [r0, r0+rows) and columns [c0, c0+cols) of X.
+func unpack32(x []float32, ld int, o blas.Order, src []float64, r0, c0, rows, cols int) {
+	rs, cs := strides(o, blas.NoTrans, ld)
+	for i := 0; i < rows; i++ {
+		s := src[i*cols : (i+1)*cols]
+		off := (r0+i)*rs + c0*cs
+		for j, v := range s {
+			x[off+j*cs] = float32(v)
+		}
+	}
+}
+
+// unpack64 is unpack32 for double precision X.
+func unpack64(x []float64, ld int, o blas.Order, src []float64, r0, c0, rows, cols int) {
+	rs, cs := strides(o, blas.NoTrans, ld)
+	for i := 0; i < rows; i++ {
+		s := src[i*cols : (i+1)*cols]
+		off := (r0+i)*rs + c0*cs
+		for j, v := range s {
+			x[off+j*cs] = v
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestMixedGemm tests Dsgemm and Sdsgemm against Dgemm of the operands
+// converted to double precision when they span several tiles.
+func TestMixedGemm(t *testing.T) {
+	defer SetTile("gemm", SetTile("gemm", 3))
+	rnd := rand.New(rand.NewSource(1))
+	var impl Blas
+	for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+		for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, beta := range []float64{0, 1, -0.5} {
+					m, n, k := 7, 5, 8
+					ra, ca := m, k
+					if tA != blas.NoTrans {
+						ra, ca = k, m
+					}
+					rb, cb := k, n
+					if tB != blas.NoTrans {
+						rb, cb = n, k
+					}
+					lda, ldb, ldc := ld(o, ra, ca), ld(o, rb, cb), ld(o, m, n)
+					a := single(random(rnd, size(o, ra, ca, lda)))
+					b := single(random(rnd, size(o, rb, cb, ldb)))
+					c := single(random(rnd, size(o, m, n, ldc)))
+					want := double(c)
+					impl.Dgemm(o, tA, tB, m, n, k, 0.5, double(a), lda, double(b), ldb, beta, want, ldc)
+					desc := fmt.Sprintf("(%v, %v, %v) beta=%v", o, tA, tB, beta)
+
+					got := double(c)
+					impl.Dsgemm(o, tA, tB, m, n, k, 0.5, a, lda, b, ldb, beta, got, ldc)
+					same(t, "Dsgemm"+desc, got, want, k)
+
+					got32 := append([]float32(nil), c...)
+					impl.Sdsgemm(o, tA, tB, m, n, k, 0.5, a, lda, b, ldb, float32(beta), got32, ldc)
+					for i, w := range want {
+						if math.Abs(float64(got32[i])-w) > 0x1p-24*math.Abs(w)+1e-14*float64(k) {
+							t.Errorf("Sdsgemm%s: element %d: got %v want %v", desc, i, got32[i], w)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestMixedGemmCheck tests that the arguments of Dsgemm are checked as
+// for Dgemm, with a 2×3 A.
+func TestMixedGemmCheck(t *testing.T) {
+	a := make([]float32, 9)
+	b := make([]float32, 9)
+	c := make([]float64, 4)
+	for _, test := range []struct {
+		o      blas.Order
+		tA     blas.Transpose
+		lda    int
+		lenA   int
+		panics string
+	}{
+		{o: blas.RowMajor, tA: 'x', lda: 3, lenA: 6, panics: "cblas: illegal transpose"},
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 2, lenA: 6, panics: "cblas: index out of range"},
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 3, lenA: 5, panics: "cblas: index out of range"},
+		{o: blas.ColMajor, tA: blas.NoTrans, lda: 1, lenA: 6, panics: "cblas: index out of range"},
+		{o: blas.ColMajor, tA: blas.NoTrans, lda: 3, lenA: 7, panics: "cblas: index out of range"},
+		{o: blas.RowMajor, tA: blas.Trans, lda: 2, lenA: 6},
+		// The last row or column of A need not be padded to lda.
+		{o: blas.RowMajor, tA: blas.NoTrans, lda: 4, lenA: 7},
+		{o: blas.ColMajor, tA: blas.NoTrans, lda: 3, lenA: 8},
+	} {
+		func() {
+			defer func() {
+				r := recover()
+				if r == nil && test.panics != "" || r != nil && r != test.panics {
+					t.Errorf("%v %v lda=%d len=%d: unexpected panic: got %v want %q", test.o, test.tA, test.lda, test.lenA, r, test.panics)
+				}
+			}()
+			Blas{}.Dsgemm(test.o, test.tA, blas.NoTrans, 2, 2, 3, 1, a[:test.lenA], test.lda, b, 3, 0, c, 2)
+		}()
+	}
+}
+
+func single(v []float64) []float32 {
+	s := make([]float32, len(v))
+	for i, f := range v {
+		s[i] = float32(f)
+	}
+	return s
+}
+
+func double(v []float32) []float64 {
+	d := make([]float64, len(v))
+	for i, f := range v {
+		d[i] = float64(f)
+	}
+	return d
+}
@@ -0,0 +1,301 @@
+// Do not manually edit this file. It was created by the genBlas.pl script from cblas.h.
+
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.24
+
+package cblas
+
+// The CBLAS functions neither keep the pointers they are passed nor call
+// back into Go. Marking them so lets the slices passed to the Blas methods
+// stay on the stack and avoids preparing for callbacks on each call.
+
+/*
+#cgo noescape cblas_caxpy
+#cgo nocallback cblas_caxpy
+#cgo noescape cblas_ccopy
+#cgo nocallback cblas_ccopy
+#cgo noescape cblas_cdotc_sub
+#cgo nocallback cblas_cdotc_sub
+#cgo noescape cblas_cdotu_sub
+#cgo nocallback cblas_cdotu_sub
+#cgo noescape cblas_cgbmv
+#cgo nocallback cblas_cgbmv
+#cgo noescape cblas_cgemm
+#cgo nocallback cblas_cgemm
+#cgo noescape cblas_cgemv
+#cgo nocallback cblas_cgemv
+#cgo noescape cblas_cgerc
+#cgo nocallback cblas_cgerc
+#cgo noescape cblas_cgeru
+#cgo nocallback cblas_cgeru
+#cgo noescape cblas_chbmv
+#cgo nocallback cblas_chbmv
+#cgo noescape cblas_chemm
+#cgo nocallback cblas_chemm
+#cgo noescape cblas_chemv
+#cgo nocallback cblas_chemv
+#cgo noescape cblas_cher
+#cgo nocallback cblas_cher
+#cgo noescape cblas_cher2
+#cgo nocallback cblas_cher2
+#cgo noescape cblas_cher2k
+#cgo nocallback cblas_cher2k
+#cgo noescape cblas_cherk
+#cgo nocallback cblas_cherk
+#cgo noescape cblas_chpmv
+#cgo nocallback cblas_chpmv
+#cgo noescape cblas_chpr
+#cgo nocallback cblas_chpr
+#cgo noescape cblas_chpr2
+#cgo nocallback cblas_chpr2
+#cgo noescape cblas_cscal
+#cgo nocallback cblas_cscal
+#cgo noescape cblas_csscal
+#cgo nocallback cblas_csscal
+#cgo noescape cblas_cswap
+#cgo nocallback cblas_cswap
+#cgo noescape cblas_csymm
+#cgo nocallback cblas_csymm
+#cgo noescape cblas_csyr2k
+#cgo nocallback cblas_csyr2k
+#cgo noescape cblas_csyrk
+#cgo nocallback cblas_csyrk
+#cgo noescape cblas_ctbmv
+#cgo nocallback cblas_ctbmv
+#cgo noescape cblas_ctbsv
+#cgo nocallback cblas_ctbsv
+#cgo noescape cblas_ctpmv
+#cgo nocallback cblas_ctpmv
+#cgo noescape cblas_ctpsv
+#cgo nocallback cblas_ctpsv
+#cgo noescape cblas_ctrmm
+#cgo nocallback cblas_ctrmm
+#cgo noescape cblas_ctrmv
+#cgo nocallback cblas_ctrmv
+#cgo noescape cblas_ctrsm
+#cgo nocallback cblas_ctrsm
+#cgo noescape cblas_ctrsv
+#cgo nocallback cblas_ctrsv
+#cgo noescape cblas_dasum
+#cgo nocallback cblas_dasum
+#cgo noescape cblas_daxpy
+#cgo nocallback cblas_daxpy
+#cgo noescape cblas_dcopy
+#cgo nocallback cblas_dcopy
+#cgo noescape cblas_ddot
+#cgo nocallback cblas_ddot
+#cgo noescape cblas_dgbmv
+#cgo nocallback cblas_dgbmv
+#cgo noescape cblas_dgemm
+#cgo nocallback cblas_dgemm
+#cgo noescape cblas_dgemv
+#cgo nocallback cblas_dgemv
+#cgo noescape cblas_dger
+#cgo nocallback cblas_dger
+#cgo noescape cblas_dnrm2
+#cgo nocallback cblas_dnrm2
+#cgo noescape cblas_drot
+#cgo nocallback cblas_drot
+#cgo noescape cblas_drotg
+#cgo nocallback cblas_drotg
+#cgo noescape cblas_drotm
+#cgo nocallback cblas_drotm
+#cgo noescape cblas_drotmg
+#cgo nocallback cblas_drotmg
+#cgo noescape cblas_dsbmv
+#cgo nocallback cblas_dsbmv
+#cgo noescape cblas_dscal
+#cgo nocallback cblas_dscal
+#cgo noescape cblas_dsdot
+#cgo nocallback cblas_dsdot
+#cgo noescape cblas_dspmv
+#cgo nocallback cblas_dspmv
+#cgo noescape cblas_dspr
+#cgo nocallback cblas_dspr
+#cgo noescape cblas_dspr2
+#cgo nocallback cblas_dspr2
+#cgo noescape cblas_dswap
+#cgo nocallback cblas_dswap
+#cgo noescape cblas_dsymm
+#cgo nocallback cblas_dsymm
+#cgo noescape cblas_dsymv
+#cgo nocallback cblas_dsymv
+#cgo noescape cblas_dsyr
+#cgo nocallback cblas_dsyr
+#cgo noescape cblas_dsyr2
+#cgo nocallback cblas_dsyr2
+#cgo noescape cblas_dsyr2k
+#cgo nocallback cblas_dsyr2k
+#cgo noescape cblas_dsyrk
+#cgo nocallback cblas_dsyrk
+#cgo noescape cblas_dtbmv
+#cgo nocallback cblas_dtbmv
+#cgo noescape cblas_dtbsv
+#cgo nocallback cblas_dtbsv
+#cgo noescape cblas_dtpmv
+#cgo nocallback cblas_dtpmv
+#cgo noescape cblas_dtpsv
+#cgo nocallback cblas_dtpsv
+#cgo noescape cblas_dtrmm
+#cgo nocallback cblas_dtrmm
+#cgo noescape cblas_dtrmv
+#cgo nocallback cblas_dtrmv
+#cgo noescape cblas_dtrsm
+#cgo nocallback cblas_dtrsm
+#cgo noescape cblas_dtrsv
+#cgo nocallback cblas_dtrsv
+#cgo noescape cblas_dzasum
+#cgo nocallback cblas_dzasum
+#cgo noescape cblas_dznrm2
+#cgo nocallback cblas_dznrm2
+#cgo noescape cblas_icamax
+#cgo nocallback cblas_icamax
+#cgo noescape cblas_idamax
+#cgo nocallback cblas_idamax
+#cgo noescape cblas_isamax
+#cgo nocallback cblas_isamax
+#cgo noescape cblas_izamax
+#cgo nocallback cblas_izamax
+#cgo noescape cblas_sasum
+#cgo nocallback cblas_sasum
+#cgo noescape cblas_saxpy
+#cgo nocallback cblas_saxpy
+#cgo noescape cblas_scasum
+#cgo nocallback cblas_scasum
+#cgo noescape cblas_scnrm2
+#cgo nocallback cblas_scnrm2
+#cgo noescape cblas_scopy
+#cgo nocallback cblas_scopy
+#cgo noescape cblas_sdot
+#cgo nocallback cblas_sdot
+#cgo noescape cblas_sdsdot
+#cgo nocallback cblas_sdsdot
+#cgo noescape cblas_sgbmv
+#cgo nocallback cblas_sgbmv
+#cgo noescape cblas_sgemm
+#cgo nocallback cblas_sgemm
+#cgo noescape cblas_sgemv
+#cgo nocallback cblas_sgemv
+#cgo noescape cblas_sger
+#cgo nocallback cblas_sger
+#cgo noescape cblas_snrm2
+#cgo nocallback cblas_snrm2
+#cgo noescape cblas_srot
+#cgo nocallback cblas_srot
+#cgo noescape cblas_srotg
+#cgo nocallback cblas_srotg
+#cgo noescape cblas_srotm
+#cgo nocallback cblas_srotm
+#cgo noescape cblas_srotmg
+#cgo nocallback cblas_srotmg
+#cgo noescape cblas_ssbmv
+#cgo nocallback cblas_ssbmv
+#cgo noescape cblas_sscal
+#cgo nocallback cblas_sscal
+#cgo noescape cblas_sspmv
+#cgo nocallback cblas_sspmv
+#cgo noescape cblas_sspr
+#cgo nocallback cblas_sspr
+#cgo noescape cblas_sspr2
+#cgo nocallback cblas_sspr2
+#cgo noescape cblas_sswap
+#cgo nocallback cblas_sswap
+#cgo noescape cblas_ssymm
+#cgo nocallback cblas_ssymm
+#cgo noescape cblas_ssymv
+#cgo nocallback cblas_ssymv
+#cgo noescape cblas_ssyr
+#cgo nocallback cblas_ssyr
+#cgo noescape cblas_ssyr2
+#cgo nocallback cblas_ssyr2
+#cgo noescape cblas_ssyr2k
+#cgo nocallback cblas_ssyr2k
+#cgo noescape cblas_ssyrk
+#cgo nocallback cblas_ssyrk
+#cgo noescape cblas_stbmv
+#cgo nocallback cblas_stbmv
+#cgo noescape cblas_stbsv
+#cgo nocallback cblas_stbsv
+#cgo noescape cblas_stpmv
+#cgo nocallback cblas_stpmv
+#cgo noescape cblas_stpsv
+#cgo nocallback cblas_stpsv
+#cgo noescape cblas_strmm
+#cgo nocallback cblas_strmm
+#cgo noescape cblas_strmv
+#cgo nocallback cblas_strmv
+#cgo noescape cblas_strsm
+#cgo nocallback cblas_strsm
+#cgo noescape cblas_strsv
+#cgo nocallback cblas_strsv
+#cgo noescape cblas_zaxpy
+#cgo nocallback cblas_zaxpy
+#cgo noescape cblas_zcopy
+#cgo nocallback cblas_zcopy
+#cgo noescape cblas_zdotc_sub
+#cgo nocallback cblas_zdotc_sub
+#cgo noescape cblas_zdotu_sub
+#cgo nocallback cblas_zdotu_sub
+#cgo noescape cblas_zdscal
+#cgo nocallback cblas_zdscal
+#cgo noescape cblas_zgbmv
+#cgo nocallback cblas_zgbmv
+#cgo noescape cblas_zgemm
+#cgo nocallback cblas_zgemm
+#cgo noescape cblas_zgemv
+#cgo nocallback cblas_zgemv
+#cgo noescape cblas_zgerc
+#cgo nocallback cblas_zgerc
+#cgo noescape cblas_zgeru
+#cgo nocallback cblas_zgeru
+#cgo noescape cblas_zhbmv
+#cgo nocallback cblas_zhbmv
+#cgo noescape cblas_zhemm
+#cgo nocallback cblas_zhemm
+#cgo noescape cblas_zhemv
+#cgo nocallback cblas_zhemv
+#cgo noescape cblas_zher
+#cgo nocallback cblas_zher
+#cgo noescape cblas_zher2
+#cgo nocallback cblas_zher2
+#cgo noescape cblas_zher2k
+#cgo nocallback cblas_zher2k
+#cgo noescape cblas_zherk
+#cgo nocallback cblas_zherk
+#cgo noescape cblas_zhpmv
+#cgo nocallback cblas_zhpmv
+#cgo noescape cblas_zhpr
+#cgo nocallback cblas_zhpr
+#cgo noescape cblas_zhpr2
+#cgo nocallback cblas_zhpr2
+#cgo noescape cblas_zscal
+#cgo nocallback cblas_zscal
+#cgo noescape cblas_zswap
+#cgo nocallback cblas_zswap
+#cgo noescape cblas_zsymm
+#cgo nocallback cblas_zsymm
+#cgo noescape cblas_zsyr2k
+#cgo nocallback cblas_zsyr2k
+#cgo noescape cblas_zsyrk
+#cgo nocallback cblas_zsyrk
+#cgo noescape cblas_ztbmv
+#cgo nocallback cblas_ztbmv
+#cgo noescape cblas_ztbsv
+#cgo nocallback cblas_ztbsv
+#cgo noescape cblas_ztpmv
+#cgo nocallback cblas_ztpmv
+#cgo noescape cblas_ztpsv
+#cgo nocallback cblas_ztpsv
+#cgo noescape cblas_ztrmm
+#cgo nocallback cblas_ztrmm
+#cgo noescape cblas_ztrmv
+#cgo nocallback cblas_ztrmv
+#cgo noescape cblas_ztrsm
+#cgo nocallback cblas_ztrsm
+#cgo noescape cblas_ztrsv
+#cgo nocallback cblas_ztrsv
+*/
+import "C"
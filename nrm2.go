@@ -0,0 +1,214 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+#include "cblas.h"
+*/
+import "C"
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Nrm2Mode selects the implementation of the nrm2 routines of Blas and
+// Implementation.
+type Nrm2Mode int32
+
+const (
+	// Nrm2Auto uses the portable implementations if a self-test run
+	// on the first call finds that any of the library's nrm2 routines
+	// overflow or underflow, or do not return +Inf for a vector with
+	// infinite elements. It is the default.
+	Nrm2Auto Nrm2Mode = iota
+
+	// Nrm2Library always uses the library's nrm2 routines.
+	Nrm2Library
+
+	// Nrm2Safe always uses the portable implementations.
+	Nrm2Safe
+)
+
+var (
+	nrm2Mode int32 // Holds a Nrm2Mode.
+
+	nrm2Once   sync.Once
+	nrm2Unsafe bool
+)
+
+// SetNrm2Mode sets the implementation used by the Snrm2, Dnrm2, Scnrm2
+// and Dznrm2 methods of Blas and Implementation.
+//
+// The portable implementations use Blue's algorithm, accumulating the
+// squares of small, medium and large elements separately with scaling
+// that prevents overflow and harmful underflow, as described by Anderson,
+// "Algorithm 978: Safe Scaling in the Level 1 BLAS", ACM Trans. Math.
+// Softw. 44(1), 2017. Some BLAS libraries compute the norm naively and
+// return +Inf for vectors with elements near 1e200, or zero for vectors
+// with elements near 1e-200.
+func SetNrm2Mode(m Nrm2Mode) {
+	if m < Nrm2Auto || m > Nrm2Safe {
+		panic("cblas: illegal nrm2 mode")
+	}
+	atomic.StoreInt32(&nrm2Mode, int32(m))
+}
+
+// LibraryNrm2Safe returns whether the nrm2 routines of the library
+// passed the self-test used by Nrm2Auto.
+func LibraryNrm2Safe() bool {
+	nrm2Once.Do(nrm2SelfTest)
+	return !nrm2Unsafe
+}
+
+// safeNrm2 returns whether the portable nrm2 implementations are used.
+func safeNrm2() bool {
+	switch Nrm2Mode(atomic.LoadInt32(&nrm2Mode)) {
+	case Nrm2Library:
+		return false
+	case Nrm2Safe:
+		return true
+	}
+	return !LibraryNrm2Safe()
+}
+
+// nrm2SelfTest checks the library's nrm2 routines with vectors whose
+// elements have magnitudes at which the squares overflow or underflow,
+// and with vectors of infinite elements of both signs.
+func nrm2SelfTest() {
+	ok := func(got, want float64) bool {
+		if math.IsInf(want, 1) {
+			return math.IsInf(got, 1)
+		}
+		return math.Abs(got-want) <= 1e-5*want
+	}
+	inf := math.Inf(1)
+	x := [2]float64{inf, -inf}
+	z := [2]complex128{complex(inf, 1), complex(1, -inf)}
+	xs := [2]float32{float32(inf), float32(-inf)}
+	zs := [2]complex64{complex(float32(inf), 1), complex(1, float32(-inf))}
+	if !ok(float64(C.cblas_dnrm2(2, (*C.double)(&x[0]), 1)), inf) ||
+		!ok(float64(C.cblas_dznrm2(2, unsafe.Pointer(&z[0]), 1)), inf) ||
+		!ok(float64(C.cblas_snrm2(2, (*C.float)(&xs[0]), 1)), inf) ||
+		!ok(float64(C.cblas_scnrm2(2, unsafe.Pointer(&zs[0]), 1)), inf) {
+		nrm2Unsafe = true
+	}
+	for _, v := range []float64{1e300, 1e200, 1e-200, 1e-300} {
+		x := [2]float64{v, v}
+		if !ok(float64(C.cblas_dnrm2(2, (*C.double)(&x[0]), 1)), math.Sqrt2*v) {
+			nrm2Unsafe = true
+		}
+		z := [2]complex128{complex(v, v), complex(v, v)}
+		if !ok(float64(C.cblas_dznrm2(2, unsafe.Pointer(&z[0]), 1)), 2*v) {
+			nrm2Unsafe = true
+		}
+	}
+	for _, v := range []float32{1e35, 1e25, 1e-25, 1e-35} {
+		x := [2]float32{v, v}
+		if !ok(float64(C.cblas_snrm2(2, (*C.float)(&x[0]), 1)), math.Sqrt2*float64(v)) {
+			nrm2Unsafe = true
+		}
+		z := [2]complex64{complex(v, v), complex(v, v)}
+		if !ok(float64(C.cblas_scnrm2(2, unsafe.Pointer(&z[0]), 1)), 2*float64(v)) {
+			nrm2Unsafe = true
+		}
+	}
+}
+
+// Blue's scaling constants for double precision from Anderson (2017).
+const (
+	blueTsml = 0x1p-511 // Threshold below which elements are small.
+	blueTbig = 0x1p486  // Threshold above which elements are big.
+	blueSsml = 0x1p537  // Scaling for small elements.
+	blueSbig = 0x1p-538 // Scaling for big elements.
+)
+
+// blue is a sum of squares accumulated by Blue's algorithm.
+type blue struct {
+	asml, amed, abig float64
+	big              bool // Whether a big element has been added.
+}
+
+func (b *blue) add(v float64) {
+	a := math.Abs(v)
+	switch {
+	case a > blueTbig:
+		a *= blueSbig
+		b.abig += a * a
+		b.big = true
+	case a < blueTsml:
+		// Small elements do not contribute once there are big ones.
+		if !b.big {
+			a *= blueSsml
+			b.asml += a * a
+		}
+	default:
+		// NaNs are accumulated here.
+		b.amed += a * a
+	}
+}
+
+// norm returns the square root of the sum.
+func (b *blue) norm() float64 {
+	switch {
+	case b.abig > 0:
+		// Medium elements are negligible unless they are NaN or
+		// there are very many of them.
+		sum := b.abig
+		if b.amed > 0 || math.IsNaN(b.amed) {
+			sum += b.amed * blueSbig * blueSbig
+		}
+		return math.Sqrt(sum) / blueSbig
+	case b.asml > 0:
+		if b.amed > 0 || math.IsNaN(b.amed) {
+			amed := math.Sqrt(b.amed)
+			asml := math.Sqrt(b.asml) / blueSsml
+			ymin, ymax := asml, amed
+			if asml > amed {
+				ymin, ymax = amed, asml
+			}
+			r := ymin / ymax
+			return ymax * math.Sqrt(1+r*r)
+		}
+		return math.Sqrt(b.asml) / blueSsml
+	}
+	return math.Sqrt(b.amed)
+}
+
+func snrm2(n int, x []float32, incX int) float32 {
+	var b blue
+	for i := 0; i < n; i++ {
+		b.add(float64(x[i*incX]))
+	}
+	return float32(b.norm())
+}
+
+func dnrm2(n int, x []float64, incX int) float64 {
+	var b blue
+	for i := 0; i < n; i++ {
+		b.add(x[i*incX])
+	}
+	return b.norm()
+}
+
+func scnrm2(n int, x []complex64, incX int) float32 {
+	var b blue
+	for i := 0; i < n; i++ {
+		b.add(float64(real(x[i*incX])))
+		b.add(float64(imag(x[i*incX])))
+	}
+	return float32(b.norm())
+}
+
+func dznrm2(n int, x []complex128, incX int) float64 {
+	var b blue
+	for i := 0; i < n; i++ {
+		b.add(real(x[i*incX]))
+		b.add(imag(x[i*incX]))
+	}
+	return b.norm()
+}
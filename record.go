@@ -0,0 +1,1089 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+// Type check assertion:
+var _ Backend = (*Recorder)(nil)
+
+// The log written by a Recorder starts with the magic string "cblasrec"
+// and a format version byte, followed by a record for each call. A record
+// holds
+//
+//   - the routine name, as a uvarint length followed by its bytes,
+//   - the number of arguments as a uvarint, followed by each argument
+//     value as it was before the call,
+//   - a byte holding one if the call panicked and zero otherwise,
+//
+// and, if the call returned,
+//
+//   - the number of arguments changed by the call as a uvarint, followed
+//     by the uvarint index and the new value of each, and
+//   - the number of results as a uvarint, followed by each result value.
+//
+// A value is a type byte, an index into logTypes, followed by its data. An
+// argument that is the same slice as an earlier argument is written as the
+// type byte aliasType followed by the uvarint index of that argument.
+// Integers are zig-zag varints, floating point numbers are little-endian
+// IEEE 754 values with the imaginary part of a complex number following
+// the real part, a slice is a uvarint holding zero for a nil slice and
+// otherwise one more than its length followed by its elements, a pointer
+// is a byte holding zero for nil and one otherwise followed by the value
+// pointed to, and arrays and structs are their elements in order.
+const (
+	logMagic   = "cblasrec"
+	logVersion = 1
+
+	aliasType = 0xff
+)
+
+// logTypes is the list of types of values in a log.
+var logTypes = []reflect.Type{
+	reflect.TypeOf(int(0)),
+	reflect.TypeOf(float32(0)),
+	reflect.TypeOf(float64(0)),
+	reflect.TypeOf(complex64(0)),
+	reflect.TypeOf(complex128(0)),
+	reflect.TypeOf(blas.Order(0)),
+	reflect.TypeOf(blas.Transpose(0)),
+	reflect.TypeOf(blas.Uplo(0)),
+	reflect.TypeOf(blas.Diag(0)),
+	reflect.TypeOf(blas.Side(0)),
+	reflect.TypeOf([]float32(nil)),
+	reflect.TypeOf([]float64(nil)),
+	reflect.TypeOf([]complex64(nil)),
+	reflect.TypeOf([]complex128(nil)),
+	reflect.TypeOf((*blas.SrotmParams)(nil)),
+	reflect.TypeOf((*blas.DrotmParams)(nil)),
+}
+
+// Recorder is a Backend that writes a log of every call made to the
+// Backend it wraps, with the values of the arguments before the call and
+// the values of the arguments changed and of the results after it. The
+// log may be read with a Reader and replayed against another backend to
+// reproduce a problem outside the program that made the calls.
+//
+// Recording a call copies all of its operands, so a Recorder is slow and
+// writes large logs when used with large matrices.
+type Recorder struct {
+	b Backend
+
+	mu  sync.Mutex
+	w   *bufio.Writer
+	buf bytes.Buffer
+	err error
+}
+
+// NewRecorder returns a Recorder calling b and writing the log to w.
+// The log is buffered; Flush must be called once the calls of interest
+// have been made.
+func NewRecorder(b Backend, w io.Writer) *Recorder {
+	r := &Recorder{b: b, w: bufio.NewWriter(w)}
+	r.buf.WriteString(logMagic)
+	r.buf.WriteByte(logVersion)
+	return r
+}
+
+// Flush writes any buffered log data to the underlying writer and returns
+// the first error encountered while writing the log.
+func (rec *Recorder) Flush() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.err == nil && rec.buf.Len() != 0 {
+		_, rec.err = rec.w.Write(rec.buf.Bytes())
+		rec.buf.Reset()
+	}
+	if rec.err == nil {
+		rec.err = rec.w.Flush()
+	}
+	return rec.err
+}
+
+// call calls the named method of the wrapped backend with args and logs
+// the call.
+func (rec *Recorder) call(name string, args ...interface{}) []reflect.Value {
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		in[i] = reflect.ValueOf(a)
+	}
+	before := cloneArgs(in)
+
+	// The call is logged after it returns, or panics, so that
+	// concurrent calls are not interleaved.
+	var out []reflect.Value
+	done := false
+	defer func() {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		if rec.err != nil {
+			return
+		}
+		b := &rec.buf
+		putString(b, name)
+		putUvarint(b, uint64(len(before)))
+		for i, v := range before {
+			if j := alias(before[:i], v); j >= 0 {
+				b.WriteByte(aliasType)
+				putUvarint(b, uint64(j))
+				continue
+			}
+			putValue(b, v)
+		}
+		if !done {
+			b.WriteByte(1)
+			return
+		}
+		b.WriteByte(0)
+		var changed []int
+		for i, v := range in {
+			if !identical(v, before[i]) {
+				changed = append(changed, i)
+			}
+		}
+		putUvarint(b, uint64(len(changed)))
+		for _, i := range changed {
+			putUvarint(b, uint64(i))
+			putValue(b, in[i])
+		}
+		putUvarint(b, uint64(len(out)))
+		for _, v := range out {
+			putValue(b, v)
+		}
+		if b.Len() >= 1<<16 {
+			_, rec.err = rec.w.Write(b.Bytes())
+			b.Reset()
+		}
+	}()
+	out = reflect.ValueOf(rec.b).MethodByName(name).Call(in)
+	done = true
+	return out
+}
+
+// identical returns whether a slice or pointer argument has the same
+// bits after a call as before it.
+func identical(v, before reflect.Value) bool {
+	var b1, b2 bytes.Buffer
+	switch v.Kind() {
+	case reflect.Slice, reflect.Ptr:
+		putValue(&b1, v)
+		putValue(&b2, before)
+		return bytes.Equal(b1.Bytes(), b2.Bytes())
+	}
+	return true
+}
+
+func putUvarint(b *bytes.Buffer, x uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], x)])
+}
+
+func putString(b *bytes.Buffer, s string) {
+	putUvarint(b, uint64(len(s)))
+	b.WriteString(s)
+}
+
+// putValue writes the type and data of v to b.
+func putValue(b *bytes.Buffer, v reflect.Value) {
+	for i, t := range logTypes {
+		if t == v.Type() {
+			b.WriteByte(byte(i))
+			putData(b, v)
+			return
+		}
+	}
+	panic(fmt.Sprintf("cblas: cannot log value of type %s", v.Type()))
+}
+
+// putData writes the data of v to b.
+func putData(b *bytes.Buffer, v reflect.Value) {
+	var buf [binary.MaxVarintLen64]byte
+	switch v.Kind() {
+	case reflect.Int:
+		b.Write(buf[:binary.PutVarint(buf[:], v.Int())])
+	case reflect.Float32:
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(v.Float())))
+		b.Write(buf[:4])
+	case reflect.Float64:
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(v.Float()))
+		b.Write(buf[:8])
+	case reflect.Complex64:
+		c := v.Complex()
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(real(c))))
+		binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(float32(imag(c))))
+		b.Write(buf[:8])
+	case reflect.Complex128:
+		c := v.Complex()
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(real(c)))
+		b.Write(buf[:8])
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(imag(c)))
+		b.Write(buf[:8])
+	case reflect.Slice:
+		if v.IsNil() {
+			putUvarint(b, 0)
+			return
+		}
+		putUvarint(b, uint64(v.Len())+1)
+		for i := 0; i < v.Len(); i++ {
+			putData(b, v.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			putData(b, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			putData(b, v.Field(i))
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			b.WriteByte(0)
+			return
+		}
+		b.WriteByte(1)
+		putData(b, v.Elem())
+	default:
+		panic(fmt.Sprintf("cblas: cannot log value of type %s", v.Type()))
+	}
+}
+
+// Call is a call read from a log written by a Recorder.
+type Call struct {
+	Routine string        // The name of the routine.
+	Args    []interface{} // The arguments before the call.
+
+	// Changed holds the arguments after the call, with nil for
+	// arguments that were not changed. Changed and Results are nil if
+	// the call panicked.
+	Changed []interface{}
+
+	Results []interface{} // The results of the call.
+
+	// Panicked is whether the call panicked.
+	Panicked bool
+}
+
+// Reader reads the calls of a log written by a Recorder.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// ErrBadLog is returned by a Reader for data that is not a valid log.
+var ErrBadLog = errors.New("cblas: malformed call log")
+
+// NewReader returns a Reader reading the log in r.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	var head [len(logMagic) + 1]byte
+	if _, err := io.ReadFull(br, head[:]); err != nil {
+		return nil, ErrBadLog
+	}
+	if string(head[:len(logMagic)]) != logMagic {
+		return nil, ErrBadLog
+	}
+	if head[len(logMagic)] != logVersion {
+		return nil, fmt.Errorf("cblas: unsupported call log version %d", head[len(logMagic)])
+	}
+	return &Reader{r: br}, nil
+}
+
+// Next returns the next call in the log. It returns io.EOF at the end of
+// the log.
+func (r *Reader) Next() (*Call, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil || n > 64 {
+		return nil, ErrBadLog
+	}
+	name := make([]byte, n)
+	if _, err := io.ReadFull(r.r, name); err != nil {
+		return nil, ErrBadLog
+	}
+	c := &Call{Routine: string(name)}
+	m, ok := reflect.TypeOf((*Backend)(nil)).Elem().MethodByName(c.Routine)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown routine %q", ErrBadLog, c.Routine)
+	}
+
+	n, err = binary.ReadUvarint(r.r)
+	if err != nil || n != uint64(m.Type.NumIn()) {
+		return nil, ErrBadLog
+	}
+	c.Args = make([]interface{}, n)
+	for i := range c.Args {
+		if t, err := r.r.ReadByte(); err == nil && t == aliasType {
+			j, err := binary.ReadUvarint(r.r)
+			if err != nil || j >= uint64(i) || m.Type.In(int(j)) != m.Type.In(i) {
+				return nil, ErrBadLog
+			}
+			c.Args[i] = c.Args[j]
+			continue
+		}
+		r.r.UnreadByte()
+		if c.Args[i], err = r.value(m.Type.In(i)); err != nil {
+			return nil, err
+		}
+	}
+
+	status, err := r.r.ReadByte()
+	if err != nil || status > 1 {
+		return nil, ErrBadLog
+	}
+	if status == 1 {
+		c.Panicked = true
+		return c, nil
+	}
+
+	n, err = binary.ReadUvarint(r.r)
+	if err != nil || n > uint64(len(c.Args)) {
+		return nil, ErrBadLog
+	}
+	c.Changed = make([]interface{}, len(c.Args))
+	for ; n > 0; n-- {
+		i, err := binary.ReadUvarint(r.r)
+		if err != nil || i >= uint64(len(c.Args)) {
+			return nil, ErrBadLog
+		}
+		if c.Changed[i], err = r.value(m.Type.In(int(i))); err != nil {
+			return nil, err
+		}
+	}
+
+	n, err = binary.ReadUvarint(r.r)
+	if err != nil || n != uint64(m.Type.NumOut()) {
+		return nil, ErrBadLog
+	}
+	c.Results = make([]interface{}, n)
+	for i := range c.Results {
+		if c.Results[i], err = r.value(m.Type.Out(i)); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// value reads a value of type want.
+func (r *Reader) value(want reflect.Type) (interface{}, error) {
+	t, err := r.r.ReadByte()
+	if err != nil || int(t) >= len(logTypes) || logTypes[t] != want {
+		return nil, ErrBadLog
+	}
+	v := reflect.New(logTypes[t]).Elem()
+	if err := r.data(v); err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// data reads the data of v.
+func (r *Reader) data(v reflect.Value) error {
+	var buf [16]byte
+	switch v.Kind() {
+	case reflect.Int:
+		x, err := binary.ReadVarint(r.r)
+		if err != nil {
+			return ErrBadLog
+		}
+		v.SetInt(x)
+		return nil
+	case reflect.Slice:
+		n, err := binary.ReadUvarint(r.r)
+		if err != nil || n > math.MaxInt32 {
+			return ErrBadLog
+		}
+		if n == 0 {
+			return nil
+		}
+		n--
+		// The slice is grown as it is read so that a corrupt length
+		// cannot cause a large allocation.
+		c := n
+		if c > 1<<16 {
+			c = 1 << 16
+		}
+		s := reflect.MakeSlice(v.Type(), 0, int(c))
+		e := reflect.New(v.Type().Elem()).Elem()
+		for i := uint64(0); i < n; i++ {
+			if err := r.data(e); err != nil {
+				return err
+			}
+			s = reflect.Append(s, e)
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.data(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if err := r.data(v.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Ptr:
+		b, err := r.r.ReadByte()
+		if err != nil || b > 1 {
+			return ErrBadLog
+		}
+		if b == 1 {
+			v.Set(reflect.New(v.Type().Elem()))
+			return r.data(v.Elem())
+		}
+		return nil
+	}
+	n := int(v.Type().Size())
+	if _, err := io.ReadFull(r.r, buf[:n]); err != nil {
+		return ErrBadLog
+	}
+	switch v.Kind() {
+	case reflect.Float32:
+		v.SetFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[:]))))
+	case reflect.Float64:
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(buf[:])))
+	case reflect.Complex64:
+		v.SetComplex(complex(
+			float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[:]))),
+			float64(math.Float32frombits(binary.LittleEndian.Uint32(buf[4:]))),
+		))
+	case reflect.Complex128:
+		v.SetComplex(complex(
+			math.Float64frombits(binary.LittleEndian.Uint64(buf[:])),
+			math.Float64frombits(binary.LittleEndian.Uint64(buf[8:])),
+		))
+	}
+	return nil
+}
+
+// Replay calls the routine of c with copies of its arguments on b and
+// compares the arguments changed and the results with those recorded,
+// returning the first difference exceeding the tolerance described for
+// Shadow, or nil if there is none. The Primary and Secondary of the
+// Divergence are the recorded value and the value from b. If the
+// recorded call panicked, the call on b is expected to panic and its
+// panic value is not compared. A panic in b is reported as a Divergence
+// if the recorded call did not panic.
+func (c *Call) Replay(b Backend, ulp float64) (d *Divergence) {
+	in := make([]reflect.Value, len(c.Args))
+	for i, a := range c.Args {
+		in[i] = reflect.ValueOf(a)
+	}
+	in = cloneArgs(in)
+	mags, depth := magnitudes(in)
+
+	var out []reflect.Value
+	if p := func() (p interface{}) {
+		defer func() { p = recover() }()
+		out = reflect.ValueOf(b).MethodByName(c.Routine).Call(in)
+		return nil
+	}(); p != nil || c.Panicked {
+		if (p != nil) == c.Panicked {
+			return nil
+		}
+		return &Divergence{Routine: c.Routine, Args: c.Args, Arg: -1, Secondary: p, Panicked: p != nil}
+	}
+
+	want := make([]reflect.Value, 0, len(c.Args)+len(c.Results))
+	for i, a := range c.Args {
+		if c.Changed[i] != nil {
+			a = c.Changed[i]
+		}
+		want = append(want, reflect.ValueOf(a))
+	}
+	for _, r := range c.Results {
+		want = append(want, reflect.ValueOf(r))
+	}
+	return diverge(c.Routine, c.Args, mags, depth, ulp, want, append(in, out...))
+}
+
+func (rec *Recorder) Srotg(a float32, b float32) (c float32, s float32, r float32, z float32) {
+	v := rec.call("Srotg", a, b)
+	return v[0].Interface().(float32), v[1].Interface().(float32), v[2].Interface().(float32), v[3].Interface().(float32)
+}
+
+func (rec *Recorder) Srotmg(d1 float32, d2 float32, b1 float32, b2 float32) (p *blas.SrotmParams, rd1 float32, rd2 float32, rb1 float32) {
+	v := rec.call("Srotmg", d1, d2, b1, b2)
+	return v[0].Interface().(*blas.SrotmParams), v[1].Interface().(float32), v[2].Interface().(float32), v[3].Interface().(float32)
+}
+
+func (rec *Recorder) Srotm(n int, x []float32, incX int, y []float32, incY int, p *blas.SrotmParams) {
+	rec.call("Srotm", n, x, incX, y, incY, p)
+}
+
+func (rec *Recorder) Drotg(a float64, b float64) (c float64, s float64, r float64, z float64) {
+	v := rec.call("Drotg", a, b)
+	return v[0].Interface().(float64), v[1].Interface().(float64), v[2].Interface().(float64), v[3].Interface().(float64)
+}
+
+func (rec *Recorder) Drotmg(d1 float64, d2 float64, b1 float64, b2 float64) (p *blas.DrotmParams, rd1 float64, rd2 float64, rb1 float64) {
+	v := rec.call("Drotmg", d1, d2, b1, b2)
+	return v[0].Interface().(*blas.DrotmParams), v[1].Interface().(float64), v[2].Interface().(float64), v[3].Interface().(float64)
+}
+
+func (rec *Recorder) Drotm(n int, x []float64, incX int, y []float64, incY int, p *blas.DrotmParams) {
+	rec.call("Drotm", n, x, incX, y, incY, p)
+}
+
+func (rec *Recorder) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
+	return rec.call("Cdotu", n, x, incX, y, incY)[0].Interface().(complex64)
+}
+
+func (rec *Recorder) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
+	return rec.call("Cdotc", n, x, incX, y, incY)[0].Interface().(complex64)
+}
+
+func (rec *Recorder) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
+	return rec.call("Zdotu", n, x, incX, y, incY)[0].Interface().(complex128)
+}
+
+func (rec *Recorder) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
+	return rec.call("Zdotc", n, x, incX, y, incY)[0].Interface().(complex128)
+}
+
+func (rec *Recorder) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
+	return rec.call("Sdsdot", n, alpha, x, incX, y, incY)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
+	return rec.call("Dsdot", n, x, incX, y, incY)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
+	return rec.call("Sdot", n, x, incX, y, incY)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	return rec.call("Ddot", n, x, incX, y, incY)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Snrm2(n int, x []float32, incX int) float32 {
+	return rec.call("Snrm2", n, x, incX)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Sasum(n int, x []float32, incX int) float32 {
+	return rec.call("Sasum", n, x, incX)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Dnrm2(n int, x []float64, incX int) float64 {
+	return rec.call("Dnrm2", n, x, incX)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Dasum(n int, x []float64, incX int) float64 {
+	return rec.call("Dasum", n, x, incX)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Scnrm2(n int, x []complex64, incX int) float32 {
+	return rec.call("Scnrm2", n, x, incX)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Scasum(n int, x []complex64, incX int) float32 {
+	return rec.call("Scasum", n, x, incX)[0].Interface().(float32)
+}
+
+func (rec *Recorder) Dznrm2(n int, x []complex128, incX int) float64 {
+	return rec.call("Dznrm2", n, x, incX)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Dzasum(n int, x []complex128, incX int) float64 {
+	return rec.call("Dzasum", n, x, incX)[0].Interface().(float64)
+}
+
+func (rec *Recorder) Isamax(n int, x []float32, incX int) int {
+	return rec.call("Isamax", n, x, incX)[0].Interface().(int)
+}
+
+func (rec *Recorder) Idamax(n int, x []float64, incX int) int {
+	return rec.call("Idamax", n, x, incX)[0].Interface().(int)
+}
+
+func (rec *Recorder) Icamax(n int, x []complex64, incX int) int {
+	return rec.call("Icamax", n, x, incX)[0].Interface().(int)
+}
+
+func (rec *Recorder) Izamax(n int, x []complex128, incX int) int {
+	return rec.call("Izamax", n, x, incX)[0].Interface().(int)
+}
+
+func (rec *Recorder) Sswap(n int, x []float32, incX int, y []float32, incY int) {
+	rec.call("Sswap", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Scopy(n int, x []float32, incX int, y []float32, incY int) {
+	rec.call("Scopy", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	rec.call("Saxpy", n, alpha, x, incX, y, incY)
+}
+
+func (rec *Recorder) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	rec.call("Dswap", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	rec.call("Dcopy", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	rec.call("Daxpy", n, alpha, x, incX, y, incY)
+}
+
+func (rec *Recorder) Cswap(n int, x []complex64, incX int, y []complex64, incY int) {
+	rec.call("Cswap", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Ccopy(n int, x []complex64, incX int, y []complex64, incY int) {
+	rec.call("Ccopy", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Caxpy(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
+	rec.call("Caxpy", n, alpha, x, incX, y, incY)
+}
+
+func (rec *Recorder) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
+	rec.call("Zswap", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Zcopy(n int, x []complex128, incX int, y []complex128, incY int) {
+	rec.call("Zcopy", n, x, incX, y, incY)
+}
+
+func (rec *Recorder) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	rec.call("Zaxpy", n, alpha, x, incX, y, incY)
+}
+
+func (rec *Recorder) Srot(n int, x []float32, incX int, y []float32, incY int, c float32, s float32) {
+	rec.call("Srot", n, x, incX, y, incY, c, s)
+}
+
+func (rec *Recorder) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
+	rec.call("Drot", n, x, incX, y, incY, c, s)
+}
+
+func (rec *Recorder) Sscal(n int, alpha float32, x []float32, incX int) {
+	rec.call("Sscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Dscal(n int, alpha float64, x []float64, incX int) {
+	rec.call("Dscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Cscal(n int, alpha complex64, x []complex64, incX int) {
+	rec.call("Cscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Zscal(n int, alpha complex128, x []complex128, incX int) {
+	rec.call("Zscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Csscal(n int, alpha float32, x []complex64, incX int) {
+	rec.call("Csscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Zdscal(n int, alpha float64, x []complex128, incX int) {
+	rec.call("Zdscal", n, alpha, x, incX)
+}
+
+func (rec *Recorder) Sgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	rec.call("Sgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Sgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	rec.call("Sgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Strmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	rec.call("Strmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Stbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	rec.call("Stbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Stpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	rec.call("Stpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Strsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	rec.call("Strsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Stbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	rec.call("Stbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Stpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	rec.call("Stpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	rec.call("Dgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Dgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	rec.call("Dgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Dtrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	rec.call("Dtrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Dtbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	rec.call("Dtbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Dtpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	rec.call("Dtpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Dtrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	rec.call("Dtrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Dtbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	rec.call("Dtbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Dtpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	rec.call("Dtpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Cgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	rec.call("Cgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Cgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	rec.call("Cgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Ctrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	rec.call("Ctrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ctbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	rec.call("Ctbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ctpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	rec.call("Ctpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Ctrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	rec.call("Ctrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ctbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	rec.call("Ctbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ctpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	rec.call("Ctpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Zgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	rec.call("Zgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Zgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	rec.call("Zgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Ztrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	rec.call("Ztrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ztbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	rec.call("Ztbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ztpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	rec.call("Ztpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Ztrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	rec.call("Ztrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ztbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	rec.call("Ztbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (rec *Recorder) Ztpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	rec.call("Ztpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (rec *Recorder) Ssymv(o blas.Order, ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	rec.call("Ssymv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Ssbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	rec.call("Ssbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Sspmv(o blas.Order, ul blas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) {
+	rec.call("Sspmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Sger(o blas.Order, m int, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	rec.call("Sger", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Ssyr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
+	rec.call("Ssyr", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (rec *Recorder) Sspr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32) {
+	rec.call("Sspr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (rec *Recorder) Ssyr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	rec.call("Ssyr2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Sspr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, ap []float32) {
+	rec.call("Sspr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (rec *Recorder) Dsymv(o blas.Order, ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	rec.call("Dsymv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Dsbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	rec.call("Dsbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Dspmv(o blas.Order, ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	rec.call("Dspmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	rec.call("Dger", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Dsyr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	rec.call("Dsyr", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (rec *Recorder) Dspr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
+	rec.call("Dspr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (rec *Recorder) Dsyr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	rec.call("Dsyr2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Dspr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, ap []float64) {
+	rec.call("Dspr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (rec *Recorder) Chemv(o blas.Order, ul blas.Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	rec.call("Chemv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Chbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	rec.call("Chbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Chpmv(o blas.Order, ul blas.Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	rec.call("Chpmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Cgeru(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	rec.call("Cgeru", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Cgerc(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	rec.call("Cgerc", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Cher(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) {
+	rec.call("Cher", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (rec *Recorder) Chpr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, ap []complex64) {
+	rec.call("Chpr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (rec *Recorder) Cher2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	rec.call("Cher2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Chpr2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) {
+	rec.call("Chpr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (rec *Recorder) Zhemv(o blas.Order, ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	rec.call("Zhemv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Zhbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	rec.call("Zhbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Zhpmv(o blas.Order, ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	rec.call("Zhpmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (rec *Recorder) Zgeru(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	rec.call("Zgeru", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Zgerc(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	rec.call("Zgerc", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Zher(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	rec.call("Zher", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (rec *Recorder) Zhpr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
+	rec.call("Zhpr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (rec *Recorder) Zher2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	rec.call("Zher2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (rec *Recorder) Zhpr2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	rec.call("Zhpr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (rec *Recorder) Sgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	rec.call("Sgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Ssymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	rec.call("Ssymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Ssyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+	rec.call("Ssyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Ssyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	rec.call("Ssyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Strmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	rec.call("Strmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Strsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	rec.call("Strsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Dgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	rec.call("Dgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Dsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	rec.call("Dsymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Dsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	rec.call("Dsyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Dsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	rec.call("Dsyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Dtrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	rec.call("Dtrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Dtrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	rec.call("Dtrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Cgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	rec.call("Cgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Csymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	rec.call("Csymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Csyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) {
+	rec.call("Csyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Csyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	rec.call("Csyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Ctrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	rec.call("Ctrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Ctrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	rec.call("Ctrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Zgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	rec.call("Zgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Zsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	rec.call("Zsymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Zsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	rec.call("Zsyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Zsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	rec.call("Zsyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Ztrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	rec.call("Ztrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Ztrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	rec.call("Ztrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (rec *Recorder) Chemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	rec.call("Chemm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Cherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) {
+	rec.call("Cherk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Cher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
+	rec.call("Cher2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Zhemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	rec.call("Zhemm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (rec *Recorder) Zherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	rec.call("Zherk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (rec *Recorder) Zher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	rec.call("Zher2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
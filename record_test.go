@@ -0,0 +1,115 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// TestRecordReplay tests that calls of each level recorded by a Recorder
+// are read back with their arguments and results and replay without
+// divergence on the same backend, and with divergence on a perturbed one.
+func TestRecordReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(Blas{}, &buf)
+
+	x := []float64{1, 2, 3, 4}
+	y := []float64{4, 3, 2, 1}
+	dot := rec.Ddot(4, x, 1, y, 1)
+	rec.Daxpy(4, 0.5, x, 1, y, 1)
+	zx := []complex128{1 + 2i, 3 - 1i}
+	zdot := rec.Zdotc(2, zx, 1, zx, 1)
+	p, _, _, _ := rec.Drotmg(1, 2, 3, 4)
+
+	a := []float64{1, 2, 3, 4, 5, 6}
+	rec.Dgemv(blas.RowMajor, blas.Trans, 2, 3, 1, a, 3, []float64{1, -1}, 1, 0, x[:3], 1)
+	c := make([]float64, 4)
+	rec.Dgemm(blas.ColMajor, blas.NoTrans, blas.Trans, 2, 2, 3, 1, a, 2, a, 2, 0, c, 2)
+	ct := []complex64{2, 1i, 0, 4}
+	cb := []complex64{1, 2, 3, 4}
+	rec.Ctrsm(blas.RowMajor, blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, 2, 2, 1, ct, 2, cb, 2)
+
+	func() {
+		defer func() { recover() }()
+		rec.Dscal(-1, 2, x, 1)
+	}()
+	if err := rec.Flush(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var calls []*Call
+	for {
+		call, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		calls = append(calls, call)
+	}
+
+	routines := []string{"Ddot", "Daxpy", "Zdotc", "Drotmg", "Dgemv", "Dgemm", "Ctrsm", "Dscal"}
+	if len(calls) != len(routines) {
+		t.Fatalf("unexpected number of calls: got %d want %d", len(calls), len(routines))
+	}
+	for i, call := range calls {
+		if call.Routine != routines[i] {
+			t.Errorf("call %d: unexpected routine: got %s want %s", i, call.Routine, routines[i])
+		}
+		if d := call.Replay(Blas{}, 0); d != nil {
+			t.Errorf("call %d: unexpected divergence: %v", i, d)
+		}
+	}
+
+	for _, test := range []struct {
+		call *Call
+		args []interface{}
+		got  []interface{}
+		want []interface{}
+	}{
+		{call: calls[0], args: []interface{}{4, []float64{1, 2, 3, 4}, 1, []float64{4, 3, 2, 1}, 1}, got: calls[0].Results, want: []interface{}{dot}},
+		{call: calls[1], args: []interface{}{4, 0.5, []float64{1, 2, 3, 4}, 1, []float64{4, 3, 2, 1}, 1}, got: calls[1].Changed, want: []interface{}{nil, nil, nil, nil, y, nil}},
+		{call: calls[2], got: calls[2].Results, want: []interface{}{zdot}},
+		{call: calls[3], got: calls[3].Results[:1], want: []interface{}{p}},
+		{call: calls[5], got: calls[5].Changed[12:13], want: []interface{}{c}},
+		{call: calls[6], got: calls[6].Changed[10:11], want: []interface{}{cb}},
+	} {
+		if test.args != nil && !reflect.DeepEqual(test.call.Args, test.args) {
+			t.Errorf("%s: unexpected arguments: got %v want %v", test.call.Routine, test.call.Args, test.args)
+		}
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s: unexpected values: got %v want %v", test.call.Routine, test.got, test.want)
+		}
+	}
+	if call := calls[len(calls)-1]; !call.Panicked || call.Changed != nil || call.Results != nil {
+		t.Errorf("unexpected panicked call: %+v", call)
+	}
+	// The aliased operands of Zdotc are read back as the same slice.
+	if zc := calls[2]; reflect.ValueOf(zc.Args[1]).Pointer() != reflect.ValueOf(zc.Args[3]).Pointer() {
+		t.Errorf("aliased arguments of Zdotc read back as different slices")
+	}
+
+	if d := calls[1].Replay(perturbed{rel: 1e-8, i: 2}, 0); d == nil || d.Arg != 4 || d.Index != 2 {
+		t.Errorf("unexpected divergence for perturbed Daxpy: %v", d)
+	}
+}
+
+func TestReaderBadLog(t *testing.T) {
+	for _, log := range []string{"", "cblas", "cblasrex\x01"} {
+		if _, err := NewReader(bytes.NewReader([]byte(log))); err != ErrBadLog {
+			t.Errorf("unexpected error for %q: got %v want %v", log, err, ErrBadLog)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gonum/blas"
+)
+
+// Backend is a complete implementation of the blas interfaces.
+type Backend interface {
+	blas.Float32
+	blas.Float64
+	blas.Complex64
+	blas.Complex128
+}
+
+// Type check assertion:
+var _ Backend = Blas{}
+
+// EnvBackend is the environment variable naming the backend returned by
+// Default.
+const EnvBackend = "CBLAS_BACKEND"
+
+// DefaultBackend is the name of the backend returned by Default when
+// EnvBackend is unset or empty. It is the Blas type of this package.
+const DefaultBackend = "cgo"
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]func() (Backend, error){
+		DefaultBackend: func() (Backend, error) { return Blas{}, nil },
+	}
+
+	defaultOnce    sync.Once
+	defaultBackend Backend
+	defaultErr     error
+)
+
+// Register makes a backend available under the given name. The open
+// function is called by Open, and by Default if the backend is selected,
+// to construct the backend; it returns an error if the backend is not
+// available, for example because a shared library could not be loaded.
+// Register is intended to be called from init functions, and panics if
+// open is nil or the name is empty or already registered.
+//
+// A package providing a backend registers it from its init function, and
+// a program makes it available by importing the package, if only for its
+// side effects. The Shadow and Recorder backends wrap backends chosen by
+// the program, so they are not registered by this package; a program
+// registers them with the backends they wrap:
+//
+//	func init() {
+//		cblas.Register("shadow", func() (cblas.Backend, error) {
+//			ref, err := cblas.Open("reference")
+//			if err != nil {
+//				return nil, err
+//			}
+//			return cblas.Shadow{Primary: cblas.Blas{}, Secondary: ref}, nil
+//		})
+//	}
+func Register(name string, open func() (Backend, error)) {
+	if name == "" {
+		panic("cblas: empty backend name")
+	}
+	if open == nil {
+		panic("cblas: nil backend constructor")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[name]; dup {
+		panic("cblas: backend " + name + " registered twice")
+	}
+	registry[name] = open
+}
+
+// Backends returns the sorted names of the registered backends.
+func Backends() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ErrUnknownBackend is returned by Open for a name that is not registered.
+var ErrUnknownBackend = errors.New("cblas: unknown backend")
+
+// Open returns a new instance of the named backend.
+func Open(name string) (Backend, error) {
+	registryMu.Lock()
+	open, ok := registry[name]
+	registryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w %q (registered: %s)", ErrUnknownBackend, name, strings.Join(Backends(), ", "))
+	}
+	b, err := open()
+	if err != nil {
+		return nil, fmt.Errorf("cblas: backend %q unavailable: %w", name, err)
+	}
+	return b, nil
+}
+
+// Default returns the backend named by the CBLAS_BACKEND environment
+// variable, or the DefaultBackend if it is unset or empty. The backend is
+// opened on the first call and the same backend is returned by later
+// calls, so backends registered after the first call cannot be selected.
+// Default panics if the requested backend is not registered or cannot be
+// opened.
+//
+// A backend registered by this package is opened when the package is
+// initialized. Backends of other packages are registered after this
+// package is initialized, so a program selecting one should check
+// BackendError early in main to report a bad selection at startup.
+func Default() Backend {
+	defaultOnce.Do(openDefault)
+	if defaultErr != nil {
+		panic(defaultErr.Error())
+	}
+	return defaultBackend
+}
+
+// BackendError returns the error, if any, in opening the backend selected
+// by the EnvBackend environment variable. It opens the backend as Default
+// does if it has not been opened, so it should be called once the
+// packages registering backends have been initialized, as from main.
+func BackendError() error {
+	defaultOnce.Do(openDefault)
+	return defaultErr
+}
+
+// openDefault opens the backend selected by CBLAS_BACKEND.
+func openDefault() {
+	b, err := Open(defaultName())
+	if err != nil {
+		defaultErr = fmt.Errorf("%w (selected by %s)", err, EnvBackend)
+		return
+	}
+	defaultBackend = b
+}
+
+// defaultName returns the name of the backend selected by CBLAS_BACKEND.
+func defaultName() string {
+	name := os.Getenv(EnvBackend)
+	if name == "" {
+		name = DefaultBackend
+	}
+	return name
+}
+
+func init() {
+	registryMu.Lock()
+	_, ok := registry[defaultName()]
+	registryMu.Unlock()
+	if ok {
+		Default()
+	}
+}
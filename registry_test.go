@@ -0,0 +1,78 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	errClosed := errors.New("closed")
+	Register("test-ok", func() (Backend, error) { return Shadow{Primary: Blas{}, Secondary: Blas{}}, nil })
+	Register("test-fail", func() (Backend, error) { return nil, errClosed })
+
+	b, err := Open("test-ok")
+	if err != nil {
+		t.Fatalf("unexpected error opening registered backend: %v", err)
+	}
+	if _, ok := b.(Shadow); !ok {
+		t.Errorf("unexpected backend type %T", b)
+	}
+	if b, err := Open(DefaultBackend); err != nil || b != (Blas{}) {
+		t.Errorf("unexpected default backend: %v, %v", b, err)
+	}
+
+	_, err = Open("test-fail")
+	if !errors.Is(err, errClosed) {
+		t.Errorf("unexpected error for unavailable backend: got %v want %v", err, errClosed)
+	}
+	_, err = Open("test-missing")
+	if !errors.Is(err, ErrUnknownBackend) {
+		t.Errorf("unexpected error for unknown backend: got %v want %v", err, ErrUnknownBackend)
+	}
+
+	names := Backends()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("backend names not sorted: %v", names)
+	}
+	for _, want := range []string{DefaultBackend, "test-fail", "test-ok"} {
+		if i := sort.SearchStrings(names, want); i == len(names) || names[i] != want {
+			t.Errorf("backend %q missing from %v", want, names)
+		}
+	}
+
+	for _, test := range []struct {
+		name string
+		open func() (Backend, error)
+	}{
+		{"test-ok", func() (Backend, error) { return Blas{}, nil }},
+		{"", func() (Backend, error) { return Blas{}, nil }},
+		{"test-nil", nil},
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("expected panic registering %q", test.name)
+				}
+			}()
+			Register(test.name, test.open)
+		}()
+	}
+}
+
+func TestBackendError(t *testing.T) {
+	if os.Getenv(EnvBackend) != "" {
+		t.Skipf("%s is set", EnvBackend)
+	}
+	if err := BackendError(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if Default() != (Blas{}) {
+		t.Errorf("unexpected default backend %T", Default())
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+/*
+static int repro_get(void *f) { return ((int (*)(void))f)(); }
+static void repro_set(void *f, int n) { ((void (*)(int))f)(n); }
+static long long repro_get_long(void *f) { return ((long long (*)(void))f)(); }
+static void repro_set_long(void *f, long long n) { ((void (*)(long long))f)(n); }
+static int repro_cbwr(void *f, int mode) { return ((int (*)(int))f)(mode); }
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// mklCBWRCompatible is MKL_CBWR_COMPATIBLE from mkl_cbwr.h, selecting
+// the code path that gives the same results on all processors.
+const mklCBWRCompatible = 3
+
+// ErrNotReproducible is returned by SetReproducible when the threading of
+// the library cannot be controlled.
+var ErrNotReproducible = errors.New("cblas: cannot make library results reproducible")
+
+var repro struct {
+	sync.Mutex
+	on      bool
+	threads int // The number of threads used before reproducible mode.
+}
+
+// SetReproducible sets whether the library is used in reproducible mode.
+//
+// Multithreaded BLAS libraries divide the reductions of routines such as
+// Ddot and Dgemm between threads, so the order of summation, and so the
+// rounding of the result, depends on the number of threads and on their
+// scheduling. In reproducible mode the library is pinned to a single
+// thread, giving bitwise identical results between runs on the same
+// processor and library regardless of GOMAXPROCS and the configured
+// thread count. When the library is MKL its conditional numerical
+// reproducibility mode is also set to use the same code path on all
+// processors; MKL only allows this before its first call, and the mode is
+// not reverted by SetReproducible(false). Leaving reproducible mode
+// restores the previous number of threads.
+//
+// The threading of OpenBLAS, BLIS and MKL is controlled. The Netlib
+// reference BLAS is single threaded and always reproducible. For OpenBLAS
+// built with OpenMP threading, which sets the number of threads per OS
+// thread, and for other libraries SetReproducible returns
+// ErrNotReproducible; the OpenMP build may instead be pinned by setting
+// OMP_NUM_THREADS=1 in the environment before the program starts. The library must
+// not be reconfigured by other means while reproducible mode is set.
+func SetReproducible(on bool) error {
+	repro.Lock()
+	defer repro.Unlock()
+	if on == repro.on {
+		return nil
+	}
+
+	switch l := Info(); l.Vendor {
+	case "OpenBLAS":
+		// The thread count of an OpenMP build is an OpenMP internal
+		// control variable, set for the calling OS thread only, so it
+		// cannot be pinned for the goroutines of the program.
+		if l.Parallel == "openmp" {
+			return fmt.Errorf("%w: %s", ErrNotReproducible, l)
+		}
+		get, set := symbol("openblas_get_num_threads"), symbol("openblas_set_num_threads")
+		if get == nil || set == nil {
+			return ErrNotReproducible
+		}
+		if on {
+			repro.threads = int(C.repro_get(get))
+			C.repro_set(set, 1)
+		} else {
+			C.repro_set(set, C.int(repro.threads))
+		}
+	case "BLIS":
+		get, set := symbol("bli_thread_get_num_threads"), symbol("bli_thread_set_num_threads")
+		if get == nil || set == nil {
+			return ErrNotReproducible
+		}
+		if on {
+			// The BLIS dim_t may be 32 or 64 bits wide.
+			repro.threads = int(int32(C.repro_get_long(get)))
+			C.repro_set_long(set, 1)
+		} else {
+			C.repro_set_long(set, C.longlong(repro.threads))
+		}
+	case "MKL":
+		get, set := symbol("MKL_Get_Max_Threads"), symbol("MKL_Set_Num_Threads")
+		if get == nil || set == nil {
+			return ErrNotReproducible
+		}
+		if on {
+			if cbwr := symbol("MKL_CBWR_Set"); cbwr != nil {
+				if r := C.repro_cbwr(cbwr, mklCBWRCompatible); r != 0 {
+					return fmt.Errorf("%w: MKL_CBWR_Set failed with status %d", ErrNotReproducible, r)
+				}
+			}
+			repro.threads = int(C.repro_get(get))
+			C.repro_set(set, 1)
+		} else {
+			C.repro_set(set, C.int(repro.threads))
+		}
+	case "Netlib":
+	default:
+		return fmt.Errorf("%w: %s", ErrNotReproducible, l)
+	}
+	repro.on = on
+	return nil
+}
+
+// Reproducible returns whether the library is used in reproducible mode.
+func Reproducible() bool {
+	repro.Lock()
+	defer repro.Unlock()
+	return repro.on
+}
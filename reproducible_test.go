@@ -0,0 +1,62 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+func TestSetReproducible(t *testing.T) {
+	l := Info()
+	err := SetReproducible(true)
+	if err != nil {
+		if !errors.Is(err, ErrNotReproducible) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if Reproducible() {
+			t.Errorf("reproducible mode set after error")
+		}
+		if l.Vendor == "Netlib" {
+			t.Errorf("unexpected error for %s: %v", l, err)
+		}
+		t.Skipf("library cannot be made reproducible: %v", err)
+	}
+	defer func() {
+		if err := SetReproducible(false); err != nil {
+			t.Errorf("unexpected error leaving reproducible mode: %v", err)
+		}
+		if Reproducible() {
+			t.Errorf("reproducible mode still set")
+		}
+	}()
+	if !Reproducible() {
+		t.Fatalf("reproducible mode not set")
+	}
+	if err := SetReproducible(true); err != nil {
+		t.Errorf("unexpected error setting reproducible mode twice: %v", err)
+	}
+
+	// Products large enough to be divided between threads give bitwise
+	// identical results on each call.
+	const n = 300
+	rnd := rand.New(rand.NewSource(1))
+	a, b := random(rnd, n*n), random(rnd, n*n)
+	var want []float64
+	for i := 0; i < 4; i++ {
+		c := make([]float64, n*n)
+		Blas{}.Dgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, b, n, 0, c, n)
+		if i == 0 {
+			want = c
+			continue
+		}
+		if !sameFloat64s(c, want) {
+			t.Fatalf("call %d: results differ in reproducible mode", i)
+		}
+	}
+}
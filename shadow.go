@@ -0,0 +1,956 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"reflect"
+	"strings"
+
+	"github.com/gonum/blas"
+)
+
+// Type check assertion:
+var _ Backend = Shadow{}
+
+// Shadow is a Backend that performs every call with both a Primary and a
+// Secondary backend and compares their results. The Primary operates on
+// the arguments passed, so callers see its results, and the Secondary
+// operates on copies of the slice and parameter arguments made before the
+// call. Shadow is intended for validating one BLAS library against another
+// and is much slower than either.
+//
+// After the call every slice argument and result of the Secondary is
+// compared with that of the Primary. Integer values must be equal.
+// Floating point elements may differ by
+//
+//	ULP · ε · d · s
+//
+// where ε is the machine epsilon of the element type, d is the largest
+// dimension or band width argument of the call, and s is the
+// larger of the largest magnitude of the elements compared and the product
+// of the largest magnitudes of the other slice arguments and of the
+// floating point scalars, each taken to be at least one. This is the form
+// of the normwise error bound of the BLAS reductions and products; the
+// error of the triangular solves also depends on the condition of the
+// matrix, so ULP may need to be raised when they are used with
+// ill-conditioned matrices.
+type Shadow struct {
+	Primary, Secondary Backend
+
+	// ULP scales the tolerance. A zero ULP is taken to be 16.
+	ULP float64
+
+	// Report is called for each divergence found. If Report is nil
+	// Shadow panics with the divergence.
+	Report func(*Divergence)
+}
+
+// Divergence describes a difference between the results of the backends
+// of a Shadow.
+type Divergence struct {
+	Routine string        // The name of the routine.
+	Args    []interface{} // The arguments of the call, as passed to the Secondary.
+
+	// Arg is the index of the differing argument, the index of the
+	// differing result plus len(Args), or -1 if only one call panicked.
+	Arg int
+
+	// Index is the index of the first differing element of a slice,
+	// and zero otherwise.
+	Index int
+
+	// Primary and Secondary are the differing values. If the Primary
+	// or the Secondary panicked, it holds the panic value.
+	Primary, Secondary interface{}
+
+	// Tol is the tolerance the difference exceeded.
+	Tol float64
+
+	// Panicked is whether the Secondary panicked. If Arg is -1 and
+	// Panicked is false, the Primary panicked.
+	Panicked bool
+}
+
+func (d *Divergence) Error() string {
+	call := d.Routine + "(" + describeArgs(d.Args) + ")"
+	switch {
+	case d.Panicked:
+		return fmt.Sprintf("cblas: %s: secondary panicked: %v", call, d.Secondary)
+	case d.Arg < 0 && d.Primary == nil:
+		return fmt.Sprintf("cblas: %s: primary panicked", call)
+	case d.Arg < 0:
+		return fmt.Sprintf("cblas: %s: primary panicked: %v", call, d.Primary)
+	}
+	what := fmt.Sprintf("result %d", d.Arg-len(d.Args)+1)
+	if d.Arg < len(d.Args) {
+		what = fmt.Sprintf("argument %d", d.Arg+1)
+	}
+	if d.Arg < len(d.Args) && reflect.ValueOf(d.Args[d.Arg]).Kind() == reflect.Slice {
+		what += fmt.Sprintf(" element %d", d.Index)
+	}
+	return fmt.Sprintf("cblas: %s: %s differs: primary %v, secondary %v (tolerance %g)",
+		call, what, d.Primary, d.Secondary, d.Tol)
+}
+
+// describeArgs formats args with slices shown by type and length.
+func describeArgs(args []interface{}) string {
+	s := make([]string, len(args))
+	for i, a := range args {
+		v := reflect.ValueOf(a)
+		switch v.Kind() {
+		case reflect.Slice:
+			s[i] = fmt.Sprintf("%s(len=%d)", v.Type(), v.Len())
+		case reflect.Ptr:
+			if v.IsNil() {
+				s[i] = "nil"
+			} else {
+				s[i] = fmt.Sprintf("&%+v", v.Elem().Interface())
+			}
+		default:
+			s[i] = fmt.Sprint(a)
+		}
+	}
+	return strings.Join(s, ", ")
+}
+
+// call calls the named method of the Primary with args and of the
+// Secondary with copies of args, compares their results and returns the
+// results of the Primary. If the Primary panics, the panic is reported
+// unless the Secondary also panicked, and is then propagated.
+func (sh Shadow) call(name string, args ...interface{}) []reflect.Value {
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		in[i] = reflect.ValueOf(a)
+	}
+	cin := cloneArgs(in)
+	cargs := make([]interface{}, len(args))
+	for i, v := range cin {
+		cargs[i] = v.Interface()
+	}
+	// The magnitudes of the operands are taken before the Primary can
+	// modify them.
+	mags, depth := magnitudes(cin)
+
+	var out, cout []reflect.Value
+	pp := func() (p interface{}) {
+		defer func() { p = recover() }()
+		out = reflect.ValueOf(sh.Primary).MethodByName(name).Call(in)
+		return nil
+	}()
+	sp := func() (p interface{}) {
+		defer func() { p = recover() }()
+		cout = reflect.ValueOf(sh.Secondary).MethodByName(name).Call(cin)
+		return nil
+	}()
+	switch {
+	case pp != nil:
+		if sp == nil {
+			sh.report(&Divergence{Routine: name, Args: cargs, Arg: -1, Primary: pp})
+		}
+		panic(pp)
+	case sp != nil:
+		sh.report(&Divergence{Routine: name, Args: cargs, Arg: -1, Secondary: sp, Panicked: true})
+		return out
+	}
+
+	d := diverge(name, cargs, mags, depth, sh.ULP, append(in, out...), append(cin, cout...))
+	if d != nil {
+		sh.report(d)
+	}
+	return out
+}
+
+// magnitudes returns the largest magnitude of each of the operands vs of a
+// call and the largest of its dimension and band width operands.
+func magnitudes(vs []reflect.Value) (mags []float64, depth int) {
+	mags = make([]float64, len(vs))
+	depth = 1
+	for i, v := range vs {
+		mags[i] = maxAbs(v)
+		if isDim(vs, i) && v.Int() > int64(depth) {
+			depth = int(v.Int())
+		}
+	}
+	return mags, depth
+}
+
+// isDim returns whether the ith operand of vs is a dimension or band
+// width. These are the operands of type int other than the leading
+// dimensions and increments, which follow the slice they describe;
+// the orders, transposes and other enumerations have their own types.
+func isDim(vs []reflect.Value, i int) bool {
+	if vs[i].Type() != intType {
+		return false
+	}
+	return i == 0 || vs[i-1].Kind() != reflect.Slice
+}
+
+var intType = reflect.TypeOf(0)
+
+// diverge compares the arguments followed by the results, p and q, of two
+// calls of the named routine with the arguments args, returning the first
+// difference exceeding the tolerance described for Shadow, or nil if there
+// is none. The magnitudes of the arguments and the depth of the call are
+// given by mags and depth.
+func diverge(name string, args []interface{}, mags []float64, depth int, ulp float64, p, q []reflect.Value) *Divergence {
+	if ulp == 0 {
+		ulp = 16
+	}
+	for i := range p {
+		scale := 1.0
+		for j, m := range mags {
+			if j != i && m != 0 {
+				scale *= math.Max(m, 1)
+			}
+		}
+		scale = math.Max(scale, math.Max(maxAbs(p[i]), maxAbs(q[i])))
+		idx, tol, ok := equal(p[i], q[i], ulp*float64(depth)*scale)
+		if ok {
+			continue
+		}
+		d := &Divergence{Routine: name, Args: args, Arg: i, Index: idx, Tol: tol}
+		if p[i].Kind() == reflect.Slice {
+			d.Primary, d.Secondary = p[i].Index(idx).Interface(), q[i].Index(idx).Interface()
+		} else {
+			d.Primary, d.Secondary = p[i].Interface(), q[i].Interface()
+		}
+		return d
+	}
+	return nil
+}
+
+func (sh Shadow) report(d *Divergence) {
+	if sh.Report == nil {
+		panic(d.Error())
+	}
+	sh.Report(d)
+}
+
+// cloneArgs returns copies of the arguments vs made by clone. Slice
+// arguments that are the same slice, as in Ddot(n, x, 1, x, 1), are
+// copied once so that the copies are the same slice.
+func cloneArgs(vs []reflect.Value) []reflect.Value {
+	c := make([]reflect.Value, len(vs))
+	for i, v := range vs {
+		if j := alias(vs[:i], v); j >= 0 {
+			c[i] = c[j]
+		} else {
+			c[i] = clone(v)
+		}
+	}
+	return c
+}
+
+// alias returns the index of a slice in vs that is the same slice as v,
+// or -1 if there is none.
+func alias(vs []reflect.Value, v reflect.Value) int {
+	if v.Kind() != reflect.Slice || v.Len() == 0 {
+		return -1
+	}
+	for i, u := range vs {
+		if u.Kind() == reflect.Slice && u.Type() == v.Type() && u.Len() == v.Len() && u.Pointer() == v.Pointer() {
+			return i
+		}
+	}
+	return -1
+}
+
+// clone returns a copy of v if it is a slice or a pointer to a struct,
+// and v otherwise.
+func clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		return c
+	}
+	return v
+}
+
+// maxAbs returns the largest magnitude of the floating point elements of v.
+func maxAbs(v reflect.Value) float64 {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.Abs(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return cmplx.Abs(v.Complex())
+	case reflect.Slice, reflect.Array, reflect.Struct:
+		var m float64
+		n := v.Len
+		if v.Kind() == reflect.Struct {
+			n = v.NumField
+		}
+		for i := 0; i < n(); i++ {
+			var e reflect.Value
+			if v.Kind() == reflect.Struct {
+				e = v.Field(i)
+			} else {
+				e = v.Index(i)
+			}
+			if a := maxAbs(e); a > m || math.IsNaN(a) {
+				m = a
+			}
+		}
+		return m
+	case reflect.Ptr:
+		if v.IsNil() {
+			return 0
+		}
+		return maxAbs(v.Elem())
+	}
+	return 0
+}
+
+// equal returns whether p and q are equal within tol times the machine
+// epsilon of their element type. If they are not, it returns the index
+// of the first differing element of a slice and the tolerance used.
+func equal(p, q reflect.Value, tol float64) (idx int, eps float64, ok bool) {
+	switch p.Kind() {
+	case reflect.Float32:
+		eps = tol * 0x1p-23
+		return 0, eps, within(p.Float(), q.Float(), eps)
+	case reflect.Float64:
+		eps = tol * 0x1p-52
+		return 0, eps, within(p.Float(), q.Float(), eps)
+	case reflect.Complex64:
+		eps = tol * 0x1p-23
+		a, b := p.Complex(), q.Complex()
+		return 0, eps, within(real(a), real(b), eps) && within(imag(a), imag(b), eps)
+	case reflect.Complex128:
+		eps = tol * 0x1p-52
+		a, b := p.Complex(), q.Complex()
+		return 0, eps, within(real(a), real(b), eps) && within(imag(a), imag(b), eps)
+	case reflect.Slice:
+		if p.Len() != q.Len() {
+			return 0, 0, false
+		}
+		for i := 0; i < p.Len(); i++ {
+			if _, eps, ok := equal(p.Index(i), q.Index(i), tol); !ok {
+				return i, eps, false
+			}
+		}
+		return 0, 0, true
+	case reflect.Ptr:
+		if p.IsNil() || q.IsNil() {
+			return 0, 0, p.IsNil() == q.IsNil()
+		}
+		return equal(p.Elem(), q.Elem(), tol)
+	case reflect.Struct, reflect.Array:
+		n := p.Len
+		if p.Kind() == reflect.Struct {
+			n = p.NumField
+		}
+		for i := 0; i < n(); i++ {
+			var a, b reflect.Value
+			if p.Kind() == reflect.Struct {
+				a, b = p.Field(i), q.Field(i)
+			} else {
+				a, b = p.Index(i), q.Index(i)
+			}
+			if _, eps, ok := equal(a, b, tol); !ok {
+				return 0, eps, false
+			}
+		}
+		return 0, 0, true
+	}
+	return 0, 0, p.Interface() == q.Interface()
+}
+
+// within returns whether a and b differ by no more than tol. NaNs are
+// equal to each other.
+func within(a, b, tol float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= tol
+}
+
+func (sh Shadow) Srotg(a float32, b float32) (c float32, s float32, r float32, z float32) {
+	v := sh.call("Srotg", a, b)
+	return v[0].Interface().(float32), v[1].Interface().(float32), v[2].Interface().(float32), v[3].Interface().(float32)
+}
+
+func (sh Shadow) Srotmg(d1 float32, d2 float32, b1 float32, b2 float32) (p *blas.SrotmParams, rd1 float32, rd2 float32, rb1 float32) {
+	v := sh.call("Srotmg", d1, d2, b1, b2)
+	return v[0].Interface().(*blas.SrotmParams), v[1].Interface().(float32), v[2].Interface().(float32), v[3].Interface().(float32)
+}
+
+func (sh Shadow) Srotm(n int, x []float32, incX int, y []float32, incY int, p *blas.SrotmParams) {
+	sh.call("Srotm", n, x, incX, y, incY, p)
+}
+
+func (sh Shadow) Drotg(a float64, b float64) (c float64, s float64, r float64, z float64) {
+	v := sh.call("Drotg", a, b)
+	return v[0].Interface().(float64), v[1].Interface().(float64), v[2].Interface().(float64), v[3].Interface().(float64)
+}
+
+func (sh Shadow) Drotmg(d1 float64, d2 float64, b1 float64, b2 float64) (p *blas.DrotmParams, rd1 float64, rd2 float64, rb1 float64) {
+	v := sh.call("Drotmg", d1, d2, b1, b2)
+	return v[0].Interface().(*blas.DrotmParams), v[1].Interface().(float64), v[2].Interface().(float64), v[3].Interface().(float64)
+}
+
+func (sh Shadow) Drotm(n int, x []float64, incX int, y []float64, incY int, p *blas.DrotmParams) {
+	sh.call("Drotm", n, x, incX, y, incY, p)
+}
+
+func (sh Shadow) Cdotu(n int, x []complex64, incX int, y []complex64, incY int) (dotu complex64) {
+	return sh.call("Cdotu", n, x, incX, y, incY)[0].Interface().(complex64)
+}
+
+func (sh Shadow) Cdotc(n int, x []complex64, incX int, y []complex64, incY int) (dotc complex64) {
+	return sh.call("Cdotc", n, x, incX, y, incY)[0].Interface().(complex64)
+}
+
+func (sh Shadow) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
+	return sh.call("Zdotu", n, x, incX, y, incY)[0].Interface().(complex128)
+}
+
+func (sh Shadow) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
+	return sh.call("Zdotc", n, x, incX, y, incY)[0].Interface().(complex128)
+}
+
+func (sh Shadow) Sdsdot(n int, alpha float32, x []float32, incX int, y []float32, incY int) float32 {
+	return sh.call("Sdsdot", n, alpha, x, incX, y, incY)[0].Interface().(float32)
+}
+
+func (sh Shadow) Dsdot(n int, x []float32, incX int, y []float32, incY int) float64 {
+	return sh.call("Dsdot", n, x, incX, y, incY)[0].Interface().(float64)
+}
+
+func (sh Shadow) Sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
+	return sh.call("Sdot", n, x, incX, y, incY)[0].Interface().(float32)
+}
+
+func (sh Shadow) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	return sh.call("Ddot", n, x, incX, y, incY)[0].Interface().(float64)
+}
+
+func (sh Shadow) Snrm2(n int, x []float32, incX int) float32 {
+	return sh.call("Snrm2", n, x, incX)[0].Interface().(float32)
+}
+
+func (sh Shadow) Sasum(n int, x []float32, incX int) float32 {
+	return sh.call("Sasum", n, x, incX)[0].Interface().(float32)
+}
+
+func (sh Shadow) Dnrm2(n int, x []float64, incX int) float64 {
+	return sh.call("Dnrm2", n, x, incX)[0].Interface().(float64)
+}
+
+func (sh Shadow) Dasum(n int, x []float64, incX int) float64 {
+	return sh.call("Dasum", n, x, incX)[0].Interface().(float64)
+}
+
+func (sh Shadow) Scnrm2(n int, x []complex64, incX int) float32 {
+	return sh.call("Scnrm2", n, x, incX)[0].Interface().(float32)
+}
+
+func (sh Shadow) Scasum(n int, x []complex64, incX int) float32 {
+	return sh.call("Scasum", n, x, incX)[0].Interface().(float32)
+}
+
+func (sh Shadow) Dznrm2(n int, x []complex128, incX int) float64 {
+	return sh.call("Dznrm2", n, x, incX)[0].Interface().(float64)
+}
+
+func (sh Shadow) Dzasum(n int, x []complex128, incX int) float64 {
+	return sh.call("Dzasum", n, x, incX)[0].Interface().(float64)
+}
+
+func (sh Shadow) Isamax(n int, x []float32, incX int) int {
+	return sh.call("Isamax", n, x, incX)[0].Interface().(int)
+}
+
+func (sh Shadow) Idamax(n int, x []float64, incX int) int {
+	return sh.call("Idamax", n, x, incX)[0].Interface().(int)
+}
+
+func (sh Shadow) Icamax(n int, x []complex64, incX int) int {
+	return sh.call("Icamax", n, x, incX)[0].Interface().(int)
+}
+
+func (sh Shadow) Izamax(n int, x []complex128, incX int) int {
+	return sh.call("Izamax", n, x, incX)[0].Interface().(int)
+}
+
+func (sh Shadow) Sswap(n int, x []float32, incX int, y []float32, incY int) {
+	sh.call("Sswap", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Scopy(n int, x []float32, incX int, y []float32, incY int) {
+	sh.call("Scopy", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	sh.call("Saxpy", n, alpha, x, incX, y, incY)
+}
+
+func (sh Shadow) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	sh.call("Dswap", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	sh.call("Dcopy", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	sh.call("Daxpy", n, alpha, x, incX, y, incY)
+}
+
+func (sh Shadow) Cswap(n int, x []complex64, incX int, y []complex64, incY int) {
+	sh.call("Cswap", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Ccopy(n int, x []complex64, incX int, y []complex64, incY int) {
+	sh.call("Ccopy", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Caxpy(n int, alpha complex64, x []complex64, incX int, y []complex64, incY int) {
+	sh.call("Caxpy", n, alpha, x, incX, y, incY)
+}
+
+func (sh Shadow) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
+	sh.call("Zswap", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Zcopy(n int, x []complex128, incX int, y []complex128, incY int) {
+	sh.call("Zcopy", n, x, incX, y, incY)
+}
+
+func (sh Shadow) Zaxpy(n int, alpha complex128, x []complex128, incX int, y []complex128, incY int) {
+	sh.call("Zaxpy", n, alpha, x, incX, y, incY)
+}
+
+func (sh Shadow) Srot(n int, x []float32, incX int, y []float32, incY int, c float32, s float32) {
+	sh.call("Srot", n, x, incX, y, incY, c, s)
+}
+
+func (sh Shadow) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
+	sh.call("Drot", n, x, incX, y, incY, c, s)
+}
+
+func (sh Shadow) Sscal(n int, alpha float32, x []float32, incX int) {
+	sh.call("Sscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Dscal(n int, alpha float64, x []float64, incX int) {
+	sh.call("Dscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Cscal(n int, alpha complex64, x []complex64, incX int) {
+	sh.call("Cscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Zscal(n int, alpha complex128, x []complex128, incX int) {
+	sh.call("Zscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Csscal(n int, alpha float32, x []complex64, incX int) {
+	sh.call("Csscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Zdscal(n int, alpha float64, x []complex128, incX int) {
+	sh.call("Zdscal", n, alpha, x, incX)
+}
+
+func (sh Shadow) Sgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	sh.call("Sgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Sgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	sh.call("Sgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Strmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	sh.call("Strmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Stbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	sh.call("Stbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Stpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	sh.call("Stpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Strsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float32, lda int, x []float32, incX int) {
+	sh.call("Strsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Stbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float32, lda int, x []float32, incX int) {
+	sh.call("Stbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Stpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float32, x []float32, incX int) {
+	sh.call("Stpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	sh.call("Dgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Dgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	sh.call("Dgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Dtrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	sh.call("Dtrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Dtbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	sh.call("Dtbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Dtpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	sh.call("Dtpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Dtrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	sh.call("Dtrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Dtbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []float64, lda int, x []float64, incX int) {
+	sh.call("Dtbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Dtpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	sh.call("Dtpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Cgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	sh.call("Cgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Cgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	sh.call("Cgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Ctrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	sh.call("Ctrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Ctbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	sh.call("Ctbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Ctpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	sh.call("Ctpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Ctrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex64, lda int, x []complex64, incX int) {
+	sh.call("Ctrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Ctbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex64, lda int, x []complex64, incX int) {
+	sh.call("Ctbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Ctpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex64, x []complex64, incX int) {
+	sh.call("Ctpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Zgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	sh.call("Zgemv", o, tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Zgbmv(o blas.Order, tA blas.Transpose, m int, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	sh.call("Zgbmv", o, tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Ztrmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	sh.call("Ztrmv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Ztbmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	sh.call("Ztbmv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Ztpmv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	sh.call("Ztpmv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Ztrsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []complex128, lda int, x []complex128, incX int) {
+	sh.call("Ztrsv", o, ul, tA, d, n, a, lda, x, incX)
+}
+
+func (sh Shadow) Ztbsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, k int, a []complex128, lda int, x []complex128, incX int) {
+	sh.call("Ztbsv", o, ul, tA, d, n, k, a, lda, x, incX)
+}
+
+func (sh Shadow) Ztpsv(o blas.Order, ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []complex128, x []complex128, incX int) {
+	sh.call("Ztpsv", o, ul, tA, d, n, ap, x, incX)
+}
+
+func (sh Shadow) Ssymv(o blas.Order, ul blas.Uplo, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	sh.call("Ssymv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Ssbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	sh.call("Ssbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Sspmv(o blas.Order, ul blas.Uplo, n int, alpha float32, ap []float32, x []float32, incX int, beta float32, y []float32, incY int) {
+	sh.call("Sspmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Sger(o blas.Order, m int, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	sh.call("Sger", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Ssyr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, a []float32, lda int) {
+	sh.call("Ssyr", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (sh Shadow) Sspr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, ap []float32) {
+	sh.call("Sspr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (sh Shadow) Ssyr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, a []float32, lda int) {
+	sh.call("Ssyr2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Sspr2(o blas.Order, ul blas.Uplo, n int, alpha float32, x []float32, incX int, y []float32, incY int, ap []float32) {
+	sh.call("Sspr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (sh Shadow) Dsymv(o blas.Order, ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	sh.call("Dsymv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Dsbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	sh.call("Dsbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Dspmv(o blas.Order, ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	sh.call("Dspmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Dger(o blas.Order, m int, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	sh.call("Dger", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Dsyr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	sh.call("Dsyr", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (sh Shadow) Dspr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
+	sh.call("Dspr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (sh Shadow) Dsyr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	sh.call("Dsyr2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Dspr2(o blas.Order, ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, ap []float64) {
+	sh.call("Dspr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (sh Shadow) Chemv(o blas.Order, ul blas.Uplo, n int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	sh.call("Chemv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Chbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex64, a []complex64, lda int, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	sh.call("Chbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Chpmv(o blas.Order, ul blas.Uplo, n int, alpha complex64, ap []complex64, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	sh.call("Chpmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Cgeru(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	sh.call("Cgeru", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Cgerc(o blas.Order, m int, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	sh.call("Cgerc", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Cher(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, a []complex64, lda int) {
+	sh.call("Cher", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (sh Shadow) Chpr(o blas.Order, ul blas.Uplo, n int, alpha float32, x []complex64, incX int, ap []complex64) {
+	sh.call("Chpr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (sh Shadow) Cher2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, a []complex64, lda int) {
+	sh.call("Cher2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Chpr2(o blas.Order, ul blas.Uplo, n int, alpha complex64, x []complex64, incX int, y []complex64, incY int, ap []complex64) {
+	sh.call("Chpr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (sh Shadow) Zhemv(o blas.Order, ul blas.Uplo, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	sh.call("Zhemv", o, ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Zhbmv(o blas.Order, ul blas.Uplo, n int, k int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	sh.call("Zhbmv", o, ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Zhpmv(o blas.Order, ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	sh.call("Zhpmv", o, ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+func (sh Shadow) Zgeru(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	sh.call("Zgeru", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Zgerc(o blas.Order, m int, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	sh.call("Zgerc", o, m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Zher(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
+	sh.call("Zher", o, ul, n, alpha, x, incX, a, lda)
+}
+
+func (sh Shadow) Zhpr(o blas.Order, ul blas.Uplo, n int, alpha float64, x []complex128, incX int, ap []complex128) {
+	sh.call("Zhpr", o, ul, n, alpha, x, incX, ap)
+}
+
+func (sh Shadow) Zher2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
+	sh.call("Zher2", o, ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+func (sh Shadow) Zhpr2(o blas.Order, ul blas.Uplo, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, ap []complex128) {
+	sh.call("Zhpr2", o, ul, n, alpha, x, incX, y, incY, ap)
+}
+
+func (sh Shadow) Sgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	sh.call("Sgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Ssymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	sh.call("Ssymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Ssyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, beta float32, c []float32, ldc int) {
+	sh.call("Ssyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Ssyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []float32, lda int, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	sh.call("Ssyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Strmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	sh.call("Strmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Strsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float32, a []float32, lda int, b []float32, ldb int) {
+	sh.call("Strsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Dgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	sh.call("Dgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Dsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	sh.call("Dsymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Dsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	sh.call("Dsyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Dsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	sh.call("Dsyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Dtrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	sh.call("Dtrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Dtrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	sh.call("Dtrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Cgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sh.call("Cgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Csymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sh.call("Csymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Csyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, beta complex64, c []complex64, ldc int) {
+	sh.call("Csyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Csyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sh.call("Csyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Ctrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	sh.call("Ctrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Ctrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int) {
+	sh.call("Ctrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Zgemm(o blas.Order, tA blas.Transpose, tB blas.Transpose, m int, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sh.call("Zgemm", o, tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Zsymm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sh.call("Zsymm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Zsyrk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, beta complex128, c []complex128, ldc int) {
+	sh.call("Zsyrk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Zsyr2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sh.call("Zsyr2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Ztrmm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	sh.call("Ztrmm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Ztrsm(o blas.Order, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int) {
+	sh.call("Ztrsm", o, s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+}
+
+func (sh Shadow) Chemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	sh.call("Chemm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Cherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float32, a []complex64, lda int, beta float32, c []complex64, ldc int) {
+	sh.call("Cherk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Cher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex64, a []complex64, lda int, b []complex64, ldb int, beta float32, c []complex64, ldc int) {
+	sh.call("Cher2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Zhemm(o blas.Order, s blas.Side, ul blas.Uplo, m int, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	sh.call("Zhemm", o, s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+}
+
+func (sh Shadow) Zherk(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha float64, a []complex128, lda int, beta float64, c []complex128, ldc int) {
+	sh.call("Zherk", o, ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+func (sh Shadow) Zher2k(o blas.Order, ul blas.Uplo, t blas.Transpose, n int, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta float64, c []complex128, ldc int) {
+	sh.call("Zher2k", o, ul, t, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+}
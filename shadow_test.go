@@ -0,0 +1,157 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// perturbed is a Backend whose Daxpy and Ddot results are scaled by 1+rel
+// in the element of y at index i and in the result, and whose Dscal panics.
+type perturbed struct {
+	Blas
+	rel float64
+	i   int
+}
+
+func (p perturbed) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	p.Blas.Daxpy(n, alpha, x, incX, y, incY)
+	y[p.i] *= 1 + p.rel
+}
+
+func (p perturbed) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	return p.Blas.Ddot(n, x, incX, y, incY) * (1 + p.rel)
+}
+
+func (perturbed) Dscal(n int, alpha float64, x []float64, incX int) {
+	panic("perturbed: Dscal")
+}
+
+func TestShadow(t *testing.T) {
+	const eps = 0x1p-52
+	for _, test := range []struct {
+		rel     float64
+		diverge bool
+	}{
+		{rel: 0},
+		{rel: 4 * eps},
+		{rel: 1e-8, diverge: true},
+	} {
+		var got []*Divergence
+		sh := Shadow{Primary: Blas{}, Secondary: perturbed{rel: test.rel, i: 2}, Report: func(d *Divergence) { got = append(got, d) }}
+
+		x := []float64{1, 2, 3, 4}
+		y := []float64{4, 3, 2, 1}
+		sh.Daxpy(4, 0.5, x, 1, y, 1)
+		if want := []float64{4.5, 4, 3.5, 3}; !sameFloat64s(y, want) {
+			t.Errorf("rel=%g: Daxpy: Primary result not returned: got %v want %v", test.rel, y, want)
+		}
+		sh.Ddot(4, x, 1, x, 1)
+		if !test.diverge {
+			for _, d := range got {
+				t.Errorf("rel=%g: unexpected divergence: %v", test.rel, d)
+			}
+			continue
+		}
+		if len(got) != 2 {
+			t.Fatalf("rel=%g: got %d divergences want 2", test.rel, len(got))
+		}
+		if d := got[0]; d.Routine != "Daxpy" || d.Arg != 4 || d.Index != 2 || d.Panicked {
+			t.Errorf("rel=%g: unexpected Daxpy divergence: %+v", test.rel, d)
+		}
+		if d := got[1]; d.Routine != "Ddot" || d.Arg != 5 || d.Index != 0 {
+			t.Errorf("rel=%g: unexpected Ddot divergence: %+v", test.rel, d)
+		}
+	}
+}
+
+// TestShadowAlias tests that a slice passed as two arguments is copied once
+// for the Secondary, so that both backends see the same aliasing.
+func TestShadowAlias(t *testing.T) {
+	var got []*Divergence
+	sh := Shadow{Primary: Blas{}, Secondary: Blas{}, Report: func(d *Divergence) { got = append(got, d) }}
+	x := []float64{1, 2, 3}
+	sh.Daxpy(3, 1, x, 1, x, 1)
+	if want := []float64{2, 4, 6}; !sameFloat64s(x, want) {
+		t.Errorf("unexpected result: got %v want %v", x, want)
+	}
+	if got := sh.Ddot(3, x, 1, x, 1); got != 56 {
+		t.Errorf("unexpected dot: got %v want 56", got)
+	}
+	for _, d := range got {
+		t.Errorf("unexpected divergence: %v", d)
+	}
+}
+
+func TestShadowPanic(t *testing.T) {
+	var got []*Divergence
+	report := func(d *Divergence) { got = append(got, d) }
+	x := []float64{1, 2}
+
+	// A panic of the Secondary alone is reported.
+	Shadow{Primary: Blas{}, Secondary: perturbed{}, Report: report}.Dscal(2, 2, x, 1)
+	if len(got) != 1 || got[0].Arg != -1 || !got[0].Panicked || got[0].Secondary != "perturbed: Dscal" {
+		t.Errorf("unexpected divergences for Secondary panic: %v", got)
+	}
+
+	// A panic of the Primary alone is reported and propagated.
+	got = nil
+	func() {
+		defer func() {
+			if r := recover(); r != "perturbed: Dscal" {
+				t.Errorf("unexpected panic value: %v", r)
+			}
+		}()
+		Shadow{Primary: perturbed{}, Secondary: Blas{}, Report: report}.Dscal(2, 2, x, 1)
+	}()
+	if len(got) != 1 || got[0].Arg != -1 || got[0].Panicked || got[0].Primary != "perturbed: Dscal" {
+		t.Errorf("unexpected divergences for Primary panic: %v", got)
+	} else if !strings.Contains(got[0].Error(), "primary panicked: perturbed: Dscal") {
+		t.Errorf("unexpected message: %v", got[0])
+	}
+
+	// A panic of both is propagated without a report.
+	got = nil
+	func() {
+		defer func() {
+			if r := recover(); r != "cblas: n < 0" {
+				t.Errorf("unexpected panic value: %v", r)
+			}
+		}()
+		Shadow{Primary: Blas{}, Secondary: Blas{}, Report: report}.Dscal(-1, 2, x, 1)
+	}()
+	if len(got) != 0 {
+		t.Errorf("unexpected divergences for common panic: %v", got)
+	}
+}
+
+// TestShadowDepth tests that the tolerance of Shadow is scaled by the
+// dimensions of a call and not by its leading dimensions or increments.
+func TestShadowDepth(t *testing.T) {
+	args := []interface{}{blas.ColMajor, blas.Trans, 3, 4, 1.0, make([]float64, 40), 10, make([]float64, 7), 2, 0.0, make([]float64, 13), 3}
+	in := make([]reflect.Value, len(args))
+	for i, a := range args {
+		in[i] = reflect.ValueOf(a)
+	}
+	if _, depth := magnitudes(in); depth != 4 {
+		t.Errorf("unexpected depth: got %d want 4", depth)
+	}
+}
+
+func sameFloat64s(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
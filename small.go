@@ -0,0 +1,236 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/gonum/blas"
+)
+
+// Calls to some of the Blas methods with a problem size below a threshold
+// run a Go kernel rather than calling the library, since for small
+// problems the cost of the cgo call exceeds the cost of the arithmetic.
+// The size of a problem is n for the Level 1 routines and m*n for the
+// Level 2 routines. The Go kernels have the semantics of the reference
+// BLAS, including the treatment of zero alpha and beta, but may round
+// differently from the library.
+
+// Default thresholds of the routines with Go kernels.
+var (
+	smallSaxpy int64 = 32
+	smallDaxpy int64 = 32
+	smallSdot  int64 = 32
+	smallDdot  int64 = 32
+	smallSscal int64 = 32
+	smallDscal int64 = 32
+	smallSgemv int64 = 256
+	smallDgemv int64 = 256
+)
+
+// thresholds holds the thresholds of the routines with Go kernels.
+var thresholds = map[string]*int64{
+	"Saxpy": &smallSaxpy,
+	"Daxpy": &smallDaxpy,
+	"Sdot":  &smallSdot,
+	"Ddot":  &smallDdot,
+	"Sscal": &smallSscal,
+	"Dscal": &smallDscal,
+	"Sgemv": &smallSgemv,
+	"Dgemv": &smallDgemv,
+}
+
+// Thresholds returns the names of the Blas methods that have Go kernels.
+func Thresholds() []string {
+	names := make([]string, 0, len(thresholds))
+	for name := range thresholds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Threshold returns the problem size below which calls to the named Blas
+// method run a Go kernel. It panics if the method has no Go kernel.
+func Threshold(routine string) int {
+	t, ok := thresholds[routine]
+	if !ok {
+		panic("cblas: no Go kernel for " + routine)
+	}
+	return int(atomic.LoadInt64(t))
+}
+
+// SetThreshold sets the problem size below which calls to the named Blas
+// method run a Go kernel and returns the previous threshold. A threshold
+// of zero always calls the library. It panics if the method has no Go
+// kernel or n is negative.
+func SetThreshold(routine string, n int) int {
+	t, ok := thresholds[routine]
+	if !ok {
+		panic("cblas: no Go kernel for " + routine)
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	return int(atomic.SwapInt64(t, int64(n)))
+}
+
+// start returns the index of the first element of a vector of n elements
+// with increment inc, which is the last one held if inc is negative.
+func start(n, inc int) int {
+	if inc < 0 {
+		return (1 - n) * inc
+	}
+	return 0
+}
+
+// below returns whether a problem of the given size is below the
+// threshold t.
+func below(t *int64, size int) bool {
+	return int64(size) < atomic.LoadInt64(t)
+}
+
+func saxpy(n int, alpha float32, x []float32, incX int, y []float32, incY int) {
+	if alpha == 0 {
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy+i*incY] += alpha * x[ix+i*incX]
+	}
+}
+
+func daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	if alpha == 0 {
+		return
+	}
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		y[iy+i*incY] += alpha * x[ix+i*incX]
+	}
+}
+
+func sdot(n int, x []float32, incX int, y []float32, incY int) float32 {
+	var s float32
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		s += x[ix+i*incX] * y[iy+i*incY]
+	}
+	return s
+}
+
+func ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	var s float64
+	ix, iy := start(n, incX), start(n, incY)
+	for i := 0; i < n; i++ {
+		s += x[ix+i*incX] * y[iy+i*incY]
+	}
+	return s
+}
+
+func sscal(n int, alpha float32, x []float32, incX int) {
+	for i := 0; i < n; i++ {
+		x[i*incX] *= alpha
+	}
+}
+
+func dscal(n int, alpha float64, x []float64, incX int) {
+	for i := 0; i < n; i++ {
+		x[i*incX] *= alpha
+	}
+}
+
+func sgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float32, a []float32, lda int, x []float32, incX int, beta float32, y []float32, incY int) {
+	// A column-major matrix is the transpose of a row-major one.
+	if o == blas.ColMajor {
+		m, n = n, m
+		if tA == blas.NoTrans {
+			tA = blas.Trans
+		} else {
+			tA = blas.NoTrans
+		}
+	}
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	for i := 0; i < lenY; i++ {
+		if beta == 0 {
+			y[ky+i*incY] = 0
+		} else if beta != 1 {
+			y[ky+i*incY] *= beta
+		}
+	}
+	if alpha == 0 {
+		return
+	}
+	if tA == blas.NoTrans {
+		for i := 0; i < m; i++ {
+			var s float32
+			for j, v := range a[i*lda : i*lda+n] {
+				s += v * x[kx+j*incX]
+			}
+			y[ky+i*incY] += alpha * s
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		t := alpha * x[kx+i*incX]
+		for j, v := range a[i*lda : i*lda+n] {
+			y[ky+j*incY] += t * v
+		}
+	}
+}
+
+func dgemv(o blas.Order, tA blas.Transpose, m int, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	// A column-major matrix is the transpose of a row-major one.
+	if o == blas.ColMajor {
+		m, n = n, m
+		if tA == blas.NoTrans {
+			tA = blas.Trans
+		} else {
+			tA = blas.NoTrans
+		}
+	}
+	lenX, lenY := n, m
+	if tA != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	if m == 0 || n == 0 || (alpha == 0 && beta == 1) {
+		return
+	}
+	kx, ky := start(lenX, incX), start(lenY, incY)
+	for i := 0; i < lenY; i++ {
+		if beta == 0 {
+			y[ky+i*incY] = 0
+		} else if beta != 1 {
+			y[ky+i*incY] *= beta
+		}
+	}
+	if alpha == 0 {
+		return
+	}
+	if tA == blas.NoTrans {
+		for i := 0; i < m; i++ {
+			var s float64
+			for j, v := range a[i*lda : i*lda+n] {
+				s += v * x[kx+j*incX]
+			}
+			y[ky+i*incY] += alpha * s
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		t := alpha * x[kx+i*incX]
+		for j, v := range a[i*lda : i*lda+n] {
+			y[ky+j*incY] += t * v
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// guard is the number of elements after the end of each vector operand.
+const guard = 4
+
+// TestThreshold tests that the routines with Go kernels have the same
+// semantics as the library. Each routine is called with the same
+// arguments with its threshold set to zero and to the largest int, and
+// the results must agree to within rounding, including which elements
+// are NaN and which elements are left unmodified. Zero alpha and beta are
+// tested with NaN in the operands they exclude.
+func TestThreshold(t *testing.T) {
+	v := reflect.ValueOf(Blas{})
+	for _, name := range Thresholds() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			prev := SetThreshold(name, 0)
+			defer SetThreshold(name, prev)
+			testThreshold(t, name, v.MethodByName(name), func(goKernel bool) {
+				if goKernel {
+					SetThreshold(name, int(^uint(0)>>1))
+				} else {
+					SetThreshold(name, 0)
+				}
+			})
+		})
+	}
+}
+
+func testThreshold(t *testing.T, name string, m reflect.Value, use func(goKernel bool)) {
+	rnd := rand.New(rand.NewSource(1))
+	single := name[0] == 'S'
+	eps := 0x1p-52
+	if single {
+		eps = 0x1p-23
+	}
+	vec := func(n int, fill float64) []float64 {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = fill
+			if fill == 0 {
+				v[i] = rnd.Float64()*2 - 1
+				if single {
+					v[i] = float64(float32(v[i]))
+				}
+			}
+		}
+		return v
+	}
+	elem := reflect.TypeOf(float64(0))
+	if single {
+		elem = reflect.TypeOf(float32(0))
+	}
+	scalar := func(f float64) reflect.Value { return reflect.ValueOf(f).Convert(elem) }
+
+	// call calls the routine on copies of the operands with the Go
+	// kernel or the library and returns the operands after the call,
+	// followed by the result if there is one.
+	call := func(goKernel bool, args []interface{}) [][]float64 {
+		use(goKernel)
+		var vals []reflect.Value
+		var slices []reflect.Value
+		for _, a := range args {
+			if s, ok := a.([]float64); ok {
+				c := reflect.MakeSlice(reflect.SliceOf(elem), len(s), len(s))
+				for i, f := range s {
+					c.Index(i).Set(scalar(f))
+				}
+				vals = append(vals, c)
+				slices = append(slices, c)
+				continue
+			}
+			if f, ok := a.(float64); ok {
+				vals = append(vals, scalar(f))
+				continue
+			}
+			vals = append(vals, reflect.ValueOf(a))
+		}
+		out := m.Call(vals)
+		var res [][]float64
+		for _, s := range slices {
+			r := make([]float64, s.Len())
+			for i := range r {
+				r[i] = s.Index(i).Float()
+			}
+			res = append(res, r)
+		}
+		if len(out) == 1 {
+			res = append(res, []float64{out[0].Float()})
+		}
+		return res
+	}
+
+	check := func(desc string, k int, args []interface{}) {
+		// The operands are at most 2.5 in magnitude, so a result
+		// is a sum of at most k+1 terms of magnitude at most 2.5k.
+		tol := 4 * eps * float64((k+2)*(k+2))
+		lib := call(false, args)
+		kern := call(true, args)
+		for i := range lib {
+			for j, want := range lib[i] {
+				got := kern[i][j]
+				switch {
+				case math.IsNaN(want) || math.IsNaN(got):
+					if math.IsNaN(want) != math.IsNaN(got) {
+						t.Errorf("%s: operand %d element %d: Go kernel %v, library %v", desc, i, j, got, want)
+					}
+				case math.Abs(got-want) > tol*(1+math.Abs(want)):
+					t.Errorf("%s: operand %d element %d: Go kernel %v, library %v", desc, i, j, got, want)
+				}
+			}
+		}
+	}
+
+	alphas := []float64{0, 1, -0.5, 2.5}
+	betas := []float64{0, 1, -1.5}
+	switch name[1:] {
+	case "axpy", "dot", "scal":
+		for _, n := range []int{0, 1, 2, 3, 7, 31, 32, 33, 100} {
+			for _, inc := range []int{1, 2, 3, -2} {
+				l := max(1, (n-1)*abs(inc)+1+guard)
+				if name[1:] == "dot" {
+					desc := fmt.Sprintf("n=%d inc=%d", n, inc)
+					check(desc, n, []interface{}{n, vec(l, 0), inc, vec(l, 0), inc})
+					continue
+				}
+				for _, alpha := range alphas {
+					desc := fmt.Sprintf("n=%d inc=%d alpha=%v", n, inc, alpha)
+					x, y := vec(l, 0), vec(l, 0)
+					if name[1:] == "scal" {
+						check(desc, n, []interface{}{n, alpha, x, inc})
+						continue
+					}
+					if alpha == 0 {
+						x = vec(l, math.NaN())
+					}
+					check(desc, n, []interface{}{n, alpha, x, inc, y, inc})
+				}
+			}
+		}
+	case "gemv":
+		for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, mn := range [][2]int{{0, 0}, {0, 3}, {3, 0}, {1, 1}, {3, 5}, {5, 3}, {7, 7}, {17, 9}} {
+					r, c := mn[0], mn[1]
+					lenX, lenY := c, r
+					if tA != blas.NoTrans {
+						lenX, lenY = r, c
+					}
+					// The leading dimension and length of a are
+					// valid for either order.
+					lda := max(r, c) + 1
+					for _, inc := range []int{1, 2, -1} {
+						for _, alpha := range alphas {
+							for _, beta := range betas {
+								desc := fmt.Sprintf("order=%v tA=%v m=%d n=%d inc=%d alpha=%v beta=%v", o, tA, r, c, inc, alpha, beta)
+								a := vec(lda*(max(r, c)+1), 0)
+								x := vec(max(1, (lenX-1)*abs(inc)+1+guard), 0)
+								y := vec(max(1, (lenY-1)*abs(inc)+1+guard), 0)
+								if alpha == 0 {
+									a = vec(len(a), math.NaN())
+									x = vec(len(x), math.NaN())
+								}
+								if beta == 0 {
+									y = vec(len(y), math.NaN())
+								}
+								check(desc, max(r, c), []interface{}{o, tA, r, c, alpha, a, lda, x, inc, beta, y, inc})
+							}
+						}
+					}
+				}
+			}
+		}
+	default:
+		t.Fatalf("no test for %s", name)
+	}
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
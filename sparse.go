@@ -0,0 +1,114 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+//go:generate perl genSparse.pl
+
+import "github.com/gonum/blas"
+
+// The sparse matrix types hold matrices in compressed sparse row (CSR) or
+// compressed sparse column (CSC) format with zero-based indices. The
+// sparse products are computed in Go and take their arguments in the
+// order of Dgemv and Dgemm, with the dimensions of the sparse matrix taken
+// from the matrix. A matrix must be well formed: its pointers must be
+// non-decreasing and its indices within its dimensions. Indices within a
+// row or column need not be sorted, and duplicates are summed.
+
+// checkSparse checks the dimensions and storage of a sparse matrix with
+// major lines of storage indexed by ptr, holding the indices idx of
+// elements in lines of length minor.
+func checkSparse(rows, cols int, ptr, idx []int, lenVal, minor int) {
+	if rows < 0 {
+		panic("cblas: rows < 0")
+	}
+	if cols < 0 {
+		panic("cblas: cols < 0")
+	}
+	major := len(ptr) - 1
+	if major < 0 || ptr[0] != 0 || ptr[major] > len(idx) || ptr[major] > lenVal {
+		panic("cblas: malformed sparse matrix")
+	}
+	for i := 0; i < major; i++ {
+		if ptr[i] > ptr[i+1] {
+			panic("cblas: malformed sparse matrix")
+		}
+	}
+	for _, j := range idx[:ptr[major]] {
+		if j < 0 || j >= minor {
+			panic("cblas: index out of range")
+		}
+	}
+}
+
+// checkSpmv checks the arguments of a sparse matrix-vector product with an
+// m×n sparse matrix.
+func checkSpmv(tA blas.Transpose, m, n, lenX, incX, lenY, incY int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if incX <= 0 || incY <= 0 {
+		panic("cblas: index out of range")
+	}
+	if tA != blas.NoTrans {
+		m, n = n, m
+	}
+	if (n-1)*incX >= lenX {
+		panic("cblas: index out of range")
+	}
+	if (m-1)*incY >= lenY {
+		panic("cblas: index out of range")
+	}
+}
+
+// checkSpmm checks the arguments of a sparse matrix-matrix product with an
+// m×k sparse matrix op(A), and returns m and k.
+func checkSpmm(o blas.Order, tA blas.Transpose, rows, cols, n, lenB, ldb, lenC, ldc int) (m, k int) {
+	if o != blas.RowMajor && o != blas.ColMajor {
+		panic("cblas: illegal order")
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic("cblas: illegal transpose")
+	}
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	m, k = rows, cols
+	if tA != blas.NoTrans {
+		m, k = cols, rows
+	}
+	if o == blas.RowMajor {
+		if ldb*k > lenB || ldb < max(1, n) {
+			panic("cblas: index out of range")
+		}
+		if ldc*m > lenC || ldc < max(1, n) {
+			panic("cblas: index out of range")
+		}
+	} else {
+		if ldb*n > lenB || ldb < max(1, k) {
+			panic("cblas: index out of range")
+		}
+		if ldc*n > lenC || ldc < max(1, m) {
+			panic("cblas: index out of range")
+		}
+	}
+	return m, k
+}
+
+// checkSparseVec checks the arguments of a Level 1 sparse routine with a
+// sparse vector of n values indexed by indx into a dense vector of length
+// lenY.
+func checkSparseVec(n, lenX int, indx []int, lenY int) {
+	if n < 0 {
+		panic("cblas: n < 0")
+	}
+	if lenX < n || len(indx) < n {
+		panic("cblas: index out of range")
+	}
+	for _, j := range indx[:n] {
+		if j < 0 || j >= lenY {
+			panic("cblas: index out of range")
+		}
+	}
+}
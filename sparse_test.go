@@ -0,0 +1,459 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+// sparseElems are the elements of the 5×4 sparse matrix used by the tests,
+// with unsorted and duplicate indices in both rows and columns and an
+// empty row and column. The values and the scalars of the tests are
+// chosen so that the products are exact in single precision.
+var sparseElems = []struct {
+	i, j int
+	v    complex128
+}{
+	{0, 3, 1 + 2i},
+	{0, 1, -2},
+	{0, 3, 0.5 - 1i},
+	{2, 0, 3i},
+	{2, 3, -1 + 1i},
+	{2, 1, 2},
+	{2, 0, 1},
+	{3, 1, -0.5 + 0.5i},
+	{4, 3, 2 - 2i},
+	{4, 0, -1},
+	{4, 3, 1},
+}
+
+const sparseRows, sparseCols = 5, 4
+
+// sparseMatrix returns the CSR and CSC forms of the sparseElems with
+// values of element type t in the matrix types of the precision p, and the
+// dense matrix they represent in the order o.
+func sparseMatrix(p string, t reflect.Type, o blas.Order) (csr, csc, dense reflect.Value) {
+	vals := make([]complex128, len(sparseElems))
+	d := make([]complex128, sparseRows*sparseCols)
+	for i, e := range sparseElems {
+		v := e.v
+		if t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64 {
+			v = complex(real(v), 0)
+		}
+		vals[i] = v
+		if o == blas.RowMajor {
+			d[e.i*sparseCols+e.j] += v
+		} else {
+			d[e.j*sparseRows+e.i] += v
+		}
+	}
+	dense = strided(t, d, 1)
+
+	// The elements are stored in the order of sparseElems within each
+	// row or column, so the indices are unsorted.
+	build := func(typ string, major int, key func(int) (int, int)) reflect.Value {
+		ptr := make([]int, major+1)
+		var idx []int
+		var val []complex128
+		for q := 0; q < major; q++ {
+			for k := range sparseElems {
+				if mj, mn := key(k); mj == q {
+					idx = append(idx, mn)
+					val = append(val, vals[k])
+				}
+			}
+			ptr[q+1] = len(idx)
+		}
+		m := reflect.New(sparseType(p + typ)).Elem()
+		m.FieldByName("Rows").SetInt(sparseRows)
+		m.FieldByName("Cols").SetInt(sparseCols)
+		if typ == "CSR" {
+			m.FieldByName("RowPtr").Set(reflect.ValueOf(ptr))
+			m.FieldByName("ColIdx").Set(reflect.ValueOf(idx))
+		} else {
+			m.FieldByName("ColPtr").Set(reflect.ValueOf(ptr))
+			m.FieldByName("RowIdx").Set(reflect.ValueOf(idx))
+		}
+		m.FieldByName("Val").Set(strided(t, val, 1))
+		return m.Addr()
+	}
+	csr = build("CSR", sparseRows, func(k int) (int, int) { return sparseElems[k].i, sparseElems[k].j })
+	csc = build("CSC", sparseCols, func(k int) (int, int) { return sparseElems[k].j, sparseElems[k].i })
+	return csr, csc, dense
+}
+
+func sparseType(name string) reflect.Type {
+	for _, v := range []interface{}{SCSR{}, SCSC{}, DCSR{}, DCSC{}, CCSR{}, CCSC{}, ZCSR{}, ZCSC{}} {
+		if t := reflect.TypeOf(v); t.Name() == name {
+			return t
+		}
+	}
+	panic("no sparse type " + name)
+}
+
+// TestSparseProduct tests the sparse matrix-vector and matrix-matrix
+// products against the dense products of Blas with the densified matrix.
+func TestSparseProduct(t *testing.T) {
+	impl := reflect.ValueOf(Blas{})
+	for _, p := range []string{"S", "D", "C", "Z"} {
+		gemv := impl.MethodByName(p + "gemv")
+		gemm := impl.MethodByName(p + "gemm")
+		et := gemv.Type().In(5).Elem()
+		scalar := func(v complex128) reflect.Value {
+			return reflect.ValueOf(v).Convert(gemv.Type().In(4))
+		}
+		if et.Kind() == reflect.Float32 || et.Kind() == reflect.Float64 {
+			scalar = func(v complex128) reflect.Value {
+				return reflect.ValueOf(real(v)).Convert(gemv.Type().In(4))
+			}
+		}
+		alpha := scalar(0.5 - 1i)
+		for _, o := range []blas.Order{blas.RowMajor, blas.ColMajor} {
+			csr, csc, dense := sparseMatrix(p, et, o)
+			lda := sparseCols
+			if o == blas.ColMajor {
+				lda = sparseRows
+			}
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+				m, k := sparseRows, sparseCols
+				if tA != blas.NoTrans {
+					m, k = k, m
+				}
+				for _, beta := range []complex128{0, 1, -0.5 + 2i} {
+					desc := fmt.Sprintf("(%v, %v) beta=%v", o, tA, beta)
+					for _, inc := range []int{1, 2} {
+						x := strided(et, sparseValues(k, 1), inc)
+						y := strided(et, sparseValues(m, 2), inc)
+						if beta == 0 {
+							fillNaN(y)
+						}
+						want := cloneValue(y)
+						gemv.Call([]reflect.Value{reflect.ValueOf(blas.RowMajor), reflect.ValueOf(tA), reflect.ValueOf(sparseRows), reflect.ValueOf(sparseCols),
+							alpha, denseRowMajor(o, dense), reflect.ValueOf(sparseCols), x, reflect.ValueOf(inc), scalar(beta), want, reflect.ValueOf(inc)})
+						for _, a := range []reflect.Value{csr, csc} {
+							got := cloneValue(y)
+							name := p + "cs" + string("rc"[btoi(a == csc)]) + "mv"
+							impl.MethodByName(name).Call([]reflect.Value{reflect.ValueOf(tA), alpha, a, x, reflect.ValueOf(inc), scalar(beta), got, reflect.ValueOf(inc)})
+							if !sameValues(got, want) {
+								t.Errorf("%s inc=%d: got %v want %v", name+desc, inc, got, want)
+							}
+						}
+					}
+
+					const n = 3
+					ldb, ldc := n+1, n+1
+					if o == blas.ColMajor {
+						ldb, ldc = k+1, m+1
+					}
+					b := strided(et, sparseValues(size(o, k, n, ldb), 3), 1)
+					c := strided(et, sparseValues(size(o, m, n, ldc), 4), 1)
+					if beta == 0 {
+						fillNaN(c)
+					}
+					want := cloneValue(c)
+					gemm.Call([]reflect.Value{reflect.ValueOf(o), reflect.ValueOf(tA), reflect.ValueOf(blas.NoTrans), reflect.ValueOf(m), reflect.ValueOf(n), reflect.ValueOf(k),
+						alpha, dense, reflect.ValueOf(lda), b, reflect.ValueOf(ldb), scalar(beta), want, reflect.ValueOf(ldc)})
+					for _, a := range []reflect.Value{csr, csc} {
+						got := cloneValue(c)
+						name := p + "cs" + string("rc"[btoi(a == csc)]) + "mm"
+						impl.MethodByName(name).Call([]reflect.Value{reflect.ValueOf(o), reflect.ValueOf(tA), reflect.ValueOf(n), alpha, a, b, reflect.ValueOf(ldb), scalar(beta), got, reflect.ValueOf(ldc)})
+						if !sameValues(got, want) {
+							t.Errorf("%s: got %v want %v", name+desc, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// sparseValues returns n small values that are exact in single precision.
+func sparseValues(n, seed int) []complex128 {
+	v := make([]complex128, n)
+	for i := range v {
+		v[i] = complex(float64((i*seed+1)%5-2), float64((i+seed)%3-1)) / 2
+	}
+	return v
+}
+
+// denseRowMajor returns the dense matrix d held in the order o in
+// row-major order.
+func denseRowMajor(o blas.Order, d reflect.Value) reflect.Value {
+	if o == blas.RowMajor {
+		return d
+	}
+	r := reflect.MakeSlice(d.Type(), d.Len(), d.Len())
+	for i := 0; i < sparseRows; i++ {
+		for j := 0; j < sparseCols; j++ {
+			r.Index(i*sparseCols + j).Set(d.Index(j*sparseRows + i))
+		}
+	}
+	return r
+}
+
+// sameValues returns whether the slices a and b hold the same values,
+// taking NaN values to be equal.
+func sameValues(a, b reflect.Value) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		x, y := a.Index(i), b.Index(i)
+		var eq bool
+		switch x.Kind() {
+		case reflect.Complex64, reflect.Complex128:
+			eq = sameFloat(real(x.Complex()), real(y.Complex())) && sameFloat(imag(x.Complex()), imag(y.Complex()))
+		default:
+			eq = sameFloat(x.Float(), y.Float())
+		}
+		if !eq {
+			return false
+		}
+	}
+	return true
+}
+
+func sameFloat(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b)
+}
+
+func cloneValue(v reflect.Value) reflect.Value {
+	c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+	reflect.Copy(c, v)
+	return c
+}
+
+// fillNaN sets the elements of the slice v to NaN, which must not be read
+// when beta is zero.
+func fillNaN(v reflect.Value) {
+	for i := 0; i < v.Len(); i++ {
+		e := v.Index(i)
+		switch e.Kind() {
+		case reflect.Complex64, reflect.Complex128:
+			e.SetComplex(complex(math.NaN(), math.NaN()))
+		default:
+			e.SetFloat(math.NaN())
+		}
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// sparseVecTests are the sparse Level 1 routines, named without their
+// precision prefix, with reference implementations for the sparse vector
+// x indexed by indx and the dense vector y. The alpha of axpyi is 2 and the
+// rotation of roti is (0.5, -2).
+var sparseVecTests = []struct {
+	name    string
+	real    bool // Whether the routine is real only.
+	complex bool // Whether the routine is complex only.
+	args    func(n int, x, y reflect.Value, indx []int, scalar func(float64) reflect.Value) []reflect.Value
+	ref     func(x, y []complex128, indx []int) complex128
+}{
+	{
+		name: "gthr",
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), y, x, reflect.ValueOf(indx)}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			for i, j := range indx {
+				x[i] = y[j]
+			}
+			return 0
+		},
+	},
+	{
+		name: "gthrz",
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), y, x, reflect.ValueOf(indx)}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			for i, j := range indx {
+				x[i], y[j] = y[j], 0
+			}
+			return 0
+		},
+	},
+	{
+		name: "sctr",
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), x, reflect.ValueOf(indx), y}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			for i, j := range indx {
+				y[j] = x[i]
+			}
+			return 0
+		},
+	},
+	{
+		name: "axpyi",
+		args: func(n int, x, y reflect.Value, indx []int, scalar func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), scalar(2), x, reflect.ValueOf(indx), y}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			for i, j := range indx {
+				y[j] += 2 * x[i]
+			}
+			return 0
+		},
+	},
+	{
+		name: "doti",
+		real: true,
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), x, reflect.ValueOf(indx), y}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			var s complex128
+			for i, j := range indx {
+				s += x[i] * y[j]
+			}
+			return s
+		},
+	},
+	{
+		name:    "dotui",
+		complex: true,
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), x, reflect.ValueOf(indx), y}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			var s complex128
+			for i, j := range indx {
+				s += x[i] * y[j]
+			}
+			return s
+		},
+	},
+	{
+		name:    "dotci",
+		complex: true,
+		args: func(n int, x, y reflect.Value, indx []int, _ func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), x, reflect.ValueOf(indx), y}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			var s complex128
+			for i, j := range indx {
+				s += complex(real(x[i]), -imag(x[i])) * y[j]
+			}
+			return s
+		},
+	},
+	{
+		name: "roti",
+		real: true,
+		args: func(n int, x, y reflect.Value, indx []int, scalar func(float64) reflect.Value) []reflect.Value {
+			return []reflect.Value{reflect.ValueOf(n), x, reflect.ValueOf(indx), y, scalar(0.5), scalar(-2)}
+		},
+		ref: func(x, y []complex128, indx []int) complex128 {
+			for i, j := range indx {
+				x[i], y[j] = 0.5*x[i]-2*y[j], 0.5*y[j]+2*x[i]
+			}
+			return 0
+		},
+	},
+}
+
+// TestSparseVector tests the sparse Level 1 routines against reference
+// implementations, and that they panic for illegal arguments.
+func TestSparseVector(t *testing.T) {
+	impl := reflect.ValueOf(Blas{})
+	for _, p := range []string{"S", "D", "C", "Z"} {
+		cplx := p == "C" || p == "Z"
+		for _, test := range sparseVecTests {
+			if test.real && cplx || test.complex && !cplx {
+				continue
+			}
+			name := p + test.name
+			m := impl.MethodByName(name)
+			if !m.IsValid() {
+				t.Errorf("no method %s", name)
+				continue
+			}
+			// The elements of the sparse vector are the first
+			// argument of type slice of the element type.
+			var et reflect.Type
+			for i := 0; i < m.Type().NumIn(); i++ {
+				if in := m.Type().In(i); in.Kind() == reflect.Slice && in.Elem().Kind() != reflect.Int {
+					et = in.Elem()
+					break
+				}
+			}
+			scalar := func(v float64) reflect.Value {
+				if cplx {
+					return reflect.ValueOf(complex(v, 0)).Convert(et)
+				}
+				return reflect.ValueOf(v).Convert(et)
+			}
+			values := func(n, seed int) []complex128 {
+				v := sparseValues(n, seed)
+				if !cplx {
+					for i := range v {
+						v[i] = complex(real(v[i]), 0)
+					}
+				}
+				return v
+			}
+
+			for _, indx := range [][]int{nil, {4}, {4, 0, 2}, {5, 1, 3, 0}} {
+				x, y := values(len(indx), 1), values(6, 2)
+				gx, gy := strided(et, x, 1), strided(et, y, 1)
+				want := test.ref(x, y, indx)
+				out := m.Call(test.args(len(indx), gx, gy, indx, scalar))
+				if !sameValues(gx, strided(et, x, 1)) || !sameValues(gy, strided(et, y, 1)) {
+					t.Errorf("%s indx=%v: got x=%v y=%v want x=%v y=%v", name, indx, gx, gy, x, y)
+				}
+				if len(out) != 0 {
+					var got complex128
+					if cplx {
+						got = out[0].Complex()
+					} else {
+						got = complex(out[0].Float(), 0)
+					}
+					if got != want {
+						t.Errorf("%s indx=%v: got %v want %v", name, indx, got, want)
+					}
+				}
+			}
+
+			for _, c := range []struct {
+				n      int
+				lenX   int
+				indx   []int
+				panics string
+			}{
+				{n: -1, lenX: 2, indx: []int{0, 1}, panics: "cblas: n < 0"},
+				{n: 2, lenX: 2, indx: []int{0, 6}, panics: "cblas: index out of range"},
+				{n: 2, lenX: 2, indx: []int{-1, 0}, panics: "cblas: index out of range"},
+				{n: 3, lenX: 2, indx: []int{0, 1, 2}, panics: "cblas: index out of range"},
+				{n: 3, lenX: 3, indx: []int{0, 1}, panics: "cblas: index out of range"},
+				// Indices beyond n are not used.
+				{n: 1, lenX: 2, indx: []int{0, 6}},
+			} {
+				func() {
+					defer func() {
+						r := recover()
+						if r == nil && c.panics != "" || r != nil && r != c.panics {
+							t.Errorf("%s n=%d len(x)=%d indx=%v: unexpected panic: got %v want %q", name, c.n, c.lenX, c.indx, r, c.panics)
+						}
+					}()
+					gx, gy := strided(et, values(c.lenX, 1), 1), strided(et, values(6, 2), 1)
+					m.Call(test.args(c.n, gx, gy, c.indx, scalar))
+				}()
+			}
+		}
+	}
+}
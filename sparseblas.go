@@ -0,0 +1,877 @@
+// Do not manually edit this file. It was created by the genSparse.pl script.
+
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import "github.com/gonum/blas"
+
+// SCSR is a single precision sparse matrix in compressed sparse row format. The
+// column indices and values of the elements of row i are held in
+// ColIdx[RowPtr[i]:RowPtr[i+1]] and Val[RowPtr[i]:RowPtr[i+1]].
+type SCSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Val        []float32
+}
+
+// SCSC is a single precision sparse matrix in compressed sparse column format. The
+// row indices and values of the elements of column j are held in
+// RowIdx[ColPtr[j]:ColPtr[j+1]] and Val[ColPtr[j]:ColPtr[j+1]].
+type SCSC struct {
+	Rows, Cols int
+	ColPtr     []int
+	RowIdx     []int
+	Val        []float32
+}
+
+// Scsrmv performs y = alpha * op(A) * x + beta * y for a CSR matrix A.
+func (Blas) Scsrmv(tA blas.Transpose, alpha float32, a *SCSR, x []float32, incX int, beta float32, y []float32, incY int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		sgather(a.Rows, alpha, a.RowPtr, a.ColIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		sscatter(a.Rows, a.Cols, alpha, a.RowPtr, a.ColIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Scscmv performs y = alpha * op(A) * x + beta * y for a CSC matrix A.
+func (Blas) Scscmv(tA blas.Transpose, alpha float32, a *SCSC, x []float32, incX int, beta float32, y []float32, incY int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		sscatter(a.Cols, a.Rows, alpha, a.ColPtr, a.RowIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		sgather(a.Cols, alpha, a.ColPtr, a.RowIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Scsrmm performs C = alpha * op(A) * B + beta * C for a CSR matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Scsrmm(o blas.Order, tA blas.Transpose, n int, alpha float32, a *SCSR, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	sspmm(o, tA == blas.NoTrans, tA == blas.ConjTrans, a.Rows, m, n, alpha, a.RowPtr, a.ColIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Scscmm performs C = alpha * op(A) * B + beta * C for a CSC matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Scscmm(o blas.Order, tA blas.Transpose, n int, alpha float32, a *SCSC, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	sspmm(o, tA != blas.NoTrans, tA == blas.ConjTrans, a.Cols, m, n, alpha, a.ColPtr, a.RowIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// sgather computes y[i] = alpha * Σ val[p]*x[idx[p]] + beta * y[i] for
+// each of the major lines i of a compressed matrix.
+func sgather(major int, alpha float32, ptr, idx []int, val []float32, conj bool, x []float32, incX int, beta float32, y []float32, incY int) {
+	for i := 0; i < major; i++ {
+		var s float32
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			s += v * x[idx[p]*incX]
+		}
+		if beta == 0 {
+			y[i*incY] = alpha * s
+		} else {
+			y[i*incY] = alpha*s + beta*y[i*incY]
+		}
+	}
+}
+
+// sscatter computes y = beta * y and adds alpha * val[p] * x[i] to
+// y[idx[p]] for each element p of the major lines i of a compressed
+// matrix with minor lines.
+func sscatter(major, minor int, alpha float32, ptr, idx []int, val []float32, conj bool, x []float32, incX int, beta float32, y []float32, incY int) {
+	for i := 0; i < minor; i++ {
+		if beta == 0 {
+			y[i*incY] = 0
+		} else {
+			y[i*incY] *= beta
+		}
+	}
+	for i := 0; i < major; i++ {
+		t := alpha * x[i*incX]
+		if t == 0 {
+			continue
+		}
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			y[idx[p]*incY] += v * t
+		}
+	}
+}
+
+// sspmm computes C = alpha * op(A) * B + beta * C for a compressed matrix
+// A. If gather is true the major lines of A are the rows of op(A),
+// otherwise they are its columns.
+func sspmm(o blas.Order, gather, conj bool, major, m, n int, alpha float32, ptr, idx []int, val []float32, b []float32, ldb int, beta float32, c []float32, ldc int) {
+	brs, bcs := strides(o, blas.NoTrans, ldb)
+	crs, ccs := strides(o, blas.NoTrans, ldc)
+	scale := func(i int) {
+		for j := 0; j < n; j++ {
+			if beta == 0 {
+				c[i*crs+j*ccs] = 0
+			} else {
+				c[i*crs+j*ccs] *= beta
+			}
+		}
+	}
+	if !gather {
+		for i := 0; i < m; i++ {
+			scale(i)
+		}
+	}
+	for q := 0; q < major; q++ {
+		if gather {
+			scale(q)
+		}
+		for p := ptr[q]; p < ptr[q+1]; p++ {
+			v := val[p]
+			t := alpha * v
+			// The element is at row q and column idx[p] of op(A)
+			// when gathering, and at row idx[p] and column q
+			// otherwise.
+			ci, bi := q, idx[p]
+			if !gather {
+				ci, bi = idx[p], q
+			}
+			for j := 0; j < n; j++ {
+				c[ci*crs+j*ccs] += t * b[bi*brs+j*bcs]
+			}
+		}
+	}
+}
+
+// Sgthr gathers the elements of y at the indices in indx into x,
+// x[i] = y[indx[i]] for i < n.
+func (Blas) Sgthr(n int, y []float32, x []float32, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+	}
+}
+
+// Sgthrz gathers the elements of y at the indices in indx into x and sets
+// them to zero in y.
+func (Blas) Sgthrz(n int, y []float32, x []float32, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+		y[j] = 0
+	}
+}
+
+// Ssctr scatters the elements of x into y at the indices in indx,
+// y[indx[i]] = x[i] for i < n.
+func (Blas) Ssctr(n int, x []float32, indx []int, y []float32) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		y[j] = x[i]
+	}
+}
+
+// Saxpyi adds alpha times the sparse vector x to y,
+// y[indx[i]] += alpha * x[i] for i < n.
+func (Blas) Saxpyi(n int, alpha float32, x []float32, indx []int, y []float32) {
+	checkSparseVec(n, len(x), indx, len(y))
+	if alpha == 0 {
+		return
+	}
+	for i, j := range indx[:n] {
+		y[j] += alpha * x[i]
+	}
+}
+
+// Sdoti returns the dot product of the sparse vector x and y,
+// the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Sdoti(n int, x []float32, indx []int, y []float32) float32 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s float32
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Sroti applies the plane rotation (c, s) to the sparse vector x and the
+// elements of y at the indices in indx.
+func (Blas) Sroti(n int, x []float32, indx []int, y []float32, c, s float32) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i], y[j] = c*x[i]+s*y[j], c*y[j]-s*x[i]
+	}
+}
+
+// DCSR is a double precision sparse matrix in compressed sparse row format. The
+// column indices and values of the elements of row i are held in
+// ColIdx[RowPtr[i]:RowPtr[i+1]] and Val[RowPtr[i]:RowPtr[i+1]].
+type DCSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Val        []float64
+}
+
+// DCSC is a double precision sparse matrix in compressed sparse column format. The
+// row indices and values of the elements of column j are held in
+// RowIdx[ColPtr[j]:ColPtr[j+1]] and Val[ColPtr[j]:ColPtr[j+1]].
+type DCSC struct {
+	Rows, Cols int
+	ColPtr     []int
+	RowIdx     []int
+	Val        []float64
+}
+
+// Dcsrmv performs y = alpha * op(A) * x + beta * y for a CSR matrix A.
+func (Blas) Dcsrmv(tA blas.Transpose, alpha float64, a *DCSR, x []float64, incX int, beta float64, y []float64, incY int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		dgather(a.Rows, alpha, a.RowPtr, a.ColIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		dscatter(a.Rows, a.Cols, alpha, a.RowPtr, a.ColIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Dcscmv performs y = alpha * op(A) * x + beta * y for a CSC matrix A.
+func (Blas) Dcscmv(tA blas.Transpose, alpha float64, a *DCSC, x []float64, incX int, beta float64, y []float64, incY int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		dscatter(a.Cols, a.Rows, alpha, a.ColPtr, a.RowIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		dgather(a.Cols, alpha, a.ColPtr, a.RowIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Dcsrmm performs C = alpha * op(A) * B + beta * C for a CSR matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Dcsrmm(o blas.Order, tA blas.Transpose, n int, alpha float64, a *DCSR, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	dspmm(o, tA == blas.NoTrans, tA == blas.ConjTrans, a.Rows, m, n, alpha, a.RowPtr, a.ColIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Dcscmm performs C = alpha * op(A) * B + beta * C for a CSC matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Dcscmm(o blas.Order, tA blas.Transpose, n int, alpha float64, a *DCSC, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	dspmm(o, tA != blas.NoTrans, tA == blas.ConjTrans, a.Cols, m, n, alpha, a.ColPtr, a.RowIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// dgather computes y[i] = alpha * Σ val[p]*x[idx[p]] + beta * y[i] for
+// each of the major lines i of a compressed matrix.
+func dgather(major int, alpha float64, ptr, idx []int, val []float64, conj bool, x []float64, incX int, beta float64, y []float64, incY int) {
+	for i := 0; i < major; i++ {
+		var s float64
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			s += v * x[idx[p]*incX]
+		}
+		if beta == 0 {
+			y[i*incY] = alpha * s
+		} else {
+			y[i*incY] = alpha*s + beta*y[i*incY]
+		}
+	}
+}
+
+// dscatter computes y = beta * y and adds alpha * val[p] * x[i] to
+// y[idx[p]] for each element p of the major lines i of a compressed
+// matrix with minor lines.
+func dscatter(major, minor int, alpha float64, ptr, idx []int, val []float64, conj bool, x []float64, incX int, beta float64, y []float64, incY int) {
+	for i := 0; i < minor; i++ {
+		if beta == 0 {
+			y[i*incY] = 0
+		} else {
+			y[i*incY] *= beta
+		}
+	}
+	for i := 0; i < major; i++ {
+		t := alpha * x[i*incX]
+		if t == 0 {
+			continue
+		}
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			y[idx[p]*incY] += v * t
+		}
+	}
+}
+
+// dspmm computes C = alpha * op(A) * B + beta * C for a compressed matrix
+// A. If gather is true the major lines of A are the rows of op(A),
+// otherwise they are its columns.
+func dspmm(o blas.Order, gather, conj bool, major, m, n int, alpha float64, ptr, idx []int, val []float64, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	brs, bcs := strides(o, blas.NoTrans, ldb)
+	crs, ccs := strides(o, blas.NoTrans, ldc)
+	scale := func(i int) {
+		for j := 0; j < n; j++ {
+			if beta == 0 {
+				c[i*crs+j*ccs] = 0
+			} else {
+				c[i*crs+j*ccs] *= beta
+			}
+		}
+	}
+	if !gather {
+		for i := 0; i < m; i++ {
+			scale(i)
+		}
+	}
+	for q := 0; q < major; q++ {
+		if gather {
+			scale(q)
+		}
+		for p := ptr[q]; p < ptr[q+1]; p++ {
+			v := val[p]
+			t := alpha * v
+			// The element is at row q and column idx[p] of op(A)
+			// when gathering, and at row idx[p] and column q
+			// otherwise.
+			ci, bi := q, idx[p]
+			if !gather {
+				ci, bi = idx[p], q
+			}
+			for j := 0; j < n; j++ {
+				c[ci*crs+j*ccs] += t * b[bi*brs+j*bcs]
+			}
+		}
+	}
+}
+
+// Dgthr gathers the elements of y at the indices in indx into x,
+// x[i] = y[indx[i]] for i < n.
+func (Blas) Dgthr(n int, y []float64, x []float64, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+	}
+}
+
+// Dgthrz gathers the elements of y at the indices in indx into x and sets
+// them to zero in y.
+func (Blas) Dgthrz(n int, y []float64, x []float64, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+		y[j] = 0
+	}
+}
+
+// Dsctr scatters the elements of x into y at the indices in indx,
+// y[indx[i]] = x[i] for i < n.
+func (Blas) Dsctr(n int, x []float64, indx []int, y []float64) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		y[j] = x[i]
+	}
+}
+
+// Daxpyi adds alpha times the sparse vector x to y,
+// y[indx[i]] += alpha * x[i] for i < n.
+func (Blas) Daxpyi(n int, alpha float64, x []float64, indx []int, y []float64) {
+	checkSparseVec(n, len(x), indx, len(y))
+	if alpha == 0 {
+		return
+	}
+	for i, j := range indx[:n] {
+		y[j] += alpha * x[i]
+	}
+}
+
+// Ddoti returns the dot product of the sparse vector x and y,
+// the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Ddoti(n int, x []float64, indx []int, y []float64) float64 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s float64
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Droti applies the plane rotation (c, s) to the sparse vector x and the
+// elements of y at the indices in indx.
+func (Blas) Droti(n int, x []float64, indx []int, y []float64, c, s float64) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i], y[j] = c*x[i]+s*y[j], c*y[j]-s*x[i]
+	}
+}
+
+// CCSR is a single precision complex sparse matrix in compressed sparse row format. The
+// column indices and values of the elements of row i are held in
+// ColIdx[RowPtr[i]:RowPtr[i+1]] and Val[RowPtr[i]:RowPtr[i+1]].
+type CCSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Val        []complex64
+}
+
+// CCSC is a single precision complex sparse matrix in compressed sparse column format. The
+// row indices and values of the elements of column j are held in
+// RowIdx[ColPtr[j]:ColPtr[j+1]] and Val[ColPtr[j]:ColPtr[j+1]].
+type CCSC struct {
+	Rows, Cols int
+	ColPtr     []int
+	RowIdx     []int
+	Val        []complex64
+}
+
+// Ccsrmv performs y = alpha * op(A) * x + beta * y for a CSR matrix A.
+func (Blas) Ccsrmv(tA blas.Transpose, alpha complex64, a *CCSR, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		cgather(a.Rows, alpha, a.RowPtr, a.ColIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		cscatter(a.Rows, a.Cols, alpha, a.RowPtr, a.ColIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Ccscmv performs y = alpha * op(A) * x + beta * y for a CSC matrix A.
+func (Blas) Ccscmv(tA blas.Transpose, alpha complex64, a *CCSC, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		cscatter(a.Cols, a.Rows, alpha, a.ColPtr, a.RowIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		cgather(a.Cols, alpha, a.ColPtr, a.RowIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Ccsrmm performs C = alpha * op(A) * B + beta * C for a CSR matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Ccsrmm(o blas.Order, tA blas.Transpose, n int, alpha complex64, a *CCSR, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	cspmm(o, tA == blas.NoTrans, tA == blas.ConjTrans, a.Rows, m, n, alpha, a.RowPtr, a.ColIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Ccscmm performs C = alpha * op(A) * B + beta * C for a CSC matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Ccscmm(o blas.Order, tA blas.Transpose, n int, alpha complex64, a *CCSC, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	cspmm(o, tA != blas.NoTrans, tA == blas.ConjTrans, a.Cols, m, n, alpha, a.ColPtr, a.RowIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// cgather computes y[i] = alpha * Σ val[p]*x[idx[p]] + beta * y[i] for
+// each of the major lines i of a compressed matrix.
+func cgather(major int, alpha complex64, ptr, idx []int, val []complex64, conj bool, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	for i := 0; i < major; i++ {
+		var s complex64
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			s += v * x[idx[p]*incX]
+		}
+		if beta == 0 {
+			y[i*incY] = alpha * s
+		} else {
+			y[i*incY] = alpha*s + beta*y[i*incY]
+		}
+	}
+}
+
+// cscatter computes y = beta * y and adds alpha * val[p] * x[i] to
+// y[idx[p]] for each element p of the major lines i of a compressed
+// matrix with minor lines.
+func cscatter(major, minor int, alpha complex64, ptr, idx []int, val []complex64, conj bool, x []complex64, incX int, beta complex64, y []complex64, incY int) {
+	for i := 0; i < minor; i++ {
+		if beta == 0 {
+			y[i*incY] = 0
+		} else {
+			y[i*incY] *= beta
+		}
+	}
+	for i := 0; i < major; i++ {
+		t := alpha * x[i*incX]
+		if t == 0 {
+			continue
+		}
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			y[idx[p]*incY] += v * t
+		}
+	}
+}
+
+// cspmm computes C = alpha * op(A) * B + beta * C for a compressed matrix
+// A. If gather is true the major lines of A are the rows of op(A),
+// otherwise they are its columns.
+func cspmm(o blas.Order, gather, conj bool, major, m, n int, alpha complex64, ptr, idx []int, val []complex64, b []complex64, ldb int, beta complex64, c []complex64, ldc int) {
+	brs, bcs := strides(o, blas.NoTrans, ldb)
+	crs, ccs := strides(o, blas.NoTrans, ldc)
+	scale := func(i int) {
+		for j := 0; j < n; j++ {
+			if beta == 0 {
+				c[i*crs+j*ccs] = 0
+			} else {
+				c[i*crs+j*ccs] *= beta
+			}
+		}
+	}
+	if !gather {
+		for i := 0; i < m; i++ {
+			scale(i)
+		}
+	}
+	for q := 0; q < major; q++ {
+		if gather {
+			scale(q)
+		}
+		for p := ptr[q]; p < ptr[q+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			t := alpha * v
+			// The element is at row q and column idx[p] of op(A)
+			// when gathering, and at row idx[p] and column q
+			// otherwise.
+			ci, bi := q, idx[p]
+			if !gather {
+				ci, bi = idx[p], q
+			}
+			for j := 0; j < n; j++ {
+				c[ci*crs+j*ccs] += t * b[bi*brs+j*bcs]
+			}
+		}
+	}
+}
+
+// Cgthr gathers the elements of y at the indices in indx into x,
+// x[i] = y[indx[i]] for i < n.
+func (Blas) Cgthr(n int, y []complex64, x []complex64, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+	}
+}
+
+// Cgthrz gathers the elements of y at the indices in indx into x and sets
+// them to zero in y.
+func (Blas) Cgthrz(n int, y []complex64, x []complex64, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+		y[j] = 0
+	}
+}
+
+// Csctr scatters the elements of x into y at the indices in indx,
+// y[indx[i]] = x[i] for i < n.
+func (Blas) Csctr(n int, x []complex64, indx []int, y []complex64) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		y[j] = x[i]
+	}
+}
+
+// Caxpyi adds alpha times the sparse vector x to y,
+// y[indx[i]] += alpha * x[i] for i < n.
+func (Blas) Caxpyi(n int, alpha complex64, x []complex64, indx []int, y []complex64) {
+	checkSparseVec(n, len(x), indx, len(y))
+	if alpha == 0 {
+		return
+	}
+	for i, j := range indx[:n] {
+		y[j] += alpha * x[i]
+	}
+}
+
+// Cdotui returns the unconjugated dot product of the sparse vector x and
+// y, the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Cdotui(n int, x []complex64, indx []int, y []complex64) complex64 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s complex64
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Cdotci returns the dot product of the conjugate of the sparse vector x
+// and y, the sum of conj(x[i]) * y[indx[i]] for i < n.
+func (Blas) Cdotci(n int, x []complex64, indx []int, y []complex64) complex64 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s complex64
+	for i, j := range indx[:n] {
+		v := x[i]
+		s += complex(real(v), -imag(v)) * y[j]
+	}
+	return s
+}
+
+// ZCSR is a double precision complex sparse matrix in compressed sparse row format. The
+// column indices and values of the elements of row i are held in
+// ColIdx[RowPtr[i]:RowPtr[i+1]] and Val[RowPtr[i]:RowPtr[i+1]].
+type ZCSR struct {
+	Rows, Cols int
+	RowPtr     []int
+	ColIdx     []int
+	Val        []complex128
+}
+
+// ZCSC is a double precision complex sparse matrix in compressed sparse column format. The
+// row indices and values of the elements of column j are held in
+// RowIdx[ColPtr[j]:ColPtr[j+1]] and Val[ColPtr[j]:ColPtr[j+1]].
+type ZCSC struct {
+	Rows, Cols int
+	ColPtr     []int
+	RowIdx     []int
+	Val        []complex128
+}
+
+// Zcsrmv performs y = alpha * op(A) * x + beta * y for a CSR matrix A.
+func (Blas) Zcsrmv(tA blas.Transpose, alpha complex128, a *ZCSR, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		zgather(a.Rows, alpha, a.RowPtr, a.ColIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		zscatter(a.Rows, a.Cols, alpha, a.RowPtr, a.ColIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Zcscmv performs y = alpha * op(A) * x + beta * y for a CSC matrix A.
+func (Blas) Zcscmv(tA blas.Transpose, alpha complex128, a *ZCSC, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	checkSpmv(tA, a.Rows, a.Cols, len(x), incX, len(y), incY)
+	if tA == blas.NoTrans {
+		zscatter(a.Cols, a.Rows, alpha, a.ColPtr, a.RowIdx, a.Val, false, x, incX, beta, y, incY)
+	} else {
+		zgather(a.Cols, alpha, a.ColPtr, a.RowIdx, a.Val, tA == blas.ConjTrans, x, incX, beta, y, incY)
+	}
+}
+
+// Zcsrmm performs C = alpha * op(A) * B + beta * C for a CSR matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Zcsrmm(o blas.Order, tA blas.Transpose, n int, alpha complex128, a *ZCSR, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if len(a.RowPtr) != a.Rows+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.RowPtr, a.ColIdx, len(a.Val), a.Cols)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	zspmm(o, tA == blas.NoTrans, tA == blas.ConjTrans, a.Rows, m, n, alpha, a.RowPtr, a.ColIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// Zcscmm performs C = alpha * op(A) * B + beta * C for a CSC matrix A and
+// dense matrices B and C, where op(A) is m×k, B is k×n and C is m×n.
+func (Blas) Zcscmm(o blas.Order, tA blas.Transpose, n int, alpha complex128, a *ZCSC, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if len(a.ColPtr) != a.Cols+1 {
+		panic("cblas: malformed sparse matrix")
+	}
+	checkSparse(a.Rows, a.Cols, a.ColPtr, a.RowIdx, len(a.Val), a.Rows)
+	m, _ := checkSpmm(o, tA, a.Rows, a.Cols, n, len(b), ldb, len(c), ldc)
+	zspmm(o, tA != blas.NoTrans, tA == blas.ConjTrans, a.Cols, m, n, alpha, a.ColPtr, a.RowIdx, a.Val, b, ldb, beta, c, ldc)
+}
+
+// zgather computes y[i] = alpha * Σ val[p]*x[idx[p]] + beta * y[i] for
+// each of the major lines i of a compressed matrix.
+func zgather(major int, alpha complex128, ptr, idx []int, val []complex128, conj bool, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	for i := 0; i < major; i++ {
+		var s complex128
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			s += v * x[idx[p]*incX]
+		}
+		if beta == 0 {
+			y[i*incY] = alpha * s
+		} else {
+			y[i*incY] = alpha*s + beta*y[i*incY]
+		}
+	}
+}
+
+// zscatter computes y = beta * y and adds alpha * val[p] * x[i] to
+// y[idx[p]] for each element p of the major lines i of a compressed
+// matrix with minor lines.
+func zscatter(major, minor int, alpha complex128, ptr, idx []int, val []complex128, conj bool, x []complex128, incX int, beta complex128, y []complex128, incY int) {
+	for i := 0; i < minor; i++ {
+		if beta == 0 {
+			y[i*incY] = 0
+		} else {
+			y[i*incY] *= beta
+		}
+	}
+	for i := 0; i < major; i++ {
+		t := alpha * x[i*incX]
+		if t == 0 {
+			continue
+		}
+		for p := ptr[i]; p < ptr[i+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			y[idx[p]*incY] += v * t
+		}
+	}
+}
+
+// zspmm computes C = alpha * op(A) * B + beta * C for a compressed matrix
+// A. If gather is true the major lines of A are the rows of op(A),
+// otherwise they are its columns.
+func zspmm(o blas.Order, gather, conj bool, major, m, n int, alpha complex128, ptr, idx []int, val []complex128, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	brs, bcs := strides(o, blas.NoTrans, ldb)
+	crs, ccs := strides(o, blas.NoTrans, ldc)
+	scale := func(i int) {
+		for j := 0; j < n; j++ {
+			if beta == 0 {
+				c[i*crs+j*ccs] = 0
+			} else {
+				c[i*crs+j*ccs] *= beta
+			}
+		}
+	}
+	if !gather {
+		for i := 0; i < m; i++ {
+			scale(i)
+		}
+	}
+	for q := 0; q < major; q++ {
+		if gather {
+			scale(q)
+		}
+		for p := ptr[q]; p < ptr[q+1]; p++ {
+			v := val[p]
+			if conj {
+				v = complex(real(v), -imag(v))
+			}
+			t := alpha * v
+			// The element is at row q and column idx[p] of op(A)
+			// when gathering, and at row idx[p] and column q
+			// otherwise.
+			ci, bi := q, idx[p]
+			if !gather {
+				ci, bi = idx[p], q
+			}
+			for j := 0; j < n; j++ {
+				c[ci*crs+j*ccs] += t * b[bi*brs+j*bcs]
+			}
+		}
+	}
+}
+
+// Zgthr gathers the elements of y at the indices in indx into x,
+// x[i] = y[indx[i]] for i < n.
+func (Blas) Zgthr(n int, y []complex128, x []complex128, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+	}
+}
+
+// Zgthrz gathers the elements of y at the indices in indx into x and sets
+// them to zero in y.
+func (Blas) Zgthrz(n int, y []complex128, x []complex128, indx []int) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		x[i] = y[j]
+		y[j] = 0
+	}
+}
+
+// Zsctr scatters the elements of x into y at the indices in indx,
+// y[indx[i]] = x[i] for i < n.
+func (Blas) Zsctr(n int, x []complex128, indx []int, y []complex128) {
+	checkSparseVec(n, len(x), indx, len(y))
+	for i, j := range indx[:n] {
+		y[j] = x[i]
+	}
+}
+
+// Zaxpyi adds alpha times the sparse vector x to y,
+// y[indx[i]] += alpha * x[i] for i < n.
+func (Blas) Zaxpyi(n int, alpha complex128, x []complex128, indx []int, y []complex128) {
+	checkSparseVec(n, len(x), indx, len(y))
+	if alpha == 0 {
+		return
+	}
+	for i, j := range indx[:n] {
+		y[j] += alpha * x[i]
+	}
+}
+
+// Zdotui returns the unconjugated dot product of the sparse vector x and
+// y, the sum of x[i] * y[indx[i]] for i < n.
+func (Blas) Zdotui(n int, x []complex128, indx []int, y []complex128) complex128 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s complex128
+	for i, j := range indx[:n] {
+		s += x[i] * y[j]
+	}
+	return s
+}
+
+// Zdotci returns the dot product of the conjugate of the sparse vector x
+// and y, the sum of conj(x[i]) * y[indx[i]] for i < n.
+func (Blas) Zdotci(n int, x []complex128, indx []int, y []complex128) complex128 {
+	checkSparseVec(n, len(x), indx, len(y))
+	var s complex128
+	for i, j := range indx[:n] {
+		v := x[i]
+		s += complex(real(v), -imag(v)) * y[j]
+	}
+	return s
+}
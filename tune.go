@@ -0,0 +1,371 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cblas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/gonum/blas"
+)
+
+// The thresholds of the Go kernels and the tile sizes of the tiled
+// routines depend on the machine and the library. They may be measured
+// by Tune, or the blastune command, and saved as a profile that is applied
+// when the package is initialized if it is named by the EnvProfile
+// environment variable.
+
+// EnvProfile is the environment variable naming a JSON profile file that
+// is applied when the package is initialized.
+const EnvProfile = "CBLAS_PROFILE"
+
+// ErrProfileLibrary is returned by Apply for a profile measured with a
+// different library.
+var ErrProfileLibrary = errors.New("cblas: profile measured with a different library")
+
+// Profile holds the thresholds and tile sizes of the package.
+type Profile struct {
+	// Library is the vendor and version of the library the profile
+	// was measured with, as reported by Info. An empty Library matches
+	// any library.
+	Library string `json:"library,omitempty"`
+
+	// Thresholds holds the thresholds of the Blas methods with Go
+	// kernels, as set by SetThreshold.
+	Thresholds map[string]int `json:"thresholds,omitempty"`
+
+	// Tiles holds the tile sizes of the tiled routines, as set by
+	// SetTile.
+	Tiles map[string]int `json:"tiles,omitempty"`
+}
+
+// tiles holds the tile sizes of the tiled routines.
+var tiles = map[string]*int64{
+	"gemm": &gemmTile,
+	"ctx":  &ctxTile,
+	"ctxk": &ctxKTile,
+}
+
+// Tiles returns the names of the tile sizes.
+func Tiles() []string {
+	names := make([]string, 0, len(tiles))
+	for name := range tiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Tile returns the named tile size. It panics if there is no such tile.
+func Tile(name string) int {
+	t, ok := tiles[name]
+	if !ok {
+		panic("cblas: no tile " + name)
+	}
+	return tileSize(t)
+}
+
+// SetTile sets the named tile size and returns the previous size. The
+// "gemm" tile is the size of the square tiles converted by the mixed and
+// half precision matrix multiplications, the "ctx" tile the size of the
+// tiles computed between checks of the context by the Ctx routines, and
+// the "ctxk" tile the size of the panels of the inner dimension of their
+// gemm tiles, with zero for no split. It panics if there is no such tile
+// or n is less than the smallest size of the tile.
+func SetTile(name string, n int) int {
+	t, ok := tiles[name]
+	if !ok {
+		panic("cblas: no tile " + name)
+	}
+	if n < minTile(name) {
+		panic(fmt.Sprintf("cblas: tile size < %d", minTile(name)))
+	}
+	return int(atomic.SwapInt64(t, int64(n)))
+}
+
+// minTile returns the smallest size of the named tile, which is one for
+// all but the "ctxk" tile.
+func minTile(name string) int {
+	if name == "ctxk" {
+		return 0
+	}
+	return 1
+}
+
+func tileSize(t *int64) int {
+	return int(atomic.LoadInt64(t))
+}
+
+// libraryID returns the vendor and version of the library.
+func libraryID() string {
+	l := Info()
+	if l.Version == "" {
+		return l.Vendor
+	}
+	return l.Vendor + " " + l.Version
+}
+
+// CurrentProfile returns the current thresholds and tile sizes.
+func CurrentProfile() Profile {
+	p := Profile{
+		Library:    libraryID(),
+		Thresholds: make(map[string]int),
+		Tiles:      make(map[string]int),
+	}
+	for _, name := range Thresholds() {
+		p.Thresholds[name] = Threshold(name)
+	}
+	for _, name := range Tiles() {
+		p.Tiles[name] = Tile(name)
+	}
+	return p
+}
+
+// Apply sets the thresholds and tile sizes held by the profile, leaving
+// those it does not hold unchanged. It returns an error without changing
+// any if the profile was measured with a different library or holds an
+// unknown name or an invalid value.
+func (p Profile) Apply() error {
+	if p.Library != "" && p.Library != libraryID() {
+		return ErrProfileLibrary
+	}
+	for name, n := range p.Thresholds {
+		if _, ok := thresholds[name]; !ok {
+			return fmt.Errorf("cblas: no Go kernel for %s", name)
+		}
+		if n < 0 {
+			return fmt.Errorf("cblas: negative threshold for %s", name)
+		}
+	}
+	for name, n := range p.Tiles {
+		if _, ok := tiles[name]; !ok {
+			return fmt.Errorf("cblas: no tile %s", name)
+		}
+		if n < minTile(name) {
+			return fmt.Errorf("cblas: tile size < %d for %s", minTile(name), name)
+		}
+	}
+	for name, n := range p.Thresholds {
+		SetThreshold(name, n)
+	}
+	for name, n := range p.Tiles {
+		SetTile(name, n)
+	}
+	return nil
+}
+
+// ReadProfile reads a JSON profile from r.
+func ReadProfile(r io.Reader) (Profile, error) {
+	var p Profile
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(&p)
+	return p, err
+}
+
+// Write writes the profile to w as JSON.
+func (p Profile) Write(w io.Writer) error {
+	b, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// profileErr is the error in applying the profile named by EnvProfile.
+var profileErr error
+
+func init() {
+	path := os.Getenv(EnvProfile)
+	if path == "" {
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		profileErr = err
+		return
+	}
+	defer f.Close()
+	p, err := ReadProfile(f)
+	if err != nil {
+		profileErr = fmt.Errorf("cblas: reading profile %s: %v", path, err)
+		return
+	}
+	profileErr = p.Apply()
+}
+
+// ProfileError returns the error, if any, in applying the profile named by
+// the EnvProfile environment variable when the package was initialized. A
+// profile that cannot be applied leaves the defaults in place.
+func ProfileError() error {
+	return profileErr
+}
+
+// tuneThresholds holds the problem sizes at which the routines with Go
+// kernels are timed, and functions returning a call of each routine with
+// a problem of a given size.
+var tuneThresholds = map[string]struct {
+	sizes []int
+	call  func(size int) func()
+}{
+	"Saxpy": {level1Sizes, func(n int) func() {
+		x, y := make([]float32, n), make([]float32, n)
+		return func() { Blas{}.Saxpy(n, 0.5, x, 1, y, 1) }
+	}},
+	"Daxpy": {level1Sizes, func(n int) func() {
+		x, y := make([]float64, n), make([]float64, n)
+		return func() { Blas{}.Daxpy(n, 0.5, x, 1, y, 1) }
+	}},
+	"Sdot": {level1Sizes, func(n int) func() {
+		x, y := make([]float32, n), make([]float32, n)
+		return func() { Blas{}.Sdot(n, x, 1, y, 1) }
+	}},
+	"Ddot": {level1Sizes, func(n int) func() {
+		x, y := make([]float64, n), make([]float64, n)
+		return func() { Blas{}.Ddot(n, x, 1, y, 1) }
+	}},
+	"Sscal": {level1Sizes, func(n int) func() {
+		x := make([]float32, n)
+		return func() { Blas{}.Sscal(n, 0.5, x, 1) }
+	}},
+	"Dscal": {level1Sizes, func(n int) func() {
+		x := make([]float64, n)
+		return func() { Blas{}.Dscal(n, 0.5, x, 1) }
+	}},
+	"Sgemv": {level2Sizes, func(size int) func() {
+		n := isqrt(size)
+		a, x, y := make([]float32, n*n), make([]float32, n), make([]float32, n)
+		return func() { Blas{}.Sgemv(blas.RowMajor, blas.NoTrans, n, n, 0.5, a, n, x, 1, 0.5, y, 1) }
+	}},
+	"Dgemv": {level2Sizes, func(size int) func() {
+		n := isqrt(size)
+		a, x, y := make([]float64, n*n), make([]float64, n), make([]float64, n)
+		return func() { Blas{}.Dgemv(blas.RowMajor, blas.NoTrans, n, n, 0.5, a, n, x, 1, 0.5, y, 1) }
+	}},
+}
+
+// Problem sizes at which the thresholds are measured. The Level 2 sizes
+// are those of square matrices.
+var (
+	level1Sizes = []int{1, 2, 4, 8, 16, 32, 64, 128, 256, 512, 1024, 2048, 4096}
+	level2Sizes = []int{1, 4, 16, 64, 256, 1024, 4096, 16384}
+)
+
+func isqrt(n int) int {
+	r := 0
+	for (r+1)*(r+1) <= n {
+		r++
+	}
+	return r
+}
+
+// tuneTiles holds the candidate sizes of each tile, and functions
+// returning a call of a routine using the tile.
+var tuneTiles = map[string]struct {
+	sizes []int
+	call  func() func()
+}{
+	"gemm": {[]int{64, 128, 256, 512}, func() func() {
+		const n = 512
+		a, b, c := make([]float32, n*n), make([]float32, n*n), make([]float64, n*n)
+		return func() {
+			Blas{}.Dsgemm(blas.RowMajor, blas.NoTrans, blas.NoTrans, n, n, n, 1, a, n, b, n, 0, c, n)
+		}
+	}},
+}
+
+// Tune measures the thresholds below which the Go kernels of the Blas
+// methods are faster than the library, and the fastest tile sizes, on the
+// running machine and returns them as a profile. Each measurement takes
+// about the given time. The returned profile is not applied. The "ctx"
+// and "ctxk" tiles trade speed for the latency of cancellation, so they
+// are not tuned.
+//
+// Tune changes the thresholds and tile sizes while it runs and restores
+// them before returning, so the package should not otherwise be in use.
+func Tune(budget time.Duration) Profile {
+	p := Profile{
+		Library:    libraryID(),
+		Thresholds: make(map[string]int),
+		Tiles:      make(map[string]int),
+	}
+	for _, name := range Thresholds() {
+		p.Thresholds[name] = crossover(name, budget)
+	}
+	for _, name := range Tiles() {
+		if _, ok := tuneTiles[name]; ok {
+			p.Tiles[name] = bestTile(name, budget)
+		}
+	}
+	return p
+}
+
+// crossover returns the threshold of the named routine above the largest
+// measured size at which its Go kernel is faster than the library. The
+// search stops once the library is faster at two consecutive sizes.
+func crossover(name string, budget time.Duration) int {
+	prev := SetThreshold(name, 0)
+	defer SetThreshold(name, prev)
+	t := tuneThresholds[name]
+	threshold := 0
+	lost := 0
+	for _, size := range t.sizes {
+		f := t.call(size)
+		SetThreshold(name, size+1)
+		kernel := measure(f, budget)
+		SetThreshold(name, 0)
+		library := measure(f, budget)
+		if kernel < library {
+			threshold = size + 1
+			lost = 0
+		} else if lost++; lost == 2 {
+			break
+		}
+	}
+	return threshold
+}
+
+// bestTile returns the candidate size of the named tile with the fastest
+// call.
+func bestTile(name string, budget time.Duration) int {
+	t := tuneTiles[name]
+	prev := Tile(name)
+	defer SetTile(name, prev)
+	f := t.call()
+	best, bestTime := prev, time.Duration(-1)
+	for _, size := range t.sizes {
+		SetTile(name, size)
+		d := measure(f, budget)
+		if bestTime < 0 || d < bestTime {
+			best, bestTime = size, d
+		}
+	}
+	return best
+}
+
+// measure returns the mean time of a call of f, calling it repeatedly for
+// at least the given time after a warm-up call.
+func measure(f func(), budget time.Duration) time.Duration {
+	f()
+	n := 1
+	for {
+		start := time.Now()
+		for i := 0; i < n; i++ {
+			f()
+		}
+		d := time.Since(start)
+		if d >= budget {
+			return d / time.Duration(n)
+		}
+		n *= 2
+	}
+}
@@ -0,0 +1,517 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xblat
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// sfac is the scale factor used by the reference tester's STEST;
+// results pass if their error is within 1/sfac ulps of their size.
+const sfac = 9.765625e-4
+
+var level1Routines = []string{
+	"DDOT", "DAXPY", "DROTG", "DROT", "DCOPY", "DSWAP",
+	"DNRM2", "DASUM", "DSCAL", "IDAMAX", "DROTMG", "DROTM",
+}
+
+// Level1 runs the level 1 tests against impl, writing a summary of the
+// results to w. It returns whether all tests passed.
+//
+// Unlike the reference tester, the expected results are computed rather
+// than tabulated.
+func Level1(w io.Writer, impl blas.Float64) bool {
+	t := &level1{w: w, impl: impl}
+	fmt.Fprintf(w, " Real BLAS Test Program Results\n\n")
+	ok := true
+	for i, name := range level1Routines {
+		fmt.Fprintf(w, "\n Test of subprogram number%3d%12s\n", i+1, name)
+		t.name = name
+		t.pass = true
+		t.gen.reset()
+		switch name {
+		case "DROTG":
+			t.check0rotg()
+		case "DROTMG":
+			t.check0rotmg()
+		case "DNRM2", "DASUM", "DSCAL", "IDAMAX":
+			t.check1()
+		default:
+			t.check2()
+		}
+		if t.pass {
+			fmt.Fprintf(w, "%36s----- PASS -----\n", "")
+		}
+		ok = ok && t.pass
+	}
+	return ok
+}
+
+var (
+	level1N    = []int{0, 1, 2, 4, 7}
+	level1Inc  = []int{1, 2}
+	level1Inc2 = []int{1, 2, -2, -1} // Increments of the routines taking two vectors.
+	level1Vals = []float64{0.3, -1, 0, 1}
+)
+
+// level1 holds the state of a run of the level 1 tests.
+type level1 struct {
+	w    io.Writer
+	impl blas.Float64
+	gen  generator
+
+	name string
+	pass bool
+}
+
+// stest compares computed values with the expected values, reporting any
+// that differ by more than their size allows, following STEST.
+func (t *level1) stest(desc string, comp, want, size []float64) {
+	for i := range want {
+		d := comp[i] - want[i]
+		if math.Abs(sfac*d) <= math.Abs(size[i])*eps && !math.IsNaN(d) {
+			continue
+		}
+		if t.pass {
+			fmt.Fprintf(t.w, "%39sFAIL\n", "")
+			fmt.Fprintf(t.w, " CALL%45sI%28sCOMP(I)%28sTRUE(I)  DIFFERENCE     SIZE(I)\n", "", "", "")
+		}
+		t.pass = false
+		fmt.Fprintf(t.w, " %-48s%2d%36.8e%36.8e%12.4e%12.4e\n", desc, i, comp[i], want[i], d, size[i])
+	}
+}
+
+// itest reports a failure if the computed integer differs from the
+// expected value, following ITEST1.
+func (t *level1) itest(desc string, comp, want int) {
+	if comp == want {
+		return
+	}
+	if t.pass {
+		fmt.Fprintf(t.w, "%39sFAIL\n", "")
+		fmt.Fprintf(t.w, " CALL%45s COMP TRUE  DIFFERENCE\n", "")
+	}
+	t.pass = false
+	fmt.Fprintf(t.w, " %-48s%5d%5d%12d\n", desc, comp, want, comp-want)
+}
+
+// call calls f, reporting a failure if it panics.
+func (t *level1) call(desc string, f func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if t.pass {
+				fmt.Fprintf(t.w, "%39sFAIL\n", "")
+			}
+			t.pass = false
+			fmt.Fprintf(t.w, " %s PANICKED: %v\n", desc, r)
+			ok = false
+		}
+	}()
+	f()
+	return true
+}
+
+// unchanged reports a failure if any element of v outside
+// the n elements with increment inc differs from orig.
+func (t *level1) unchanged(desc string, v, orig []float64, n, inc int) {
+	for i := range v {
+		if i%abs(inc) == 0 && i/abs(inc) < n {
+			continue
+		}
+		if !equal(v[i], orig[i]) {
+			if t.pass {
+				fmt.Fprintf(t.w, "%39sFAIL\n", "")
+			}
+			t.pass = false
+			fmt.Fprintf(t.w, " %s CHANGED ELEMENT %d OUTSIDE THE VECTOR\n", desc, i)
+			return
+		}
+	}
+}
+
+func (t *level1) vector(n, inc int) []float64 {
+	v := make([]float64, max(1, 1+(n-1)*abs(inc))+abs(inc))
+	for i := range v {
+		v[i] = rogue
+	}
+	for i := 0; i < n; i++ {
+		v[i*abs(inc)] = t.gen.next() * 2
+	}
+	return v
+}
+
+// check0rotg tests DROTG using the data of the reference tester.
+func (t *level1) check0rotg() {
+	da := []float64{0.3, 0.4, -0.3, -0.4, -0.3, 0, 0, 1}
+	db := []float64{0.4, 0.3, 0.4, 0.3, -0.4, 0, 1, 0}
+	for k := range da {
+		desc := fmt.Sprintf("DROTG(%g, %g)", da[k], db[k])
+		var c, s, r, z float64
+		if !t.call(desc, func() { c, s, r, z = t.impl.Drotg(da[k], db[k]) }) {
+			continue
+		}
+		wc, ws, wr, wz := drotg(da[k], db[k])
+		t.stest(desc, []float64{c, s, r, z}, []float64{wc, ws, wr, wz}, []float64{1, 1, 1, 1})
+	}
+}
+
+// check0rotmg tests DROTMG, including values that require rescaling.
+func (t *level1) check0rotmg() {
+	data := [][4]float64{
+		{0.1, 0.3, 1.2, 0.2},
+		{0.7, 0.2, 0.6, 4.2},
+		{0, 0, 0, 0},
+		{4, -1, 2, 4},
+		{6e-10, 2e-2, 1e5, 10},
+		{4e10, 2e-2, 1e-5, 10},
+		{2e-10, 4e-2, 1e5, 10},
+		{2e10, 4e-2, 1e-5, 10},
+		{4, -2, 8, 4},
+		{-1, 2, 3, 4},
+	}
+	for _, v := range data {
+		desc := fmt.Sprintf("DROTMG(%g, %g, %g, %g)", v[0], v[1], v[2], v[3])
+		var p *blas.DrotmParams
+		var d1, d2, b1 float64
+		if !t.call(desc, func() { p, d1, d2, b1 = t.impl.Drotmg(v[0], v[1], v[2], v[3]) }) {
+			continue
+		}
+		want, wd1, wd2, wb1 := drotmg(v[0], v[1], v[2], v[3])
+		comp := []float64{d1, d2, b1, p.Flag}
+		exp := []float64{wd1, wd2, wb1, want.Flag}
+		// Only the elements of H that are not implied by the flag are compared.
+		switch want.Flag {
+		case -1:
+			comp = append(comp, p.H[:]...)
+			exp = append(exp, want.H[:]...)
+		case 0:
+			comp = append(comp, p.H[1], p.H[2])
+			exp = append(exp, want.H[1], want.H[2])
+		case 1:
+			comp = append(comp, p.H[0], p.H[3])
+			exp = append(exp, want.H[0], want.H[3])
+		}
+		size := make([]float64, len(exp))
+		for i, e := range exp {
+			size[i] = math.Max(math.Abs(e), 1)
+		}
+		t.stest(desc, comp, exp, size)
+	}
+}
+
+// check1 tests the routines that take a single vector.
+func (t *level1) check1() {
+	for _, inc := range level1Inc {
+		for _, n := range level1N {
+			x := t.vector(n, inc)
+			xs := clone(x)
+			xv := make([]float64, n)
+			for i := range xv {
+				xv[i] = x[i*inc]
+			}
+			switch t.name {
+			case "DNRM2":
+				desc := fmt.Sprintf("DNRM2(%d, X, %d)", n, inc)
+				var got float64
+				if !t.call(desc, func() { got = t.impl.Dnrm2(n, x, inc) }) {
+					continue
+				}
+				var ss float64
+				for _, v := range xv {
+					ss += v * v
+				}
+				want := math.Sqrt(ss)
+				t.stest(desc, []float64{got}, []float64{want}, []float64{want})
+			case "DASUM":
+				desc := fmt.Sprintf("DASUM(%d, X, %d)", n, inc)
+				var got float64
+				if !t.call(desc, func() { got = t.impl.Dasum(n, x, inc) }) {
+					continue
+				}
+				var want float64
+				for _, v := range xv {
+					want += math.Abs(v)
+				}
+				t.stest(desc, []float64{got}, []float64{want}, []float64{want})
+			case "DSCAL":
+				for _, alpha := range level1Vals {
+					x := clone(xs)
+					desc := fmt.Sprintf("DSCAL(%d, %g, X, %d)", n, alpha, inc)
+					if !t.call(desc, func() { t.impl.Dscal(n, alpha, x, inc) }) {
+						continue
+					}
+					got := make([]float64, n)
+					want := make([]float64, n)
+					size := make([]float64, n)
+					for i, v := range xv {
+						got[i] = x[i*inc]
+						want[i] = alpha * v
+						size[i] = math.Abs(want[i])
+					}
+					t.stest(desc, got, want, size)
+					t.unchanged(desc, x, xs, n, inc)
+				}
+				continue
+			case "IDAMAX":
+				desc := fmt.Sprintf("IDAMAX(%d, X, %d)", n, inc)
+				var got int
+				if !t.call(desc, func() { got = t.impl.Idamax(n, x, inc) }) {
+					continue
+				}
+				want := -1
+				if n > 0 {
+					want = 0
+				}
+				for i, v := range xv {
+					if math.Abs(v) > math.Abs(xv[want]) {
+						want = i
+					}
+				}
+				if n == 0 {
+					// The result for an empty vector is
+					// implementation defined.
+					continue
+				}
+				t.itest(desc, got, want)
+			}
+			t.unchanged(t.name, x, xs, 0, inc)
+		}
+	}
+}
+
+// check2 tests the routines that take two vectors.
+func (t *level1) check2() {
+	for _, incX := range level1Inc2 {
+		for _, incY := range level1Inc2 {
+			for _, n := range level1N {
+				x, y := t.vector(n, incX), t.vector(n, incY)
+				xs, ys := clone(x), clone(y)
+				xv, yv := vector(x, n, incX), vector(y, n, incY)
+				// Expected values of the updated x and y, and their sizes.
+				var wx, wy, sx, sy []float64
+				var desc string
+				switch t.name {
+				case "DDOT":
+					desc = fmt.Sprintf("DDOT(%d, X, %d, Y, %d)", n, incX, incY)
+					var got float64
+					if !t.call(desc, func() { got = t.impl.Ddot(n, x, incX, y, incY) }) {
+						continue
+					}
+					var want, size float64
+					for i := range xv {
+						want += xv[i] * yv[i]
+						size += math.Abs(xv[i] * yv[i])
+					}
+					t.stest(desc, []float64{got}, []float64{want}, []float64{size})
+					wx, wy = xv, yv
+				case "DAXPY":
+					for _, alpha := range level1Vals {
+						x, y := clone(xs), clone(ys)
+						desc = fmt.Sprintf("DAXPY(%d, %g, X, %d, Y, %d)", n, alpha, incX, incY)
+						if !t.call(desc, func() { t.impl.Daxpy(n, alpha, x, incX, y, incY) }) {
+							continue
+						}
+						wy, sy = make([]float64, n), make([]float64, n)
+						for i := range yv {
+							wy[i] = alpha*xv[i] + yv[i]
+							sy[i] = math.Abs(alpha*xv[i]) + math.Abs(yv[i])
+						}
+						t.compare(desc, x, incX, xv, nil, xs)
+						t.compare(desc, y, incY, wy, sy, ys)
+					}
+					continue
+				case "DROT", "DROTM":
+					for _, c := range level1Vals {
+						x, y := clone(xs), clone(ys)
+						s := math.Sqrt(math.Max(0, 1-c*c))
+						var h [4]float64 // Column-major 2×2 rotation.
+						if t.name == "DROT" {
+							desc = fmt.Sprintf("DROT(%d, X, %d, Y, %d, %g, %g)", n, incX, incY, c, s)
+							if !t.call(desc, func() { t.impl.Drot(n, x, incX, y, incY, c, s) }) {
+								continue
+							}
+							h = [4]float64{c, -s, s, c}
+						} else {
+							// Exercise each flag of the modified rotation.
+							flag := c - 1
+							if c == 0.3 {
+								flag = -2
+							}
+							p := &blas.DrotmParams{Flag: flag, H: [4]float64{c, -s, s, 2 * c}}
+							desc = fmt.Sprintf("DROTM(%d, X, %d, Y, %d, %v)", n, incX, incY, *p)
+							if !t.call(desc, func() { t.impl.Drotm(n, x, incX, y, incY, p) }) {
+								continue
+							}
+							switch flag {
+							case -2:
+								h = [4]float64{1, 0, 0, 1}
+							case -1:
+								h = p.H
+							case 0:
+								h = [4]float64{1, p.H[1], p.H[2], 1}
+							case 1:
+								h = [4]float64{p.H[0], -1, 1, p.H[3]}
+							}
+						}
+						wx, wy = make([]float64, n), make([]float64, n)
+						sx, sy = make([]float64, n), make([]float64, n)
+						for i := range xv {
+							wx[i] = h[0]*xv[i] + h[2]*yv[i]
+							wy[i] = h[1]*xv[i] + h[3]*yv[i]
+							sx[i] = math.Abs(h[0]*xv[i]) + math.Abs(h[2]*yv[i])
+							sy[i] = math.Abs(h[1]*xv[i]) + math.Abs(h[3]*yv[i])
+						}
+						t.compare(desc, x, incX, wx, sx, xs)
+						t.compare(desc, y, incY, wy, sy, ys)
+					}
+					continue
+				case "DCOPY":
+					desc = fmt.Sprintf("DCOPY(%d, X, %d, Y, %d)", n, incX, incY)
+					if !t.call(desc, func() { t.impl.Dcopy(n, x, incX, y, incY) }) {
+						continue
+					}
+					wx, wy = xv, xv
+				case "DSWAP":
+					desc = fmt.Sprintf("DSWAP(%d, X, %d, Y, %d)", n, incX, incY)
+					if !t.call(desc, func() { t.impl.Dswap(n, x, incX, y, incY) }) {
+						continue
+					}
+					wx, wy = yv, xv
+				}
+				t.compare(desc, x, incX, wx, sx, xs)
+				t.compare(desc, y, incY, wy, sy, ys)
+			}
+		}
+	}
+}
+
+// compare compares the n = len(want) elements of v with increment inc
+// against want, and checks that the other elements of v are unchanged
+// from orig. If size is nil, the magnitudes of want are used.
+func (t *level1) compare(desc string, v []float64, inc int, want, size, orig []float64) {
+	got := vector(v, len(want), inc)
+	if size == nil {
+		size = make([]float64, len(want))
+		for i, w := range want {
+			size[i] = math.Abs(w)
+		}
+	}
+	t.stest(desc, got, want, size)
+	t.unchanged(desc, v, orig, len(want), inc)
+}
+
+// drotg is the reference DROTG.
+func drotg(a, b float64) (c, s, r, z float64) {
+	roe := b
+	if math.Abs(a) > math.Abs(b) {
+		roe = a
+	}
+	scale := math.Abs(a) + math.Abs(b)
+	if scale == 0 {
+		return 1, 0, 0, 0
+	}
+	r = scale * math.Sqrt((a/scale)*(a/scale)+(b/scale)*(b/scale))
+	r = math.Copysign(r, roe)
+	c = a / r
+	s = b / r
+	z = 1
+	if math.Abs(a) > math.Abs(b) {
+		z = s
+	}
+	if math.Abs(b) >= math.Abs(a) && c != 0 {
+		z = 1 / c
+	}
+	return c, s, r, z
+}
+
+// drotmg is the reference DROTMG.
+func drotmg(d1, d2, x1, y1 float64) (p blas.DrotmParams, rd1, rd2, rx1 float64) {
+	const (
+		gam    = 4096.0
+		gamsq  = gam * gam
+		rgamsq = 1 / gamsq
+	)
+	var h11, h12, h21, h22 float64
+	if d1 < 0 {
+		p.Flag = -1
+		return p, 0, 0, 0
+	}
+	p2 := d2 * y1
+	if p2 == 0 {
+		p.Flag = -2
+		return p, d1, d2, x1
+	}
+	p1 := d1 * x1
+	q2 := p2 * y1
+	q1 := p1 * x1
+	if math.Abs(q1) > math.Abs(q2) {
+		h21 = -y1 / x1
+		h12 = p2 / p1
+		u := 1 - h12*h21
+		if u <= 0 {
+			p.Flag = -1
+			return p, 0, 0, 0
+		}
+		p.Flag = 0
+		d1 /= u
+		d2 /= u
+		x1 *= u
+	} else {
+		if q2 < 0 {
+			p.Flag = -1
+			return p, 0, 0, 0
+		}
+		p.Flag = 1
+		h11 = p1 / p2
+		h22 = x1 / y1
+		u := 1 + h11*h22
+		d1, d2 = d2/u, d1/u
+		x1 = y1 * u
+	}
+	rescale := func() {
+		if p.Flag == 0 {
+			h11, h22 = 1, 1
+		} else {
+			h21, h12 = -1, 1
+		}
+		p.Flag = -1
+	}
+	if d1 != 0 {
+		for d1 <= rgamsq || d1 >= gamsq {
+			rescale()
+			if d1 <= rgamsq {
+				d1 *= gamsq
+				x1 /= gam
+				h11 /= gam
+				h12 /= gam
+			} else {
+				d1 /= gamsq
+				x1 *= gam
+				h11 *= gam
+				h12 *= gam
+			}
+		}
+	}
+	if d2 != 0 {
+		for math.Abs(d2) <= rgamsq || math.Abs(d2) >= gamsq {
+			rescale()
+			if math.Abs(d2) <= rgamsq {
+				d2 *= gamsq
+				h21 /= gam
+				h22 /= gam
+			} else {
+				d2 /= gamsq
+				h21 *= gam
+				h22 *= gam
+			}
+		}
+	}
+	p.H = [4]float64{h11, h21, h12, h22}
+	return p, d1, d2, x1
+}
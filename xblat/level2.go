@@ -0,0 +1,629 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xblat
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+var level2Routines = []string{
+	"DGEMV", "DGBMV", "DSYMV", "DSBMV", "DSPMV",
+	"DTRMV", "DTBMV", "DTPMV", "DTRSV", "DTBSV", "DTPSV",
+	"DGER", "DSYR", "DSPR", "DSYR2", "DSPR2",
+}
+
+// Level2 runs the level 2 tests described by p against impl, writing
+// a summary of the results to w. It returns whether all tests passed.
+func Level2(w io.Writer, p *Params, impl blas.Float64) bool {
+	t := &tester{w: w, impl: impl, p: p}
+	t.header(2)
+	return t.run(level2Routines, t.level2, t.level2ErrorExits)
+}
+
+func (t *tester) level2(name string) {
+	switch name {
+	case "DGEMV":
+		t.dchk1(general)
+	case "DGBMV":
+		t.dchk1(generalBand)
+	case "DSYMV":
+		t.dchk2(symmetric)
+	case "DSBMV":
+		t.dchk2(symmetricBand)
+	case "DSPMV":
+		t.dchk2(symmetricPacked)
+	case "DTRMV", "DTRSV":
+		t.dchk3(triangular, name == "DTRSV")
+	case "DTBMV", "DTBSV":
+		t.dchk3(triangularBand, name == "DTBSV")
+	case "DTPMV", "DTPSV":
+		t.dchk3(triangularPacked, name == "DTPSV")
+	case "DGER":
+		t.dchk4()
+	case "DSYR":
+		t.dchk5(symmetric)
+	case "DSPR":
+		t.dchk5(symmetricPacked)
+	case "DSYR2":
+		t.dchk6(symmetric)
+	case "DSPR2":
+		t.dchk6(symmetricPacked)
+	}
+}
+
+var (
+	transposes = []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans}
+	uplos      = []blas.Uplo{blas.Upper, blas.Lower}
+	diags      = []blas.Diag{blas.NonUnit, blas.Unit}
+	sides      = []blas.Side{blas.Left, blas.Right}
+)
+
+// dchk1 tests DGEMV and DGBMV.
+func (t *tester) dchk1(k kind) {
+	banded := k == generalBand
+	for _, n := range t.p.N {
+		nd := n/2 + 1
+		for im := 0; im < 2; im++ {
+			m := max(n-nd, 0)
+			if im == 1 {
+				m = min(n+nd, nmax)
+			}
+			kus := []int{n - 1}
+			if banded {
+				kus = t.p.K
+			}
+			for _, ku := range kus {
+				kl := m - 1
+				if banded {
+					kl = max(ku-1, 0)
+				}
+				for _, o := range t.p.Orders {
+					lda, _ := majorDims(o, m, n)
+					if banded {
+						lda = kl + ku + 1
+					}
+					lda = leadingDim(lda)
+					if lda > nmax {
+						continue
+					}
+					a := t.makeMatrix(k, blas.Upper, blas.NonUnit, o, m, n, kl, ku, lda, 0)
+					for _, tA := range transposes {
+						ml, nl := m, n
+						if tA != blas.NoTrans {
+							ml, nl = n, m
+						}
+						for _, incX := range t.p.Inc {
+							x := t.makeVector(nl, incX, 0.5, true)
+							for _, incY := range t.p.Inc {
+								for _, alpha := range t.p.Alpha {
+									for _, beta := range t.p.Beta {
+										y := t.makeVector(ml, incY, 0, false)
+										as, xs, ys := clone(a.stored), clone(x), clone(y)
+
+										var desc string
+										var f func()
+										if banded {
+											desc = fmt.Sprintf("%s(%s, %s, %d, %d, %d, %d, %.1f, A, %d, X, %d, %.1f, Y, %d)",
+												t.name, orderName(o), transName(tA), m, n, kl, ku, alpha, lda, incX, beta, incY)
+											f = func() { t.impl.Dgbmv(o, tA, m, n, kl, ku, alpha, a.stored, lda, x, incX, beta, y, incY) }
+										} else {
+											desc = fmt.Sprintf("%s(%s, %s, %d, %d, %.1f, A, %d, X, %d, %.1f, Y, %d)",
+												t.name, orderName(o), transName(tA), m, n, alpha, lda, incX, beta, incY)
+											f = func() { t.impl.Dgemv(o, tA, m, n, alpha, a.stored, lda, x, incX, beta, y, incY) }
+										}
+										if !t.call(desc, f) ||
+											!t.same(desc, "A", a.stored, as) ||
+											!t.same(desc, "X", x, xs) {
+											return
+										}
+										if m <= 0 || n <= 0 {
+											if !t.same(desc, "Y", y, ys) {
+												return
+											}
+											continue
+										}
+										if !t.sameExcept(desc, "Y", y, ys, ml, incY) {
+											return
+										}
+										err := mvch(tA, a.a, alpha, vector(xs, nl, incX), beta, vector(ys, ml, incY), vector(y, ml, incY))
+										if !t.ratio(desc, err) {
+											return
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk2 tests DSYMV, DSBMV and DSPMV.
+func (t *tester) dchk2(k kind) {
+	for _, n := range t.p.N {
+		ks := []int{n - 1}
+		if k == symmetricBand {
+			ks = t.p.K
+		}
+		for _, kb := range ks {
+			for _, o := range t.p.Orders {
+				lda := n
+				if k == symmetricBand {
+					lda = kb + 1
+				}
+				lda = leadingDim(lda)
+				if lda > nmax {
+					continue
+				}
+				for _, ul := range uplos {
+					a := t.makeMatrix(k, ul, blas.NonUnit, o, n, n, kb, kb, lda, 0)
+					for _, incX := range t.p.Inc {
+						x := t.makeVector(n, incX, 0.5, true)
+						for _, incY := range t.p.Inc {
+							for _, alpha := range t.p.Alpha {
+								for _, beta := range t.p.Beta {
+									y := t.makeVector(n, incY, 0, false)
+									as, xs, ys := clone(a.stored), clone(x), clone(y)
+
+									var desc string
+									var f func()
+									switch k {
+									case symmetric:
+										desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, A, %d, X, %d, %.1f, Y, %d)",
+											t.name, orderName(o), uploName(ul), n, alpha, lda, incX, beta, incY)
+										f = func() { t.impl.Dsymv(o, ul, n, alpha, a.stored, lda, x, incX, beta, y, incY) }
+									case symmetricBand:
+										desc = fmt.Sprintf("%s(%s, %s, %d, %d, %.1f, A, %d, X, %d, %.1f, Y, %d)",
+											t.name, orderName(o), uploName(ul), n, kb, alpha, lda, incX, beta, incY)
+										f = func() { t.impl.Dsbmv(o, ul, n, kb, alpha, a.stored, lda, x, incX, beta, y, incY) }
+									case symmetricPacked:
+										desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, AP, X, %d, %.1f, Y, %d)",
+											t.name, orderName(o), uploName(ul), n, alpha, incX, beta, incY)
+										f = func() { t.impl.Dspmv(o, ul, n, alpha, a.stored, x, incX, beta, y, incY) }
+									}
+									if !t.call(desc, f) ||
+										!t.same(desc, "A", a.stored, as) ||
+										!t.same(desc, "X", x, xs) {
+										return
+									}
+									if n <= 0 {
+										if !t.same(desc, "Y", y, ys) {
+											return
+										}
+										continue
+									}
+									if !t.sameExcept(desc, "Y", y, ys, n, incY) {
+										return
+									}
+									err := mvch(blas.NoTrans, a.a, alpha, vector(xs, n, incX), beta, vector(ys, n, incY), vector(y, n, incY))
+									if !t.ratio(desc, err) {
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk3 tests DTRMV, DTBMV, DTPMV, DTRSV, DTBSV and DTPSV.
+func (t *tester) dchk3(k kind, solve bool) {
+	for _, n := range t.p.N {
+		ks := []int{n - 1}
+		if k == triangularBand {
+			ks = t.p.K
+		}
+		for _, kb := range ks {
+			for _, o := range t.p.Orders {
+				lda := n
+				if k == triangularBand {
+					lda = kb + 1
+				}
+				lda = leadingDim(lda)
+				if lda > nmax {
+					continue
+				}
+				for _, ul := range uplos {
+					for _, tA := range transposes {
+						for _, d := range diags {
+							a := t.makeMatrix(k, ul, d, o, n, n, kb, kb, lda, 0)
+							for _, incX := range t.p.Inc {
+								x := t.makeVector(n, incX, 0.5, true)
+								as, xs := clone(a.stored), clone(x)
+
+								var f func()
+								var desc string
+								switch {
+								case k == triangular && !solve:
+									f = func() { t.impl.Dtrmv(o, ul, tA, d, n, a.stored, lda, x, incX) }
+								case k == triangular && solve:
+									f = func() { t.impl.Dtrsv(o, ul, tA, d, n, a.stored, lda, x, incX) }
+								case k == triangularBand && !solve:
+									f = func() { t.impl.Dtbmv(o, ul, tA, d, n, kb, a.stored, lda, x, incX) }
+								case k == triangularBand && solve:
+									f = func() { t.impl.Dtbsv(o, ul, tA, d, n, kb, a.stored, lda, x, incX) }
+								case k == triangularPacked && !solve:
+									f = func() { t.impl.Dtpmv(o, ul, tA, d, n, a.stored, x, incX) }
+								case k == triangularPacked && solve:
+									f = func() { t.impl.Dtpsv(o, ul, tA, d, n, a.stored, x, incX) }
+								}
+								switch k {
+								case triangular:
+									desc = fmt.Sprintf("%s(%s, %s, %s, %s, %d, A, %d, X, %d)",
+										t.name, orderName(o), uploName(ul), transName(tA), diagName(d), n, lda, incX)
+								case triangularBand:
+									desc = fmt.Sprintf("%s(%s, %s, %s, %s, %d, %d, A, %d, X, %d)",
+										t.name, orderName(o), uploName(ul), transName(tA), diagName(d), n, kb, lda, incX)
+								case triangularPacked:
+									desc = fmt.Sprintf("%s(%s, %s, %s, %s, %d, AP, X, %d)",
+										t.name, orderName(o), uploName(ul), transName(tA), diagName(d), n, incX)
+								}
+								if !t.call(desc, f) || !t.same(desc, "A", a.stored, as) {
+									return
+								}
+								if n <= 0 {
+									if !t.same(desc, "X", x, xs) {
+										return
+									}
+									continue
+								}
+								if !t.sameExcept(desc, "X", x, xs, n, incX) {
+									return
+								}
+								zero := make([]float64, n)
+								var err float64
+								if solve {
+									// Check that op(A)*x reproduces the right hand side.
+									err = mvch(tA, a.a, 1, vector(x, n, incX), 0, zero, vector(xs, n, incX))
+								} else {
+									err = mvch(tA, a.a, 1, vector(xs, n, incX), 0, zero, vector(x, n, incX))
+								}
+								if !t.ratio(desc, err) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk4 tests DGER.
+func (t *tester) dchk4() {
+	for _, n := range t.p.N {
+		nd := n/2 + 1
+		for im := 0; im < 2; im++ {
+			m := max(n-nd, 0)
+			if im == 1 {
+				m = min(n+nd, nmax)
+			}
+			for _, o := range t.p.Orders {
+				lda, _ := majorDims(o, m, n)
+				lda = leadingDim(lda)
+				if lda > nmax {
+					continue
+				}
+				for _, incX := range t.p.Inc {
+					x := t.makeVector(m, incX, 0.5, true)
+					for _, incY := range t.p.Inc {
+						y := t.makeVector(n, incY, 0, true)
+						for _, alpha := range t.p.Alpha {
+							a := t.makeMatrix(general, blas.Upper, blas.NonUnit, o, m, n, m-1, n-1, lda, 0)
+							as, xs, ys := clone(a.stored), clone(x), clone(y)
+
+							desc := fmt.Sprintf("%s(%s, %d, %d, %.1f, X, %d, Y, %d, A, %d)",
+								t.name, orderName(o), m, n, alpha, incX, incY, lda)
+							if !t.call(desc, func() { t.impl.Dger(o, m, n, alpha, x, incX, y, incY, a.stored, lda) }) ||
+								!t.same(desc, "X", x, xs) ||
+								!t.same(desc, "Y", y, ys) {
+								return
+							}
+							if m <= 0 || n <= 0 {
+								if !t.same(desc, "A", a.stored, as) {
+									return
+								}
+								continue
+							}
+							xv, yv := vector(xs, m, incX), vector(ys, n, incY)
+							err, ok := t.matrixRatio(desc, "A", general, blas.Upper, o, m, n, m-1, n-1, lda, a.stored, as, func(i, j int) (v, g float64) {
+								return a.a[i][j] + alpha*xv[i]*yv[j], math.Abs(a.a[i][j]) + math.Abs(alpha*xv[i]*yv[j])
+							})
+							if !ok || !t.ratio(desc, err) {
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk5 tests DSYR and DSPR.
+func (t *tester) dchk5(k kind) {
+	for _, n := range t.p.N {
+		for _, o := range t.p.Orders {
+			lda := leadingDim(n)
+			if lda > nmax {
+				continue
+			}
+			for _, ul := range uplos {
+				for _, incX := range t.p.Inc {
+					x := t.makeVector(n, incX, 0.5, true)
+					for _, alpha := range t.p.Alpha {
+						a := t.makeMatrix(k, ul, blas.NonUnit, o, n, n, n-1, n-1, lda, 0)
+						as, xs := clone(a.stored), clone(x)
+
+						var desc string
+						var f func()
+						if k == symmetricPacked {
+							desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, X, %d, AP)",
+								t.name, orderName(o), uploName(ul), n, alpha, incX)
+							f = func() { t.impl.Dspr(o, ul, n, alpha, x, incX, a.stored) }
+						} else {
+							desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, X, %d, A, %d)",
+								t.name, orderName(o), uploName(ul), n, alpha, incX, lda)
+							f = func() { t.impl.Dsyr(o, ul, n, alpha, x, incX, a.stored, lda) }
+						}
+						if !t.call(desc, f) || !t.same(desc, "X", x, xs) {
+							return
+						}
+						if n <= 0 {
+							if !t.same(desc, "A", a.stored, as) {
+								return
+							}
+							continue
+						}
+						xv := vector(xs, n, incX)
+						err, ok := t.matrixRatio(desc, "A", k, ul, o, n, n, n-1, n-1, lda, a.stored, as, func(i, j int) (v, g float64) {
+							return a.a[i][j] + alpha*xv[i]*xv[j], math.Abs(a.a[i][j]) + math.Abs(alpha*xv[i]*xv[j])
+						})
+						if !ok || !t.ratio(desc, err) {
+							return
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk6 tests DSYR2 and DSPR2.
+func (t *tester) dchk6(k kind) {
+	for _, n := range t.p.N {
+		for _, o := range t.p.Orders {
+			lda := leadingDim(n)
+			if lda > nmax {
+				continue
+			}
+			for _, ul := range uplos {
+				for _, incX := range t.p.Inc {
+					x := t.makeVector(n, incX, 0.5, true)
+					for _, incY := range t.p.Inc {
+						y := t.makeVector(n, incY, 0, true)
+						for _, alpha := range t.p.Alpha {
+							a := t.makeMatrix(k, ul, blas.NonUnit, o, n, n, n-1, n-1, lda, 0)
+							as, xs, ys := clone(a.stored), clone(x), clone(y)
+
+							var desc string
+							var f func()
+							if k == symmetricPacked {
+								desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, X, %d, Y, %d, AP)",
+									t.name, orderName(o), uploName(ul), n, alpha, incX, incY)
+								f = func() { t.impl.Dspr2(o, ul, n, alpha, x, incX, y, incY, a.stored) }
+							} else {
+								desc = fmt.Sprintf("%s(%s, %s, %d, %.1f, X, %d, Y, %d, A, %d)",
+									t.name, orderName(o), uploName(ul), n, alpha, incX, incY, lda)
+								f = func() { t.impl.Dsyr2(o, ul, n, alpha, x, incX, y, incY, a.stored, lda) }
+							}
+							if !t.call(desc, f) ||
+								!t.same(desc, "X", x, xs) ||
+								!t.same(desc, "Y", y, ys) {
+								return
+							}
+							if n <= 0 {
+								if !t.same(desc, "A", a.stored, as) {
+									return
+								}
+								continue
+							}
+							xv, yv := vector(xs, n, incX), vector(ys, n, incY)
+							err, ok := t.matrixRatio(desc, "A", k, ul, o, n, n, n-1, n-1, lda, a.stored, as, func(i, j int) (v, g float64) {
+								u := alpha * (xv[i]*yv[j] + yv[i]*xv[j])
+								g = math.Abs(a.a[i][j]) + math.Abs(alpha*xv[i]*yv[j]) + math.Abs(alpha*yv[i]*xv[j])
+								return a.a[i][j] + u, g
+							})
+							if !ok || !t.ratio(desc, err) {
+								return
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// mvch returns the test ratio for yy, the computed value of
+// alpha*op(A)*x + beta*y, following DMVCH.
+func mvch(tA blas.Transpose, a [][]float64, alpha float64, x []float64, beta float64, y, yy []float64) float64 {
+	var ratio float64
+	for i := range yy {
+		var yt, g float64
+		for j := range x {
+			aij := op(tA, a, i, j)
+			yt += aij * x[j]
+			g += math.Abs(aij * x[j])
+		}
+		yt = alpha*yt + beta*y[i]
+		g = math.Abs(alpha)*g + math.Abs(beta*y[i])
+		e := relErr(yy[i], yt, g)
+		if e > ratio || math.IsNaN(e) {
+			ratio = e
+		}
+	}
+	return ratio
+}
+
+// level2ErrorExits tests that illegal arguments are rejected, following
+// DCHKE. Only arguments that are validated by the Go implementation are
+// tested since invalid arguments that reach a C BLAS may terminate the
+// program.
+func (t *tester) level2ErrorExits(name string) {
+	const bad = 0
+	var (
+		a = make([]float64, 4)
+		x = make([]float64, 2)
+		y = make([]float64, 2)
+
+		o  = blas.ColMajor
+		ul = blas.Upper
+		tA = blas.NoTrans
+		d  = blas.NonUnit
+		im = t.impl
+	)
+	var calls []func()
+	switch name {
+	case "DGEMV":
+		calls = []func(){
+			func() { im.Dgemv(bad, tA, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgemv(o, tA, -1, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgemv(o, tA, 0, -1, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgemv(o, tA, 2, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgemv(o, tA, 0, 0, 0, a, 1, x, 0, 0, y, 1) },
+			func() { im.Dgemv(o, tA, 0, 0, 0, a, 1, x, 1, 0, y, 0) },
+		}
+	case "DGBMV":
+		calls = []func(){
+			func() { im.Dgbmv(bad, tA, 0, 0, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, -1, 0, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 0, -1, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 0, 0, -1, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 2, 0, 0, -1, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 0, 0, 1, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 0, 0, 0, 0, 0, a, 1, x, 0, 0, y, 1) },
+			func() { im.Dgbmv(o, tA, 0, 0, 0, 0, 0, a, 1, x, 1, 0, y, 0) },
+		}
+	case "DSYMV":
+		calls = []func(){
+			func() { im.Dsymv(bad, ul, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsymv(o, bad, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsymv(o, ul, -1, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsymv(o, ul, 2, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsymv(o, ul, 0, 0, a, 1, x, 0, 0, y, 1) },
+			func() { im.Dsymv(o, ul, 0, 0, a, 1, x, 1, 0, y, 0) },
+		}
+	case "DSBMV":
+		calls = []func(){
+			func() { im.Dsbmv(bad, ul, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsbmv(o, bad, 0, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsbmv(o, ul, -1, 0, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsbmv(o, ul, 0, -1, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsbmv(o, ul, 0, 1, 0, a, 1, x, 1, 0, y, 1) },
+			func() { im.Dsbmv(o, ul, 0, 0, 0, a, 1, x, 0, 0, y, 1) },
+			func() { im.Dsbmv(o, ul, 0, 0, 0, a, 1, x, 1, 0, y, 0) },
+		}
+	case "DSPMV":
+		calls = []func(){
+			func() { im.Dspmv(bad, ul, 0, 0, a, x, 1, 0, y, 1) },
+			func() { im.Dspmv(o, bad, 0, 0, a, x, 1, 0, y, 1) },
+			func() { im.Dspmv(o, ul, -1, 0, a, x, 1, 0, y, 1) },
+			func() { im.Dspmv(o, ul, 0, 0, a, x, 0, 0, y, 1) },
+			func() { im.Dspmv(o, ul, 0, 0, a, x, 1, 0, y, 0) },
+		}
+	case "DTRMV", "DTRSV":
+		f := im.Dtrmv
+		if name == "DTRSV" {
+			f = im.Dtrsv
+		}
+		calls = []func(){
+			func() { f(bad, ul, tA, d, 0, a, 1, x, 1) },
+			func() { f(o, bad, tA, d, 0, a, 1, x, 1) },
+			func() { f(o, ul, tA, bad, 0, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, -1, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, 2, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, 0, a, 1, x, 0) },
+		}
+	case "DTBMV", "DTBSV":
+		f := im.Dtbmv
+		if name == "DTBSV" {
+			f = im.Dtbsv
+		}
+		calls = []func(){
+			func() { f(bad, ul, tA, d, 0, 0, a, 1, x, 1) },
+			func() { f(o, bad, tA, d, 0, 0, a, 1, x, 1) },
+			func() { f(o, ul, tA, bad, 0, 0, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, -1, 0, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, 0, -1, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, 0, 1, a, 1, x, 1) },
+			func() { f(o, ul, tA, d, 0, 0, a, 1, x, 0) },
+		}
+	case "DTPMV", "DTPSV":
+		f := im.Dtpmv
+		if name == "DTPSV" {
+			f = im.Dtpsv
+		}
+		calls = []func(){
+			func() { f(bad, ul, tA, d, 0, a, x, 1) },
+			func() { f(o, bad, tA, d, 0, a, x, 1) },
+			func() { f(o, ul, tA, bad, 0, a, x, 1) },
+			func() { f(o, ul, tA, d, -1, a, x, 1) },
+			func() { f(o, ul, tA, d, 0, a, x, 0) },
+		}
+	case "DGER":
+		calls = []func(){
+			func() { im.Dger(bad, 0, 0, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dger(o, -1, 0, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dger(o, 0, -1, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dger(o, 0, 0, 0, x, 0, y, 1, a, 1) },
+			func() { im.Dger(o, 0, 0, 0, x, 1, y, 0, a, 1) },
+			func() { im.Dger(o, 2, 0, 0, x, 1, y, 1, a, 1) },
+		}
+	case "DSYR":
+		calls = []func(){
+			func() { im.Dsyr(bad, ul, 0, 0, x, 1, a, 1) },
+			func() { im.Dsyr(o, bad, 0, 0, x, 1, a, 1) },
+			func() { im.Dsyr(o, ul, -1, 0, x, 1, a, 1) },
+			func() { im.Dsyr(o, ul, 0, 0, x, 0, a, 1) },
+			func() { im.Dsyr(o, ul, 2, 0, x, 1, a, 1) },
+		}
+	case "DSPR":
+		calls = []func(){
+			func() { im.Dspr(bad, ul, 0, 0, x, 1, a) },
+			func() { im.Dspr(o, bad, 0, 0, x, 1, a) },
+			func() { im.Dspr(o, ul, -1, 0, x, 1, a) },
+			func() { im.Dspr(o, ul, 0, 0, x, 0, a) },
+		}
+	case "DSYR2":
+		calls = []func(){
+			func() { im.Dsyr2(bad, ul, 0, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dsyr2(o, bad, 0, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dsyr2(o, ul, -1, 0, x, 1, y, 1, a, 1) },
+			func() { im.Dsyr2(o, ul, 0, 0, x, 0, y, 1, a, 1) },
+			func() { im.Dsyr2(o, ul, 0, 0, x, 1, y, 0, a, 1) },
+			func() { im.Dsyr2(o, ul, 2, 0, x, 1, y, 1, a, 1) },
+		}
+	case "DSPR2":
+		calls = []func(){
+			func() { im.Dspr2(bad, ul, 0, 0, x, 1, y, 1, a) },
+			func() { im.Dspr2(o, bad, 0, 0, x, 1, y, 1, a) },
+			func() { im.Dspr2(o, ul, -1, 0, x, 1, y, 1, a) },
+			func() { im.Dspr2(o, ul, 0, 0, x, 0, y, 1, a) },
+			func() { im.Dspr2(o, ul, 0, 0, x, 1, y, 0, a) },
+		}
+	}
+	t.errorExits(name, calls)
+}
@@ -0,0 +1,485 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xblat
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+var level3Routines = []string{"DGEMM", "DSYMM", "DTRMM", "DTRSM", "DSYRK", "DSYR2K"}
+
+// Level3 runs the level 3 tests described by p against impl, writing
+// a summary of the results to w. It returns whether all tests passed.
+func Level3(w io.Writer, p *Params, impl blas.Float64) bool {
+	t := &tester{w: w, impl: impl, p: p}
+	t.header(3)
+	return t.run(level3Routines, t.level3, t.level3ErrorExits)
+}
+
+func (t *tester) level3(name string) {
+	switch name {
+	case "DGEMM":
+		t.dchk31()
+	case "DSYMM":
+		t.dchk32()
+	case "DTRMM", "DTRSM":
+		t.dchk33(name == "DTRSM")
+	case "DSYRK":
+		t.dchk34()
+	case "DSYR2K":
+		t.dchk35()
+	}
+}
+
+// op returns the (i, j) element of op(A).
+func op(tA blas.Transpose, a [][]float64, i, j int) float64 {
+	if tA == blas.NoTrans {
+		return a[i][j]
+	}
+	return a[j][i]
+}
+
+// general returns an m×n general test matrix with the given order
+// and its leading dimension, which is zero if it exceeds nmax.
+func (t *tester) general(o blas.Order, m, n int) (matrix, int) {
+	lda, _ := majorDims(o, m, n)
+	lda = leadingDim(lda)
+	if lda > nmax {
+		return matrix{}, 0
+	}
+	return t.makeMatrix(general, blas.Upper, blas.NonUnit, o, m, n, m-1, n-1, lda, 0), lda
+}
+
+// dchk31 tests DGEMM.
+func (t *tester) dchk31() {
+	for _, m := range t.p.N {
+		for _, n := range t.p.N {
+			for _, k := range t.p.N {
+				for _, tA := range transposes {
+					ma, na := m, k
+					if tA != blas.NoTrans {
+						ma, na = k, m
+					}
+					for _, tB := range transposes {
+						mb, nb := k, n
+						if tB != blas.NoTrans {
+							mb, nb = n, k
+						}
+						for _, o := range t.p.Orders {
+							a, lda := t.general(o, ma, na)
+							b, ldb := t.general(o, mb, nb)
+							if lda == 0 || ldb == 0 {
+								continue
+							}
+							for _, alpha := range t.p.Alpha {
+								for _, beta := range t.p.Beta {
+									c, ldc := t.general(o, m, n)
+									if ldc == 0 {
+										continue
+									}
+									as, bs, cs := clone(a.stored), clone(b.stored), clone(c.stored)
+
+									desc := fmt.Sprintf("%s(%s, %s, %s, %d, %d, %d, %.1f, A, %d, B, %d, %.1f, C, %d)",
+										t.name, orderName(o), transName(tA), transName(tB), m, n, k, alpha, lda, ldb, beta, ldc)
+									if !t.call(desc, func() {
+										t.impl.Dgemm(o, tA, tB, m, n, k, alpha, a.stored, lda, b.stored, ldb, beta, c.stored, ldc)
+									}) ||
+										!t.same(desc, "A", a.stored, as) ||
+										!t.same(desc, "B", b.stored, bs) {
+										return
+									}
+									if m <= 0 || n <= 0 {
+										if !t.same(desc, "C", c.stored, cs) {
+											return
+										}
+										continue
+									}
+									err, ok := t.matrixRatio(desc, "C", general, blas.Upper, o, m, n, m-1, n-1, ldc, c.stored, cs, func(i, j int) (v, g float64) {
+										for l := 0; l < k; l++ {
+											p := op(tA, a.a, i, l) * op(tB, b.a, l, j)
+											v += p
+											g += math.Abs(p)
+										}
+										return alpha*v + beta*c.a[i][j], math.Abs(alpha)*g + math.Abs(beta*c.a[i][j])
+									})
+									if !ok || !t.ratio(desc, err) {
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk32 tests DSYMM.
+func (t *tester) dchk32() {
+	for _, m := range t.p.N {
+		for _, n := range t.p.N {
+			for _, o := range t.p.Orders {
+				b, ldb := t.general(o, m, n)
+				if ldb == 0 {
+					continue
+				}
+				for _, s := range sides {
+					na := m
+					if s == blas.Right {
+						na = n
+					}
+					lda := leadingDim(na)
+					if lda > nmax {
+						continue
+					}
+					for _, ul := range uplos {
+						a := t.makeMatrix(symmetric, ul, blas.NonUnit, o, na, na, na-1, na-1, lda, 0)
+						for _, alpha := range t.p.Alpha {
+							for _, beta := range t.p.Beta {
+								c, ldc := t.general(o, m, n)
+								as, bs, cs := clone(a.stored), clone(b.stored), clone(c.stored)
+
+								desc := fmt.Sprintf("%s(%s, %s, %s, %d, %d, %.1f, A, %d, B, %d, %.1f, C, %d)",
+									t.name, orderName(o), sideName(s), uploName(ul), m, n, alpha, lda, ldb, beta, ldc)
+								if !t.call(desc, func() {
+									t.impl.Dsymm(o, s, ul, m, n, alpha, a.stored, lda, b.stored, ldb, beta, c.stored, ldc)
+								}) ||
+									!t.same(desc, "A", a.stored, as) ||
+									!t.same(desc, "B", b.stored, bs) {
+									return
+								}
+								if m <= 0 || n <= 0 {
+									if !t.same(desc, "C", c.stored, cs) {
+										return
+									}
+									continue
+								}
+								err, ok := t.matrixRatio(desc, "C", general, blas.Upper, o, m, n, m-1, n-1, ldc, c.stored, cs, func(i, j int) (v, g float64) {
+									for l := 0; l < na; l++ {
+										var p float64
+										if s == blas.Left {
+											p = a.a[i][l] * b.a[l][j]
+										} else {
+											p = b.a[i][l] * a.a[l][j]
+										}
+										v += p
+										g += math.Abs(p)
+									}
+									return alpha*v + beta*c.a[i][j], math.Abs(alpha)*g + math.Abs(beta*c.a[i][j])
+								})
+								if !ok || !t.ratio(desc, err) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk33 tests DTRMM and DTRSM.
+func (t *tester) dchk33(solve bool) {
+	for _, m := range t.p.N {
+		for _, n := range t.p.N {
+			for _, o := range t.p.Orders {
+				for _, s := range sides {
+					na := m
+					if s == blas.Right {
+						na = n
+					}
+					lda := leadingDim(na)
+					if lda > nmax {
+						continue
+					}
+					for _, ul := range uplos {
+						for _, tA := range transposes {
+							for _, d := range diags {
+								for _, alpha := range t.p.Alpha {
+									a := t.makeMatrix(triangular, ul, d, o, na, na, na-1, na-1, lda, 0)
+									b, ldb := t.general(o, m, n)
+									if ldb == 0 {
+										continue
+									}
+									as, bs := clone(a.stored), clone(b.stored)
+
+									desc := fmt.Sprintf("%s(%s, %s, %s, %s, %s, %d, %d, %.1f, A, %d, B, %d)",
+										t.name, orderName(o), sideName(s), uploName(ul), transName(tA), diagName(d), m, n, alpha, lda, ldb)
+									f := t.impl.Dtrmm
+									if solve {
+										f = t.impl.Dtrsm
+									}
+									if !t.call(desc, func() { f(o, s, ul, tA, d, m, n, alpha, a.stored, lda, b.stored, ldb) }) ||
+										!t.same(desc, "A", a.stored, as) {
+										return
+									}
+									if m <= 0 || n <= 0 {
+										if !t.same(desc, "B", b.stored, bs) {
+											return
+										}
+										continue
+									}
+									if !t.sameOutside(desc, "B", general, blas.Upper, o, m, n, m-1, n-1, ldb, b.stored, bs) {
+										return
+									}
+
+									// Extract the computed result.
+									x := make([][]float64, m)
+									for i := range x {
+										x[i] = make([]float64, n)
+									}
+									positions(general, blas.Upper, o, m, n, m-1, n-1, ldb, func(p, i, j int) { x[i][j] = b.stored[p] })
+
+									// For DTRMM, compare the result with alpha*op(A)*B or
+									// alpha*B*op(A). For DTRSM, check that op(A)*X or
+									// X*op(A) reproduces alpha*B.
+									in, scale := b.a, alpha
+									if solve {
+										in, scale = x, 1
+									}
+									var err float64
+									for i := 0; i < m; i++ {
+										for j := 0; j < n; j++ {
+											var v, g float64
+											for l := 0; l < na; l++ {
+												var p float64
+												if s == blas.Left {
+													p = op(tA, a.a, i, l) * in[l][j]
+												} else {
+													p = in[i][l] * op(tA, a.a, l, j)
+												}
+												v += p
+												g += math.Abs(p)
+											}
+											v *= scale
+											g *= math.Abs(scale)
+											var e float64
+											if solve {
+												e = relErr(v, alpha*b.a[i][j], g)
+											} else {
+												e = relErr(x[i][j], v, g)
+											}
+											if e > err || math.IsNaN(e) {
+												err = e
+											}
+										}
+									}
+									if !t.ratio(desc, err) {
+										return
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk34 tests DSYRK.
+func (t *tester) dchk34() {
+	for _, n := range t.p.N {
+		ldc := leadingDim(n)
+		if ldc > nmax {
+			continue
+		}
+		for _, k := range t.p.N {
+			for _, tr := range transposes {
+				ma, na := n, k
+				if tr != blas.NoTrans {
+					ma, na = k, n
+				}
+				for _, o := range t.p.Orders {
+					a, lda := t.general(o, ma, na)
+					if lda == 0 {
+						continue
+					}
+					for _, ul := range uplos {
+						for _, alpha := range t.p.Alpha {
+							for _, beta := range t.p.Beta {
+								c := t.makeMatrix(symmetric, ul, blas.NonUnit, o, n, n, n-1, n-1, ldc, 0)
+								as, cs := clone(a.stored), clone(c.stored)
+
+								desc := fmt.Sprintf("%s(%s, %s, %s, %d, %d, %.1f, A, %d, %.1f, C, %d)",
+									t.name, orderName(o), uploName(ul), transName(tr), n, k, alpha, lda, beta, ldc)
+								if !t.call(desc, func() {
+									t.impl.Dsyrk(o, ul, tr, n, k, alpha, a.stored, lda, beta, c.stored, ldc)
+								}) ||
+									!t.same(desc, "A", a.stored, as) {
+									return
+								}
+								if n <= 0 {
+									if !t.same(desc, "C", c.stored, cs) {
+										return
+									}
+									continue
+								}
+								// op(A) is A for NoTrans and A^T otherwise.
+								opT := blas.Trans
+								if tr != blas.NoTrans {
+									opT = blas.NoTrans
+								}
+								err, ok := t.matrixRatio(desc, "C", symmetric, ul, o, n, n, n-1, n-1, ldc, c.stored, cs, func(i, j int) (v, g float64) {
+									for l := 0; l < k; l++ {
+										p := op(tr, a.a, i, l) * op(opT, a.a, l, j)
+										v += p
+										g += math.Abs(p)
+									}
+									return alpha*v + beta*c.a[i][j], math.Abs(alpha)*g + math.Abs(beta*c.a[i][j])
+								})
+								if !ok || !t.ratio(desc, err) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dchk35 tests DSYR2K.
+func (t *tester) dchk35() {
+	for _, n := range t.p.N {
+		ldc := leadingDim(n)
+		if ldc > nmax {
+			continue
+		}
+		for _, k := range t.p.N {
+			for _, tr := range transposes {
+				ma, na := n, k
+				if tr != blas.NoTrans {
+					ma, na = k, n
+				}
+				for _, o := range t.p.Orders {
+					a, lda := t.general(o, ma, na)
+					b, ldb := t.general(o, ma, na)
+					if lda == 0 || ldb == 0 {
+						continue
+					}
+					for _, ul := range uplos {
+						for _, alpha := range t.p.Alpha {
+							for _, beta := range t.p.Beta {
+								c := t.makeMatrix(symmetric, ul, blas.NonUnit, o, n, n, n-1, n-1, ldc, 0)
+								as, bs, cs := clone(a.stored), clone(b.stored), clone(c.stored)
+
+								desc := fmt.Sprintf("%s(%s, %s, %s, %d, %d, %.1f, A, %d, B, %d, %.1f, C, %d)",
+									t.name, orderName(o), uploName(ul), transName(tr), n, k, alpha, lda, ldb, beta, ldc)
+								if !t.call(desc, func() {
+									t.impl.Dsyr2k(o, ul, tr, n, k, alpha, a.stored, lda, b.stored, ldb, beta, c.stored, ldc)
+								}) ||
+									!t.same(desc, "A", a.stored, as) ||
+									!t.same(desc, "B", b.stored, bs) {
+									return
+								}
+								if n <= 0 {
+									if !t.same(desc, "C", c.stored, cs) {
+										return
+									}
+									continue
+								}
+								opT := blas.Trans
+								if tr != blas.NoTrans {
+									opT = blas.NoTrans
+								}
+								err, ok := t.matrixRatio(desc, "C", symmetric, ul, o, n, n, n-1, n-1, ldc, c.stored, cs, func(i, j int) (v, g float64) {
+									for l := 0; l < k; l++ {
+										p := op(tr, a.a, i, l) * op(opT, b.a, l, j)
+										q := op(tr, b.a, i, l) * op(opT, a.a, l, j)
+										v += p + q
+										g += math.Abs(p) + math.Abs(q)
+									}
+									return alpha*v + beta*c.a[i][j], math.Abs(alpha)*g + math.Abs(beta*c.a[i][j])
+								})
+								if !ok || !t.ratio(desc, err) {
+									return
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// level3ErrorExits tests that illegal arguments are rejected, following
+// DCHKE. As for the level 2 tests, only arguments that are validated by
+// the Go implementation are tested.
+func (t *tester) level3ErrorExits(name string) {
+	const bad = 0
+	var (
+		a = make([]float64, 4)
+		b = make([]float64, 4)
+		c = make([]float64, 4)
+
+		o  = blas.ColMajor
+		s  = blas.Left
+		ul = blas.Upper
+		tA = blas.NoTrans
+		d  = blas.NonUnit
+		im = t.impl
+	)
+	var calls []func()
+	switch name {
+	case "DGEMM":
+		calls = []func(){
+			func() { im.Dgemm(bad, tA, tA, 0, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dgemm(o, tA, tA, -1, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dgemm(o, tA, tA, 0, -1, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dgemm(o, tA, tA, 0, 0, -1, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dgemm(o, tA, tA, 2, 0, 0, 0, a, 1, b, 1, 0, c, 2) },
+			func() { im.Dgemm(o, tA, tA, 0, 0, 2, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dgemm(o, tA, tA, 2, 0, 0, 0, a, 2, b, 1, 0, c, 1) },
+		}
+	case "DSYMM":
+		calls = []func(){
+			func() { im.Dsymm(bad, s, ul, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsymm(o, bad, ul, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsymm(o, s, bad, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsymm(o, s, ul, -1, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsymm(o, s, ul, 0, -1, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsymm(o, s, ul, 2, 0, 0, a, 1, b, 2, 0, c, 2) },
+		}
+	case "DTRMM", "DTRSM":
+		f := im.Dtrmm
+		if name == "DTRSM" {
+			f = im.Dtrsm
+		}
+		calls = []func(){
+			func() { f(bad, s, ul, tA, d, 0, 0, 0, a, 1, b, 1) },
+			func() { f(o, bad, ul, tA, d, 0, 0, 0, a, 1, b, 1) },
+			func() { f(o, s, bad, tA, d, 0, 0, 0, a, 1, b, 1) },
+			func() { f(o, s, ul, tA, bad, 0, 0, 0, a, 1, b, 1) },
+			func() { f(o, s, ul, tA, d, -1, 0, 0, a, 1, b, 1) },
+			func() { f(o, s, ul, tA, d, 0, -1, 0, a, 1, b, 1) },
+		}
+	case "DSYRK":
+		calls = []func(){
+			func() { im.Dsyrk(bad, ul, tA, 0, 0, 0, a, 1, 0, c, 1) },
+			func() { im.Dsyrk(o, bad, tA, 0, 0, 0, a, 1, 0, c, 1) },
+			func() { im.Dsyrk(o, ul, tA, -1, 0, 0, a, 1, 0, c, 1) },
+			func() { im.Dsyrk(o, ul, tA, 0, -1, 0, a, 1, 0, c, 1) },
+			func() { im.Dsyrk(o, ul, tA, 2, 0, 0, a, 2, 0, c, 1) },
+		}
+	case "DSYR2K":
+		calls = []func(){
+			func() { im.Dsyr2k(bad, ul, tA, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsyr2k(o, bad, tA, 0, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsyr2k(o, ul, tA, -1, 0, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsyr2k(o, ul, tA, 0, -1, 0, a, 1, b, 1, 0, c, 1) },
+			func() { im.Dsyr2k(o, ul, tA, 2, 0, 0, a, 2, b, 2, 0, c, 1) },
+		}
+	}
+	t.errorExits(name, calls)
+}
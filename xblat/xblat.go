@@ -0,0 +1,861 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package xblat is a port of the Netlib reference BLAS test programs
+// dblat1, dblat2 and dblat3 to the blas.Float64 interface.
+//
+// The level 2 and level 3 testers read their parameter sets from the
+// standard dblat2.in and dblat3.in files, either in the format used by the
+// Fortran testers or in the format used by the CBLAS testers, which adds a
+// line selecting the matrix layouts to test. Example input files are
+// provided in the testdata directory. Results are written in the format
+// used by the reference testers.
+package xblat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gonum/blas"
+)
+
+const (
+	nmax   = 65    // Maximum matrix dimension.
+	incmax = 2     // Maximum absolute vector increment.
+	rogue  = -1e10 // Value placed in unreferenced storage.
+)
+
+// eps is the relative machine precision.
+var eps = math.Nextafter(1, 2) - 1
+
+// Params holds the parameters read from a dblat2.in or dblat3.in file.
+type Params struct {
+	StopOnFailure  bool
+	TestErrorExits bool
+	Orders         []blas.Order
+	Threshold      float64
+
+	N     []int
+	K     []int // Band widths; level 2 only.
+	Inc   []int // Vector increments; level 2 only.
+	Alpha []float64
+	Beta  []float64
+
+	// Routines lists the routines named in the
+	// file in order with their test flag.
+	Routines []Routine
+}
+
+// Routine is a routine name and whether it is to be tested.
+type Routine struct {
+	Name string
+	Test bool
+}
+
+// ParseLevel2 reads level 2 test parameters in dblat2.in format from r.
+func ParseLevel2(r io.Reader) (*Params, error) {
+	return parse(r, true)
+}
+
+// ParseLevel3 reads level 3 test parameters in dblat3.in format from r.
+func ParseLevel3(r io.Reader) (*Params, error) {
+	return parse(r, false)
+}
+
+func parse(r io.Reader, level2 bool) (*Params, error) {
+	var lines [][]string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		f := fields(sc.Text())
+		if len(f) != 0 {
+			lines = append(lines, f)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	in := &input{lines: lines}
+
+	// The Fortran testers name a summary file and unit before the
+	// snapshot file and unit; the CBLAS testers only name the snapshot
+	// but specify the layouts to test.
+	fortran := len(lines) > 2 && isQuoted(lines[0][0]) && isQuoted(lines[2][0])
+	if fortran {
+		in.skip(2)
+	}
+	in.skip(3) // Snapshot file, unit and rewind flag.
+
+	var p Params
+	p.StopOnFailure = in.logical()
+	p.TestErrorExits = in.logical()
+	if !fortran {
+		switch in.integer() {
+		case 0:
+			p.Orders = []blas.Order{blas.ColMajor}
+		case 1:
+			p.Orders = []blas.Order{blas.RowMajor}
+		case 2:
+			p.Orders = []blas.Order{blas.ColMajor, blas.RowMajor}
+		default:
+			in.err = errors.New("xblat: invalid layout")
+		}
+	} else {
+		p.Orders = []blas.Order{blas.ColMajor, blas.RowMajor}
+	}
+	p.Threshold = in.real()
+	p.N = in.integers(0, nmax)
+	if level2 {
+		p.K = in.integers(0, math.MaxInt32)
+		p.Inc = in.integers(-incmax, incmax)
+		for _, inc := range p.Inc {
+			if inc == 0 {
+				in.err = errors.New("xblat: zero increment")
+			}
+		}
+	}
+	p.Alpha = in.reals()
+	p.Beta = in.reals()
+	if in.err != nil {
+		return nil, in.err
+	}
+	for _, l := range in.lines[in.line:] {
+		name := strings.ToUpper(strings.TrimSpace(strings.Trim(l[0], "'")))
+		name = strings.TrimPrefix(name, "CBLAS_")
+		if len(l) < 2 {
+			return nil, fmt.Errorf("xblat: missing test flag for %s", name)
+		}
+		test, err := parseLogical(l[1])
+		if err != nil {
+			return nil, err
+		}
+		p.Routines = append(p.Routines, Routine{Name: name, Test: test})
+	}
+	return &p, nil
+}
+
+// fields splits a Fortran list-directed input line into fields, keeping
+// quoted strings intact.
+func fields(line string) []string {
+	var f []string
+	for {
+		line = strings.TrimLeft(line, " \t,")
+		if line == "" {
+			return f
+		}
+		if line[0] == '\'' {
+			end := strings.IndexByte(line[1:], '\'')
+			if end < 0 {
+				return append(f, line)
+			}
+			f = append(f, line[:end+2])
+			line = line[end+2:]
+			continue
+		}
+		end := strings.IndexAny(line, " \t,")
+		if end < 0 {
+			return append(f, line)
+		}
+		f = append(f, line[:end])
+		line = line[end:]
+	}
+}
+
+func isQuoted(s string) bool { return strings.HasPrefix(s, "'") }
+
+// input is a cursor over parsed input lines. The first error
+// encountered is held in err and subsequent reads are no-ops.
+type input struct {
+	lines [][]string
+	line  int
+	err   error
+}
+
+func (in *input) skip(n int) { in.line += n }
+
+func (in *input) next() []string {
+	if in.err != nil {
+		return nil
+	}
+	if in.line >= len(in.lines) {
+		in.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	in.line++
+	return in.lines[in.line-1]
+}
+
+func (in *input) logical() bool {
+	l := in.next()
+	if l == nil {
+		return false
+	}
+	v, err := parseLogical(l[0])
+	if err != nil && in.err == nil {
+		in.err = err
+	}
+	return v
+}
+
+func (in *input) integer() int {
+	l := in.next()
+	if l == nil {
+		return 0
+	}
+	v, err := strconv.Atoi(l[0])
+	if err != nil && in.err == nil {
+		in.err = err
+	}
+	return v
+}
+
+func (in *input) real() float64 {
+	l := in.next()
+	if l == nil {
+		return 0
+	}
+	v, err := parseReal(l[0])
+	if err != nil && in.err == nil {
+		in.err = err
+	}
+	return v
+}
+
+// integers reads a count line followed by a line of that many integers
+// within [min, max].
+func (in *input) integers(min, max int) []int {
+	n := in.integer()
+	l := in.next()
+	if in.err != nil {
+		return nil
+	}
+	if n < 1 || n > len(l) {
+		in.err = fmt.Errorf("xblat: invalid value count %d", n)
+		return nil
+	}
+	v := make([]int, n)
+	for i := range v {
+		var err error
+		v[i], err = strconv.Atoi(l[i])
+		if err != nil {
+			in.err = err
+			return nil
+		}
+		if v[i] < min || v[i] > max {
+			in.err = fmt.Errorf("xblat: value %d out of range [%d, %d]", v[i], min, max)
+			return nil
+		}
+	}
+	return v
+}
+
+// reals reads a count line followed by a line of that many reals.
+func (in *input) reals() []float64 {
+	n := in.integer()
+	l := in.next()
+	if in.err != nil {
+		return nil
+	}
+	if n < 1 || n > len(l) {
+		in.err = fmt.Errorf("xblat: invalid value count %d", n)
+		return nil
+	}
+	v := make([]float64, n)
+	for i := range v {
+		var err error
+		v[i], err = parseReal(l[i])
+		if err != nil {
+			in.err = err
+			return nil
+		}
+	}
+	return v
+}
+
+func parseLogical(s string) (bool, error) {
+	switch v := strings.ToUpper(strings.TrimPrefix(s, ".")); {
+	case strings.HasPrefix(v, "T"):
+		return true, nil
+	case strings.HasPrefix(v, "F"):
+		return false, nil
+	}
+	return false, fmt.Errorf("xblat: invalid logical %q", s)
+}
+
+// parseReal parses a Fortran real, including D exponents.
+func parseReal(s string) (float64, error) {
+	return strconv.ParseFloat(strings.NewReplacer("D", "E", "d", "e").Replace(s), 64)
+}
+
+// tester holds the state of a run of the level 2 or level 3 tests.
+type tester struct {
+	w    io.Writer
+	impl blas.Float64
+	p    *Params
+	gen  generator
+
+	name   string  // Name of the routine under test.
+	calls  int     // Number of calls made to the routine.
+	errMax float64 // Maximum test ratio seen for the routine.
+	fatal  bool    // Whether a fatal error occurred for the routine.
+	failed bool    // Whether any routine failed.
+}
+
+// header writes the parameter summary in the style of the reference testers.
+func (t *tester) header(level int) {
+	fmt.Fprintf(t.w, " TESTS OF THE DOUBLE PRECISION LEVEL %d BLAS\n\n", level)
+	fmt.Fprintf(t.w, " THE FOLLOWING PARAMETER VALUES WILL BE USED:\n")
+	fmt.Fprintf(t.w, "   FOR N          %s\n", formatInts(t.p.N))
+	if level == 2 {
+		fmt.Fprintf(t.w, "   FOR K          %s\n", formatInts(t.p.K))
+		fmt.Fprintf(t.w, "   FOR INCX AND INCY %s\n", formatInts(t.p.Inc))
+	}
+	fmt.Fprintf(t.w, "   FOR ALPHA      %s\n", formatReals(t.p.Alpha))
+	fmt.Fprintf(t.w, "   FOR BETA       %s\n", formatReals(t.p.Beta))
+	for _, o := range t.p.Orders {
+		fmt.Fprintf(t.w, "   FOR LAYOUT     %s\n", orderName(o))
+	}
+	if !t.p.TestErrorExits {
+		fmt.Fprintf(t.w, " ERROR-EXITS WILL NOT BE TESTED\n")
+	}
+	fmt.Fprintf(t.w, " ROUTINES PASS COMPUTATIONAL TESTS IF TEST RATIO IS LESS THAN%8.2f\n", t.p.Threshold)
+	fmt.Fprintf(t.w, " RELATIVE MACHINE PRECISION IS TAKEN TO BE%9.1e\n\n", eps)
+}
+
+// run runs check for each selected routine, returning whether
+// all routines passed.
+func (t *tester) run(known []string, check func(name string), errorExits func(name string)) bool {
+	selected := make(map[string]bool)
+	for _, r := range t.p.Routines {
+		if !contains(known, r.Name) {
+			fmt.Fprintf(t.w, " SUBPROGRAM NAME %-6s NOT RECOGNIZED\n ******* TESTS ABANDONED *******\n", r.Name)
+			return false
+		}
+		selected[r.Name] = r.Test
+	}
+	for _, name := range known {
+		test, ok := selected[name]
+		if !ok || !test {
+			fmt.Fprintf(t.w, " %-6s WAS NOT TESTED\n", name)
+			continue
+		}
+		if t.p.TestErrorExits {
+			errorExits(name)
+		}
+		t.name = name
+		t.calls = 0
+		t.errMax = 0
+		t.fatal = false
+		t.gen.reset()
+		check(name)
+		t.summary()
+		if t.fatal && t.p.StopOnFailure {
+			break
+		}
+	}
+	if t.failed {
+		fmt.Fprintf(t.w, "\n ******* FATAL ERROR - TESTS ABANDONED *******\n")
+	} else {
+		fmt.Fprintf(t.w, "\n END OF TESTS\n")
+	}
+	return !t.failed
+}
+
+func (t *tester) summary() {
+	switch {
+	case t.fatal:
+		t.failed = true
+	case t.errMax < t.p.Threshold:
+		fmt.Fprintf(t.w, " %-6s PASSED THE COMPUTATIONAL TESTS (%6d CALLS)\n", t.name, t.calls)
+	default:
+		t.failed = true
+		fmt.Fprintf(t.w, " %-6s COMPLETED THE COMPUTATIONAL TESTS (%6d CALLS)\n", t.name, t.calls)
+		fmt.Fprintf(t.w, " ******* BUT WITH MAXIMUM TEST RATIO%8.2f - SUSPECT *******\n", t.errMax)
+	}
+}
+
+// call makes a call to the routine under test, recovering from any panic.
+// It returns false and reports a failure if the call panicked.
+func (t *tester) call(desc string, f func()) (ok bool) {
+	t.calls++
+	defer func() {
+		r := recover()
+		if r != nil {
+			t.fail(desc, fmt.Sprintf("******* FATAL ERROR - PANIC: %v *******", r))
+			ok = false
+		}
+	}()
+	f()
+	return true
+}
+
+// fail reports a fatal error for the routine under test.
+func (t *tester) fail(desc, msg string) {
+	t.fatal = true
+	fmt.Fprintf(t.w, " %s\n", msg)
+	fmt.Fprintf(t.w, " ******* %-6s FAILED ON CALL NUMBER:\n", t.name)
+	fmt.Fprintf(t.w, " %6d: %s\n", t.calls, desc)
+}
+
+// same checks that the saved copy of an input parameter is unchanged,
+// reporting a failure if it was not.
+func (t *tester) same(desc, param string, got, want []float64) bool {
+	for i := range want {
+		if !equal(got[i], want[i]) {
+			t.fail(desc, fmt.Sprintf("******* FATAL ERROR - PARAMETER %s WAS CHANGED INCORRECTLY *******", param))
+			return false
+		}
+	}
+	return true
+}
+
+// ratio records a test ratio, reporting a failure if the computed result
+// is less than half accurate.
+func (t *tester) ratio(desc string, err float64) bool {
+	if err > t.errMax {
+		t.errMax = err
+	}
+	if err*math.Sqrt(eps) >= 1 || math.IsNaN(err) {
+		t.fail(desc, "******* FATAL ERROR - COMPUTED RESULT IS LESS THAN HALF ACCURATE *******")
+		return false
+	}
+	return true
+}
+
+// errorExit reports whether f panics with a cblas error.
+func errorExit(f func()) (ok bool) {
+	defer func() {
+		r := recover()
+		s, isString := r.(string)
+		ok = isString && strings.HasPrefix(s, "cblas: ")
+	}()
+	f()
+	return false
+}
+
+// errorExits runs each of the calls, which must all panic, reporting
+// the result.
+func (t *tester) errorExits(name string, calls []func()) {
+	for i, f := range calls {
+		if !errorExit(f) {
+			t.failed = true
+			fmt.Fprintf(t.w, " ******* %-6s FAILED THE TESTS OF ERROR-EXITS *******\n", name)
+			fmt.Fprintf(t.w, " ******* ERROR-EXIT %d WAS NOT TAKEN *******\n", i+1)
+			return
+		}
+	}
+	fmt.Fprintf(t.w, " %-6s PASSED THE TESTS OF ERROR-EXITS\n", name)
+}
+
+func equal(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+func formatInts(v []int) string {
+	var b strings.Builder
+	for _, e := range v {
+		fmt.Fprintf(&b, "%6d", e)
+	}
+	return b.String()
+}
+
+func formatReals(v []float64) string {
+	var b strings.Builder
+	for _, e := range v {
+		fmt.Fprintf(&b, "%7.1f", e)
+	}
+	return b.String()
+}
+
+func orderName(o blas.Order) string {
+	if o == blas.RowMajor {
+		return "RowMajor"
+	}
+	return "ColMajor"
+}
+
+func transName(t blas.Transpose) string {
+	switch t {
+	case blas.NoTrans:
+		return "NoTrans"
+	case blas.Trans:
+		return "Trans"
+	}
+	return "ConjTrans"
+}
+
+func uploName(ul blas.Uplo) string {
+	if ul == blas.Upper {
+		return "Upper"
+	}
+	return "Lower"
+}
+
+func diagName(d blas.Diag) string {
+	if d == blas.Unit {
+		return "Unit"
+	}
+	return "NonUnit"
+}
+
+func sideName(s blas.Side) string {
+	if s == blas.Left {
+		return "Left"
+	}
+	return "Right"
+}
+
+// generator is the pseudo-random number generator used by
+// the reference testers (DBEG).
+type generator struct {
+	i, ic int
+}
+
+func (g *generator) reset() { *g = generator{i: 7} }
+
+// next returns a value in [-0.5, 0.5].
+func (g *generator) next() float64 {
+	const mi = 891
+	g.ic++
+	for {
+		g.i = (g.i * mi) % 1000
+		if g.ic < 5 {
+			break
+		}
+		g.ic = 0
+	}
+	return float64(g.i-500) / 1001
+}
+
+// kind describes the structure and storage of a test matrix.
+type kind int
+
+const (
+	general kind = iota
+	generalBand
+	symmetric
+	symmetricBand
+	symmetricPacked
+	triangular
+	triangularBand
+	triangularPacked
+)
+
+func (k kind) isSymmetric() bool {
+	return k == symmetric || k == symmetricBand || k == symmetricPacked
+}
+
+func (k kind) isTriangular() bool {
+	return k == triangular || k == triangularBand || k == triangularPacked
+}
+
+func (k kind) isPacked() bool {
+	return k == symmetricPacked || k == triangularPacked
+}
+
+// matrix is a test matrix held both in full form
+// and in the storage format passed to the BLAS.
+type matrix struct {
+	a      [][]float64 // a[i][j] is the (i, j) element.
+	stored []float64
+}
+
+// makeMatrix generates an m×n test matrix of the given kind following
+// DMAKE. Elements outside the band given by kl and ku are zero, and the
+// elements of stored that are not part of the matrix are set to rogue.
+func (t *tester) makeMatrix(k kind, ul blas.Uplo, d blas.Diag, o blas.Order, m, n, kl, ku, lda int, transl float64) matrix {
+	upper := ul == blas.Upper
+	unit := k.isTriangular() && d == blas.Unit
+	a := make([][]float64, m)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	// Generate in column-major order to follow the reference testers.
+	for j := 0; j < n; j++ {
+		for i := 0; i < m; i++ {
+			if k.isSymmetric() || k.isTriangular() {
+				if (upper && i > j) || (!upper && i < j) {
+					continue
+				}
+			}
+			if (i <= j && j-i <= ku) || (i >= j && i-j <= kl) {
+				a[i][j] = t.gen.next() + transl
+			} else {
+				a[i][j] = 0
+			}
+			if i != j {
+				if k.isSymmetric() {
+					a[j][i] = a[i][j]
+				} else if k.isTriangular() {
+					a[j][i] = 0
+				}
+			}
+		}
+		if k.isTriangular() {
+			a[j][j]++
+		}
+		if unit {
+			a[j][j] = 1
+		}
+	}
+
+	size := nmax * nmax
+	if lda*max(m, n) > size {
+		size = lda * max(m, n)
+	}
+	stored := make([]float64, size)
+	for i := range stored {
+		stored[i] = rogue
+	}
+	if k.isPacked() {
+		var p int
+		for _, ij := range packedOrder(o, ul, n) {
+			i, j := ij[0], ij[1]
+			if !(unit && i == j) {
+				stored[p] = a[i][j]
+			}
+			p++
+		}
+		return matrix{a: a, stored: stored}
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if !inStorage(k, ul, i, j, kl, ku) || (unit && i == j) {
+				continue
+			}
+			stored[index(k, ul, o, lda, i, j, kl, ku)] = a[i][j]
+		}
+	}
+	return matrix{a: a, stored: stored}
+}
+
+// inStorage returns whether the (i, j) element is held in storage
+// for a matrix of the given kind.
+func inStorage(k kind, ul blas.Uplo, i, j, kl, ku int) bool {
+	if k.isSymmetric() || k.isTriangular() {
+		if (ul == blas.Upper && i > j) || (ul == blas.Lower && i < j) {
+			return false
+		}
+	}
+	switch k {
+	case generalBand, symmetricBand, triangularBand:
+		return j-i <= ku && i-j <= kl
+	}
+	return true
+}
+
+// index returns the storage index of the (i, j) element.
+func index(k kind, ul blas.Uplo, o blas.Order, lda, i, j, kl, ku int) int {
+	switch k {
+	case generalBand:
+		if o == blas.RowMajor {
+			return i*lda + kl + j - i
+		}
+		return ku + i - j + j*lda
+	case symmetricBand, triangularBand:
+		// kl and ku are equal to the band width of
+		// the stored triangle.
+		if o == blas.RowMajor {
+			if ul == blas.Upper {
+				return i*lda + j - i
+			}
+			return i*lda + kl + j - i
+		}
+		if ul == blas.Upper {
+			return ku + i - j + j*lda
+		}
+		return i - j + j*lda
+	}
+	if o == blas.RowMajor {
+		return i*lda + j
+	}
+	return i + j*lda
+}
+
+// packedOrder returns the (i, j) indices of the triangle of an n×n
+// matrix in the order they are held in packed storage.
+func packedOrder(o blas.Order, ul blas.Uplo, n int) [][2]int {
+	var idx [][2]int
+	for outer := 0; outer < n; outer++ {
+		lo, hi := 0, outer
+		if (ul == blas.Lower) == (o == blas.ColMajor) {
+			lo, hi = outer, n-1
+		}
+		for inner := lo; inner <= hi; inner++ {
+			if o == blas.ColMajor {
+				idx = append(idx, [2]int{inner, outer})
+			} else {
+				idx = append(idx, [2]int{outer, inner})
+			}
+		}
+	}
+	return idx
+}
+
+// makeVector generates a vector of length n stored with increment inc
+// with rogue values in the gaps. If zero is true, the middle element is
+// set to zero when n > 1 as is done by the reference testers for vectors
+// that are not updated.
+func (t *tester) makeVector(n, inc int, transl float64, zero bool) []float64 {
+	v := make([]float64, nmax*incmax)
+	for i := range v {
+		v[i] = rogue
+	}
+	for i := 0; i < n; i++ {
+		v[i*abs(inc)] = t.gen.next() + transl
+	}
+	if zero && n > 1 {
+		v[(n/2-1)*abs(inc)] = 0
+	}
+	return v
+}
+
+// vectorIndex returns the storage index of the ith element
+// as seen by a BLAS routine of a vector of length n.
+func vectorIndex(i, n, inc int) int {
+	if inc < 0 {
+		return (n - 1 - i) * -inc
+	}
+	return i * inc
+}
+
+// vector returns the n elements of v as seen by a BLAS routine.
+func vector(v []float64, n, inc int) []float64 {
+	e := make([]float64, n)
+	for i := range e {
+		e[i] = v[vectorIndex(i, n, inc)]
+	}
+	return e
+}
+
+func clone(s []float64) []float64 {
+	return append([]float64(nil), s...)
+}
+
+// leadingDim returns the leading dimension used for a matrix with at
+// least n elements in its leading dimension. As in the reference
+// testers it is one larger than needed when possible.
+func leadingDim(n int) int {
+	if n < nmax {
+		n++
+	}
+	return max(n, 1)
+}
+
+// majorDims returns the number of rows and columns of storage for an m×n
+// matrix in the given order, the first being the leading dimension.
+func majorDims(o blas.Order, m, n int) (lead, other int) {
+	if o == blas.RowMajor {
+		return n, m
+	}
+	return m, n
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// sameExcept checks that the elements of v that are not among the n
+// elements of the vector with increment inc are unchanged from orig.
+func (t *tester) sameExcept(desc, param string, v, orig []float64, n, inc int) bool {
+	ref := make([]bool, len(v))
+	for i := 0; i < n; i++ {
+		ref[vectorIndex(i, n, inc)] = true
+	}
+	for i := range v {
+		if !ref[i] && !equal(v[i], orig[i]) {
+			t.fail(desc, fmt.Sprintf("******* FATAL ERROR - PARAMETER %s WAS CHANGED OUTSIDE THE VECTOR *******", param))
+			return false
+		}
+	}
+	return true
+}
+
+// positions calls f with the storage index and the row and column of each
+// stored element of an m×n matrix of the given kind.
+func positions(k kind, ul blas.Uplo, o blas.Order, m, n, kl, ku, lda int, f func(p, i, j int)) {
+	if k.isPacked() {
+		for p, ij := range packedOrder(o, ul, n) {
+			f(p, ij[0], ij[1])
+		}
+		return
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if inStorage(k, ul, i, j, kl, ku) {
+				f(index(k, ul, o, lda, i, j, kl, ku), i, j)
+			}
+		}
+	}
+}
+
+// sameOutside checks that the elements of got that are not stored
+// elements of an m×n matrix of the given kind are unchanged from orig.
+func (t *tester) sameOutside(desc, param string, k kind, ul blas.Uplo, o blas.Order, m, n, kl, ku, lda int, got, orig []float64) bool {
+	ref := make([]bool, len(got))
+	positions(k, ul, o, m, n, kl, ku, lda, func(p, _, _ int) { ref[p] = true })
+	for p := range got {
+		if !ref[p] && !equal(got[p], orig[p]) {
+			t.fail(desc, fmt.Sprintf("******* FATAL ERROR - PARAMETER %s WAS CHANGED OUTSIDE THE MATRIX *******", param))
+			return false
+		}
+	}
+	return true
+}
+
+// matrixRatio returns the test ratio for the stored elements of an updated
+// m×n matrix of the given kind. For each stored element, want returns the
+// expected value and the sum of the magnitudes of the terms contributing to
+// it. matrixRatio also checks that storage outside the matrix is unchanged
+// from orig, reporting a failure if it was not.
+func (t *tester) matrixRatio(desc, param string, k kind, ul blas.Uplo, o blas.Order, m, n, kl, ku, lda int, got, orig []float64, want func(i, j int) (v, g float64)) (ratio float64, ok bool) {
+	positions(k, ul, o, m, n, kl, ku, lda, func(p, i, j int) {
+		v, g := want(i, j)
+		e := relErr(got[p], v, g)
+		if e > ratio || math.IsNaN(e) {
+			ratio = e
+		}
+	})
+	return ratio, t.sameOutside(desc, param, k, ul, o, m, n, kl, ku, lda, got, orig)
+}
+
+// relErr returns the error of got relative to want in units of eps
+// scaled by g, the magnitude of the terms contributing to want.
+func relErr(got, want, g float64) float64 {
+	e := math.Abs(got-want) / eps
+	if g != 0 {
+		e /= g
+	}
+	return e
+}
@@ -0,0 +1,53 @@
+// Copyright ©2012 The bíogo.blas Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package xblat_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gonum/blas/cblas"
+	"github.com/gonum/blas/cblas/xblat"
+)
+
+func TestLevel1(t *testing.T) {
+	var buf bytes.Buffer
+	if !xblat.Level1(&buf, cblas.Blas{}) {
+		t.Errorf("level 1 tests failed:\n%s", &buf)
+	}
+}
+
+func TestLevel2(t *testing.T) {
+	p := parse(t, "dblat2.in", xblat.ParseLevel2)
+	var buf bytes.Buffer
+	if !xblat.Level2(&buf, p, cblas.Blas{}) {
+		t.Errorf("level 2 tests failed:\n%s", &buf)
+	}
+}
+
+func TestLevel3(t *testing.T) {
+	p := parse(t, "dblat3.in", xblat.ParseLevel3)
+	var buf bytes.Buffer
+	if !xblat.Level3(&buf, p, cblas.Blas{}) {
+		t.Errorf("level 3 tests failed:\n%s", &buf)
+	}
+}
+
+// parse reads the parameters in the named file of the testdata directory.
+func parse(t *testing.T, name string, parse func(io.Reader) (*xblat.Params, error)) *xblat.Params {
+	f, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	p, err := parse(f)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return p
+}